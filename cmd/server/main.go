@@ -0,0 +1,312 @@
+// Command server runs the JollyRogerUserService gRPC daemon.
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/buildinfo"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/config"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/gormzap"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/health"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/logger"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/resilience"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/server"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/service"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/migrations"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/adminpb"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/authpb"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+func main() {
+	cfg := config.Load()
+	if err := cfg.Validate(); err != nil {
+		panic(err)
+	}
+
+	log, err := logger.NewLogger(logger.Config{
+		Level:           cfg.Logger.Level,
+		Encoding:        logger.Encoding(cfg.Logger.Encoding),
+		SamplingEnabled: cfg.Logger.SamplingEnabled,
+	})
+	if err != nil {
+		panic(err)
+	}
+	defer log.Sync()
+
+	db, err := connectPostgres(cfg.Startup, cfg.Postgres, log, gormzap.LevelFromString(cfg.Logger.GormLevel), cfg.Resilience.SlowQueryThreshold)
+	if err != nil {
+		log.Fatal("connect to postgres", zap.Error(err))
+	}
+
+	if err := migrations.RunMigrations(context.Background(), db); err != nil {
+		log.Fatal("run migrations", zap.Error(err))
+	}
+
+	redisClient, err := connectRedis(cfg.Startup, cfg.Redis)
+	if err != nil {
+		if !cfg.Startup.AllowDegradedRedisStart {
+			log.Fatal("connect to redis", zap.Error(err))
+		}
+		log.Warn("starting in degraded mode: redis is unreachable, caching and idempotency are disabled", zap.Error(err))
+		redisClient = redisClientFromConfig(cfg.Redis)
+	}
+
+	userRepo := repository.NewResilientUserRepository(
+		repository.NewPostgresUserRepository(db),
+		resilience.Config{
+			SlowQueryThreshold:  cfg.Resilience.SlowQueryThreshold,
+			MaxOperationTimeout: cfg.Resilience.MaxOperationTimeout,
+		},
+		log,
+	)
+	cacheRepo := repository.NewResilientCacheRepository(
+		repository.NewCacheRepositoryWithTTLs(redisClient, cfg.Redis.KeyPrefix, cacheCodecFromConfig(cfg.Redis.Codec), cfg.Redis.UserCacheSoftTTL, cfg.Redis.UserCacheHardTTL),
+		cfg.Redis.L1Size, cfg.Redis.L1TTL,
+		repository.CacheWritePolicy(cfg.Redis.WritePolicy), cfg.Redis.WriteBehindQueueSize,
+	)
+	prefRepo := repository.NewPostgresPreferenceRepository(db)
+	locationRepo := repository.NewPostgresLocationRepository(db, repository.GeoBackend(cfg.Geo.Backend))
+	outboxRepo := repository.NewPostgresOutboxRepository(db)
+
+	maintenance := service.NewMaintenanceMode()
+	userService := service.NewUserService(userRepo, prefRepo, cacheRepo, log, cfg.User.MaxPreferencesPerUser, service.GeoLimits{
+		MaxRadiusKm:           cfg.Geo.MaxRadiusKm,
+		MaxResultLimit:        cfg.Geo.MaxResultLimit,
+		CellPrecision:         cfg.Geo.CellPrecision,
+		MaxConcurrentSearches: cfg.Geo.MaxConcurrentSearches,
+		SearchAcquireTimeout:  cfg.Geo.SearchAcquireTimeout,
+	}, maintenance, locationRepo, service.LocationDebounceConfig{
+		MinDistanceMeters: cfg.Location.DebounceMinDistanceMeters,
+		MinInterval:       cfg.Location.DebounceMinInterval,
+	}, service.FeatureFlagConfig{
+		AllowedKeys: cfg.User.FeatureFlagKeys,
+	}, cfg.User.OnlineWindow, cfg.User.AllowEmptyUsername)
+	adminService := service.NewAdminService(userRepo, prefRepo, locationRepo, cacheRepo, log, maintenance)
+	authService := service.NewAuthService(cacheRepo.CacheRepository, log)
+
+	sigusr1 := make(chan os.Signal, 1)
+	signal.Notify(sigusr1, syscall.SIGUSR1)
+	go func() {
+		for range sigusr1 {
+			enabled := !maintenance.Enabled()
+			maintenance.SetEnabled(enabled)
+			log.Info("toggled maintenance mode via SIGUSR1", zap.Bool("enabled", enabled))
+		}
+	}()
+
+	listenAddr, err := server.BuildListenAddr(cfg.GRPC.Host, cfg.GRPC.Port)
+	if err != nil {
+		log.Fatal("build listen address", zap.Error(err))
+	}
+	lis, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		log.Fatal("listen", zap.Error(err))
+	}
+
+	refresher := health.NewRefresher(health.NewChecker(db, redisClient), cfg.Health.RefreshInterval)
+	refresherCtx, stopRefresher := context.WithCancel(context.Background())
+	defer stopRefresher()
+	go refresher.Run(refresherCtx)
+
+	cacheSweeper := service.NewCacheSweeper(userRepo, cacheRepo, log, service.CacheSweeperConfig{
+		Interval:            cfg.CacheSweep.Interval,
+		InactivityThreshold: cfg.CacheSweep.InactivityThreshold,
+	})
+	sweeperCtx, stopSweeper := context.WithCancel(context.Background())
+	defer stopSweeper()
+	go cacheSweeper.Run(sweeperCtx)
+
+	statsRefresher := service.NewStatsRefresher(userRepo, cacheRepo, log, service.StatsRefresherConfig{
+		Interval: cfg.StatsRefresh.Interval,
+	})
+	statsRefresherCtx, stopStatsRefresher := context.WithCancel(context.Background())
+	defer stopStatsRefresher()
+	go statsRefresher.Run(statsRefresherCtx)
+
+	invalidationCtx, stopInvalidationListener := context.WithCancel(context.Background())
+	defer stopInvalidationListener()
+	go cacheRepo.RunInvalidationListener(invalidationCtx, log)
+
+	outboxRelay := service.NewOutboxRelay(outboxRepo, redisClient, log, service.OutboxRelayConfig{})
+	outboxRelayCtx, stopOutboxRelay := context.WithCancel(context.Background())
+	defer stopOutboxRelay()
+	go outboxRelay.Run(outboxRelayCtx)
+
+	inFlight := &server.InFlightTracker{}
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			server.APIVersionInterceptor(cfg.APIVersion.CurrentVersion, cfg.APIVersion.MinSupportedVersion),
+			server.DefaultDeadlineInterceptor(nil, cfg.GRPC.DefaultRequestDeadline),
+			inFlight.UnaryInterceptor,
+			server.LatencyUnaryInterceptor,
+			server.LocaleUnaryInterceptor,
+		),
+		grpc.StreamInterceptor(inFlight.StreamInterceptor),
+		server.KeepaliveServerOption(server.KeepaliveOptions{
+			Time:    cfg.GRPC.KeepaliveTime,
+			Timeout: cfg.GRPC.KeepaliveTimeout,
+		}),
+	}
+	serverOpts = append(serverOpts, server.MessageSizeServerOptions(server.MessageSizeOptions{
+		MaxRecvMsgSize: cfg.GRPC.MaxRecvMsgSize,
+		MaxSendMsgSize: cfg.GRPC.MaxSendMsgSize,
+	})...)
+	if cfg.TLS.Enabled {
+		creds, err := server.LoadTLSCredentials(server.TLSOptions{
+			CertFile:          cfg.TLS.CertFile,
+			KeyFile:           cfg.TLS.KeyFile,
+			ClientCAFile:      cfg.TLS.ClientCAFile,
+			RequireClientCert: cfg.TLS.RequireClientCert,
+		})
+		if err != nil {
+			log.Fatal("load TLS credentials", zap.Error(err))
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
+	userpb.RegisterUserServiceServer(grpcServer, userService)
+	adminpb.RegisterAdminServiceServer(grpcServer, adminService)
+	authpb.RegisterAuthServiceServer(grpcServer, authService)
+	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer(refresher, maintenance))
+	registerReflection(grpcServer, cfg.GRPC.EnableReflection)
+
+	graceful := server.NewGracefulShutdown(log)
+	graceful.AddShutdownFunc("flush_pending_locations", func() error {
+		return userService.FlushAllPendingLocations(context.Background())
+	})
+	graceful.AddShutdownFunc("stop_activity_recorder", func() error {
+		userService.StopActivityRecorder()
+		return nil
+	})
+	graceful.AddShutdownFunc("stop_cache_sweeper", func() error {
+		stopSweeper()
+		return nil
+	})
+	graceful.AddShutdownFunc("drain_grpc_server", func() error {
+		server.Drain(grpcServer, inFlight, cfg.Shutdown.DrainTimeout)
+		return nil
+	})
+	graceful.AddShutdownFunc("flush_write_behind_cache", func() error {
+		cacheRepo.Close()
+		return nil
+	})
+	graceful.AddShutdownFunc("close_redis", func() error {
+		return redisClient.Close()
+	})
+	graceful.AddShutdownFunc("close_postgres", func() error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.Close()
+	})
+
+	shutdown := make(chan os.Signal, 1)
+	signal.Notify(shutdown, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		sig := <-shutdown
+		log.Info("shutting down", zap.String("signal", sig.String()))
+		graceful.Shutdown()
+	}()
+
+	log.Info("starting JollyRogerUserService",
+		zap.String("addr", listenAddr),
+		zap.String("version", buildinfo.Version),
+		zap.String("git_commit", buildinfo.GitCommit),
+		zap.String("build_time", buildinfo.BuildTime),
+		zap.String("go_version", buildinfo.GoVersion),
+	)
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatal("serve", zap.Error(err))
+	}
+}
+
+// reflectionServiceName is the gRPC service name grpc-go's reflection
+// package registers under, used by tests to check whether
+// registerReflection actually registered it.
+const reflectionServiceName = "grpc.reflection.v1alpha.ServerReflection"
+
+// registerReflection registers the gRPC reflection service on s when
+// enabled, so tools like grpcurl can discover the API without a local
+// copy of the .proto files. See config.GRPCConfig.EnableReflection for
+// why this is gated rather than always on.
+func registerReflection(s *grpc.Server, enabled bool) {
+	if enabled {
+		reflection.Register(s)
+	}
+}
+
+// cacheCodecFromConfig resolves CACHE_CODEC to a repository.CacheCodec,
+// falling back to the JSON default for an unrecognized value.
+func cacheCodecFromConfig(name string) repository.CacheCodec {
+	switch name {
+	case "gob":
+		return repository.GobCodec
+	default:
+		return repository.JSONCodec
+	}
+}
+
+// connectPostgres opens the Postgres connection, retrying with backoff up
+// to cfg.MaxAttempts times before giving up, so a database that comes up
+// a little late at boot doesn't crash the process on the first attempt.
+// GORM's own SQL logging is routed through log at gormLevel instead of
+// its default logger, so query errors and slow queries show up as
+// structured log lines alongside the rest of the service's output.
+func connectPostgres(cfg config.StartupConfig, pgCfg config.PostgresConfig, log *zap.Logger, gormLevel gormlogger.LogLevel, slowQueryThreshold time.Duration) (*gorm.DB, error) {
+	var db *gorm.DB
+	err := resilience.WithRetry(context.Background(), "connect_postgres", cfg.MaxAttempts-1, cfg.Backoff, func() error {
+		var err error
+		db, err = gorm.Open(postgres.Open(pgCfg.BuildDSN()), &gorm.Config{
+			Logger: gormzap.New(log, gormLevel, slowQueryThreshold),
+		})
+		return err
+	})
+	return db, err
+}
+
+// connectRedis is like connectPostgres, but for Redis. It pings the
+// connection so a Redis that isn't actually reachable yet is retried
+// rather than being handed back as a lazily-failing client.
+func connectRedis(cfg config.StartupConfig, redisCfg config.RedisConfig) (*redis.Client, error) {
+	var client *redis.Client
+	err := resilience.WithRetry(context.Background(), "connect_redis", cfg.MaxAttempts-1, cfg.Backoff, func() error {
+		client = redisClientFromConfig(redisCfg)
+		return client.Ping(context.Background()).Err()
+	})
+	return client, err
+}
+
+// redisClientFromConfig translates config.RedisConfig into a
+// *redis.Client with go-redis's own reconnection behavior enabled, so
+// the client keeps working across a Redis restart without being
+// recreated.
+func redisClientFromConfig(redisCfg config.RedisConfig) *redis.Client {
+	return repository.NewRedisClient(repository.RedisClientOptions{
+		Addr:            redisCfg.Addr,
+		Password:        redisCfg.Password,
+		DB:              redisCfg.DB,
+		MaxRetries:      redisCfg.MaxRetries,
+		MinRetryBackoff: redisCfg.MinRetryBackoff,
+		MaxRetryBackoff: redisCfg.MaxRetryBackoff,
+		DialTimeout:     redisCfg.DialTimeout,
+		ReadTimeout:     redisCfg.ReadTimeout,
+		WriteTimeout:    redisCfg.WriteTimeout,
+	})
+}
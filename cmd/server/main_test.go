@@ -0,0 +1,25 @@
+package main
+
+import (
+	"testing"
+
+	"google.golang.org/grpc"
+)
+
+func TestRegisterReflection_RegistersTheReflectionServiceWhenEnabled(t *testing.T) {
+	s := grpc.NewServer()
+	registerReflection(s, true)
+
+	if _, ok := s.GetServiceInfo()[reflectionServiceName]; !ok {
+		t.Fatalf("expected the reflection service to be registered")
+	}
+}
+
+func TestRegisterReflection_SkipsTheReflectionServiceWhenDisabled(t *testing.T) {
+	s := grpc.NewServer()
+	registerReflection(s, false)
+
+	if _, ok := s.GetServiceInfo()[reflectionServiceName]; ok {
+		t.Fatalf("expected the reflection service not to be registered")
+	}
+}
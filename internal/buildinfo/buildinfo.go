@@ -0,0 +1,23 @@
+// Package buildinfo holds version metadata stamped into the binary at
+// build time, for incident triage ("what build is actually running").
+package buildinfo
+
+import "runtime"
+
+// Version, GitCommit, and BuildTime are injected via -ldflags at build
+// time, e.g.:
+//
+//	go build -ldflags "-X .../internal/buildinfo.Version=1.4.0 \
+//	  -X .../internal/buildinfo.GitCommit=$(git rev-parse HEAD) \
+//	  -X .../internal/buildinfo.BuildTime=$(date -u +%FT%TZ)"
+//
+// They default to placeholder values for local builds that skip
+// ldflags.
+var (
+	Version   = "dev"
+	GitCommit = "unknown"
+	BuildTime = "unknown"
+)
+
+// GoVersion is the Go toolchain version this binary was built with.
+var GoVersion = runtime.Version()
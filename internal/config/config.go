@@ -0,0 +1,800 @@
+// Package config loads runtime configuration for JollyRogerUserService
+// from the environment, optionally seeded from a CONFIG_FILE (see
+// loadFileConfig).
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PostgresConfig holds connection settings for the primary Postgres
+// database.
+type PostgresConfig struct {
+	// DSN is the base libpq connection string (e.g.
+	// "host=localhost user=jollyroger dbname=jollyroger sslmode=disable").
+	DSN string
+
+	// StatementTimeout bounds how long a single query may run before
+	// Postgres cancels it, so a runaway query (e.g. a pathological geo
+	// search) can't hold a connection open indefinitely. <= 0 leaves
+	// statement_timeout unset.
+	StatementTimeout time.Duration
+}
+
+// BuildDSN returns cfg.DSN with a statement_timeout option appended when
+// StatementTimeout is set, so callers have one connection string to hand
+// to gorm.Open rather than layering the option on separately.
+func (cfg PostgresConfig) BuildDSN() string {
+	if cfg.StatementTimeout <= 0 {
+		return cfg.DSN
+	}
+	option := fmt.Sprintf("options='-c statement_timeout=%d'", cfg.StatementTimeout.Milliseconds())
+	if cfg.DSN == "" {
+		return option
+	}
+	return cfg.DSN + " " + option
+}
+
+// RedisConfig holds connection settings for the Redis cache used both as
+// a read-through cache and as the idempotency-key store.
+type RedisConfig struct {
+	Addr     string
+	Password string
+	DB       int
+
+	// IdempotencyTTL bounds how long a CreateUser idempotency key is
+	// remembered before a replay is treated as a brand-new request.
+	IdempotencyTTL time.Duration
+
+	// KeyPrefix is prepended to every key this service writes to Redis,
+	// e.g. "prod:users:". It lets several environments or tenants share
+	// one Redis instance without key collisions.
+	KeyPrefix string
+
+	// MultiTenant requires KeyPrefix to be set; it exists so a
+	// misconfigured shared-Redis deployment fails fast at startup
+	// instead of silently mixing tenants' data.
+	MultiTenant bool
+
+	// L1Size and L1TTL configure the in-process LRU cache that sits in
+	// front of Redis for the hottest reads. See
+	// repository.defaultL1Size/defaultL1TTL for the fallback when unset.
+	L1Size int
+	L1TTL  time.Duration
+
+	// UserCacheSoftTTL and UserCacheHardTTL implement stale-while-revalidate
+	// for GetUser: UserCacheSoftTTL is the TTL of the normal cache entry,
+	// and UserCacheHardTTL is the TTL of its fallback copy, served
+	// immediately (marked stale) and refreshed from Postgres in the
+	// background once the normal entry expires. See
+	// repository.defaultUserCacheSoftTTL/defaultUserCacheHardTTL for the
+	// fallback when unset.
+	UserCacheSoftTTL time.Duration
+	UserCacheHardTTL time.Duration
+
+	// Codec selects the CacheCodec CacheRepository serializes values
+	// with ("json" or "gob"). It is folded into cache keys, so changing
+	// it is safe to roll out without corrupting reads of values written
+	// by the previous codec.
+	Codec string
+
+	// MaxRetries, MinRetryBackoff and MaxRetryBackoff configure
+	// go-redis's own per-command retry behavior, so a client that loses
+	// its connection (e.g. Redis restarting) recovers transparently
+	// instead of needing to be recreated.
+	MaxRetries      int
+	MinRetryBackoff time.Duration
+	MaxRetryBackoff time.Duration
+
+	// DialTimeout, ReadTimeout and WriteTimeout bound how long a single
+	// connection attempt or command may take before go-redis gives up
+	// and, where retries remain, tries again.
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+
+	// WritePolicy selects how ResilientCacheRepository.SetUser applies a
+	// write ("write-through", the default, or "write-behind"). See
+	// repository.CacheWritePolicy for the tradeoff.
+	WritePolicy string
+
+	// WriteBehindQueueSize bounds the channel a write-behind SetUser
+	// enqueues onto. See repository.defaultWriteBehindQueueSize for the
+	// fallback when unset.
+	WriteBehindQueueSize int
+}
+
+// LoggerConfig controls the process-wide logger. See logger.Config for
+// field semantics; this mirrors it so the env-loading logic lives in one
+// place.
+type LoggerConfig struct {
+	Level           string
+	Encoding        string
+	SamplingEnabled bool
+	// GormLevel is the SQL log level GORM's zap adapter runs at (silent,
+	// error, warn, info). See gormzap.LevelFromString.
+	GormLevel string
+}
+
+// HealthConfig controls the background dependency-health refresher.
+type HealthConfig struct {
+	// RefreshInterval is how often the refresher re-probes Postgres and
+	// Redis in the background. See health.DefaultRefreshInterval for the
+	// fallback when unset.
+	RefreshInterval time.Duration
+}
+
+// UserConfig controls limits on user-owned data.
+type UserConfig struct {
+	// MaxPreferencesPerUser bounds how many preference tags a single
+	// user may accumulate, so preferences can't be used to grow an
+	// unbounded amount of state per account.
+	MaxPreferencesPerUser int
+
+	// FeatureFlagKeys is the allowlist of keys SetUserFeature accepts.
+	// A key outside this list is rejected, so an experiment can't be
+	// toggled under a name nothing actually reads.
+	FeatureFlagKeys []string
+
+	// OnlineWindow bounds how recently a user must have been active to be
+	// reported as online in GetUser/GetUserFresh. Zero falls back to
+	// service.defaultOnlineWindow.
+	OnlineWindow time.Duration
+
+	// AllowEmptyUsername controls whether UpdateUsername accepts an
+	// empty username. It defaults to false, since until field masks
+	// land an empty username can only mean "clear it", which is rarely
+	// what a caller actually wants.
+	AllowEmptyUsername bool
+}
+
+// GeoConfig bounds the parameters a client can supply to a
+// FindNearbyUsers search, so an oversized radius or limit can't turn a
+// single request into a full table/keyspace scan.
+type GeoConfig struct {
+	// MaxRadiusKm is the largest search radius a request may specify;
+	// larger values are clamped down to it.
+	MaxRadiusKm float64
+
+	// MaxResultLimit is the largest number of results a request may
+	// ask for; larger values are clamped down to it.
+	MaxResultLimit int
+
+	// CellPrecision controls how coarsely a search point is bucketed
+	// before its result is cached. See
+	// repository.defaultGeoCellPrecision for the tradeoff and the
+	// fallback used when this is <= 0.
+	CellPrecision int
+
+	// MaxConcurrentSearches bounds how many FindNearbyUsers calls may
+	// run at once, so a flood of searches can't exhaust the DB pool
+	// that cheaper endpoints also depend on.
+	MaxConcurrentSearches int
+
+	// SearchAcquireTimeout bounds how long a FindNearbyUsers call
+	// waits for a free bulkhead slot before failing with
+	// ResourceExhausted.
+	SearchAcquireTimeout time.Duration
+
+	// Backend selects the SQL formula PostgresLocationRepository uses
+	// for a radius search: "haversine" (portable, the default) or
+	// "postgis" (requires the PostGIS extension and a GiST index, see
+	// docs/postgis-migration.md, but lets the query use the index
+	// instead of scanning every row).
+	Backend string
+}
+
+// CacheSweepConfig controls the background job that evicts cache
+// entries for users who have gone inactive.
+type CacheSweepConfig struct {
+	// Interval is how often the sweeper scans for inactive users.
+	Interval time.Duration
+
+	// InactivityThreshold is how long a user must have gone without
+	// activity before their cache entry is evicted.
+	InactivityThreshold time.Duration
+}
+
+// StatsRefreshConfig controls the background job that recomputes and
+// caches the service-wide user count.
+type StatsRefreshConfig struct {
+	// Interval is how often the refresher recomputes the user count.
+	Interval time.Duration
+}
+
+// GRPCConfig controls cross-cutting gRPC server behavior.
+type GRPCConfig struct {
+	// Host is the interface the gRPC listener binds to. Empty (the
+	// default) binds all interfaces; set it to e.g. "127.0.0.1" or a
+	// pod IP to restrict which interface can reach the service.
+	Host string
+
+	// Port is the TCP port the gRPC listener binds to.
+	Port int
+
+	// DefaultRequestDeadline is applied to an incoming RPC that doesn't
+	// already carry a deadline, so a client that forgets to set one
+	// can't hang a handler indefinitely. <= 0 disables the fallback,
+	// leaving such requests without a deadline.
+	DefaultRequestDeadline time.Duration
+
+	// MaxRecvMsgSize and MaxSendMsgSize bound the largest message the
+	// server will accept or send, in bytes, so a large batch response
+	// (e.g. a big FindNearbyUsers result) isn't rejected by grpc-go's
+	// own small defaults.
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+
+	// KeepaliveTime is how often the server pings an idle connection to
+	// check it's still alive; KeepaliveTimeout is how long it waits for
+	// the ping response before considering the connection dead.
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+
+	// EnableReflection registers the gRPC reflection service, letting a
+	// client like grpcurl discover the API without a local copy of the
+	// .proto files. Convenient in development; defaults to true here
+	// since this repo has no dev/prod environment distinction to key
+	// off of, but an operator running in production should set
+	// GRPC_ENABLE_REFLECTION=false to close off that attack surface.
+	EnableReflection bool
+}
+
+// APIVersionConfig controls the x-api-version compatibility check
+// enforced by server.APIVersionInterceptor.
+type APIVersionConfig struct {
+	// CurrentVersion is the API version this build implements. A
+	// request that omits the x-api-version header is treated as if it
+	// had asked for CurrentVersion.
+	CurrentVersion int
+
+	// MinSupportedVersion is the oldest client version this build still
+	// serves; anything older is rejected with codes.FailedPrecondition.
+	// Keeping it below CurrentVersion is what gives older clients a
+	// grace window to upgrade instead of breaking the moment a new
+	// version ships.
+	MinSupportedVersion int
+}
+
+// minGRPCMsgSize is the smallest MaxRecvMsgSize/MaxSendMsgSize Load
+// accepts, so a misconfigured tiny limit doesn't silently reject every
+// nontrivial response at startup instead of at request time.
+const minGRPCMsgSize = 16 * 1024
+
+// minKeepaliveTime is the smallest GRPCConfig.KeepaliveTime Load
+// accepts, so a misconfigured near-zero interval can't turn keepalive
+// pings into a connection-thrashing busy loop.
+const minKeepaliveTime = time.Second
+
+// TLSConfig controls the gRPC server's transport security. It is
+// unrelated to config.PostgresConfig/RedisConfig's own TLS since those
+// are handled by their respective drivers.
+type TLSConfig struct {
+	// Enabled turns on TLS for the gRPC listener. Disabled by default
+	// so local development keeps working over plaintext.
+	Enabled bool
+
+	// CertFile and KeyFile are required when Enabled is true.
+	CertFile string
+	KeyFile  string
+
+	// ClientCAFile, if set, enables mTLS: incoming client certificates
+	// are verified against the CA pool it contains.
+	ClientCAFile string
+
+	// RequireClientCert rejects a client that doesn't present a
+	// certificate at all. Ignored unless ClientCAFile is set.
+	RequireClientCert bool
+}
+
+// LocationConfig controls how aggressively UpdateUserLocation debounces
+// writes to Postgres.
+type LocationConfig struct {
+	// DebounceMinDistanceMeters is how far a user must move from their
+	// last persisted position before a ping is written to Postgres
+	// immediately.
+	DebounceMinDistanceMeters float64
+
+	// DebounceMinInterval is the longest a ping can go without being
+	// persisted, even if the user hasn't moved far.
+	DebounceMinInterval time.Duration
+}
+
+// ShutdownConfig controls how the server winds down on SIGTERM/SIGINT.
+type ShutdownConfig struct {
+	// DrainTimeout bounds how long the server waits for in-flight RPCs
+	// to finish once it stops accepting new ones, before forcing the
+	// remaining connections closed.
+	DrainTimeout time.Duration
+}
+
+// StartupConfig controls the bounded retry loop used to connect to
+// Postgres and Redis at boot, so a database that comes up a little late
+// doesn't crash the process on the first attempt.
+type StartupConfig struct {
+	// MaxAttempts bounds how many times a startup connection is tried
+	// in total before the process gives up.
+	MaxAttempts int
+
+	// Backoff is the delay between connection attempts.
+	Backoff time.Duration
+
+	// AllowDegradedRedisStart lets the process finish booting even if
+	// Redis is still unreachable after MaxAttempts, serving without a
+	// cache rather than refusing to start. Postgres has no equivalent
+	// flag: it's load-bearing for every request, so its exhaustion is
+	// always fatal.
+	AllowDegradedRedisStart bool
+}
+
+// ResilienceConfig controls the fault-tolerance wrappers applied to the
+// repository layer.
+type ResilienceConfig struct {
+	// SlowQueryThreshold is the duration above which a repository
+	// operation is logged as a warning and counted in
+	// jollyroger_db_slow_operations_total.
+	SlowQueryThreshold time.Duration
+
+	// MaxOperationTimeout bounds how long a repository operation may
+	// run when the caller's own context has no deadline.
+	MaxOperationTimeout time.Duration
+}
+
+// Config is the root configuration object for the service.
+type Config struct {
+	Postgres   PostgresConfig
+	Redis      RedisConfig
+	Logger     LoggerConfig
+	Health     HealthConfig
+	User       UserConfig
+	Geo        GeoConfig
+	GRPC       GRPCConfig
+	APIVersion APIVersionConfig
+	TLS        TLSConfig
+	CacheSweep   CacheSweepConfig
+	StatsRefresh StatsRefreshConfig
+	Location     LocationConfig
+	Shutdown     ShutdownConfig
+	Startup      StartupConfig
+	Resilience   ResilienceConfig
+}
+
+// fileConfig mirrors Config for CONFIG_FILE (JSON) loading. It exists as
+// a separate type, rather than putting json tags directly on Config,
+// because time.Duration doesn't have a JSON representation stdlib can
+// decode on its own; here every duration is a time.ParseDuration string
+// like "30s", matching the format the env vars already use.
+type fileConfig struct {
+	Postgres struct {
+		DSN              string `json:"dsn"`
+		StatementTimeout string `json:"statement_timeout"`
+	} `json:"postgres"`
+	Redis struct {
+		Addr                 string `json:"addr"`
+		Password             string `json:"password"`
+		DB                   int    `json:"db"`
+		IdempotencyTTL       string `json:"idempotency_ttl"`
+		KeyPrefix            string `json:"key_prefix"`
+		MultiTenant          bool   `json:"multi_tenant"`
+		L1Size               int    `json:"l1_size"`
+		L1TTL                string `json:"l1_ttl"`
+		UserCacheSoftTTL     string `json:"user_cache_soft_ttl"`
+		UserCacheHardTTL     string `json:"user_cache_hard_ttl"`
+		Codec                string `json:"codec"`
+		MaxRetries           int    `json:"max_retries"`
+		MinRetryBackoff      string `json:"min_retry_backoff"`
+		MaxRetryBackoff      string `json:"max_retry_backoff"`
+		DialTimeout          string `json:"dial_timeout"`
+		ReadTimeout          string `json:"read_timeout"`
+		WriteTimeout         string `json:"write_timeout"`
+		WritePolicy          string `json:"write_policy"`
+		WriteBehindQueueSize int    `json:"write_behind_queue_size"`
+	} `json:"redis"`
+	Logger struct {
+		Level           string `json:"level"`
+		Encoding        string `json:"encoding"`
+		SamplingEnabled bool   `json:"sampling_enabled"`
+		GormLevel       string `json:"gorm_level"`
+	} `json:"logger"`
+	Health struct {
+		RefreshInterval string `json:"refresh_interval"`
+	} `json:"health"`
+	User struct {
+		MaxPreferencesPerUser int      `json:"max_preferences_per_user"`
+		FeatureFlagKeys       []string `json:"feature_flag_keys"`
+		OnlineWindow          string   `json:"online_window"`
+		AllowEmptyUsername    bool     `json:"allow_empty_username"`
+	} `json:"user"`
+	Geo struct {
+		MaxRadiusKm           float64 `json:"max_radius_km"`
+		MaxResultLimit        int     `json:"max_result_limit"`
+		CellPrecision         int     `json:"cell_precision"`
+		MaxConcurrentSearches int     `json:"max_concurrent_searches"`
+		SearchAcquireTimeout  string  `json:"search_acquire_timeout"`
+		Backend               string  `json:"backend"`
+	} `json:"geo"`
+	GRPC struct {
+		Host                   string `json:"host"`
+		Port                   int    `json:"port"`
+		DefaultRequestDeadline string `json:"default_request_deadline"`
+		MaxRecvMsgSize         int    `json:"max_recv_msg_size"`
+		MaxSendMsgSize         int    `json:"max_send_msg_size"`
+		KeepaliveTime          string `json:"keepalive_time"`
+		KeepaliveTimeout       string `json:"keepalive_timeout"`
+		EnableReflection       bool   `json:"enable_reflection"`
+	} `json:"grpc"`
+	APIVersion struct {
+		CurrentVersion      int `json:"current_version"`
+		MinSupportedVersion int `json:"min_supported_version"`
+	} `json:"api_version"`
+	TLS struct {
+		Enabled           bool   `json:"enabled"`
+		CertFile          string `json:"cert_file"`
+		KeyFile           string `json:"key_file"`
+		ClientCAFile      string `json:"client_ca_file"`
+		RequireClientCert bool   `json:"require_client_cert"`
+	} `json:"tls"`
+	CacheSweep struct {
+		Interval            string `json:"interval"`
+		InactivityThreshold string `json:"inactivity_threshold"`
+	} `json:"cache_sweep"`
+	StatsRefresh struct {
+		Interval string `json:"interval"`
+	} `json:"stats_refresh"`
+	Location struct {
+		DebounceMinDistanceMeters float64 `json:"debounce_min_distance_meters"`
+		DebounceMinInterval       string  `json:"debounce_min_interval"`
+	} `json:"location"`
+	Shutdown struct {
+		DrainTimeout string `json:"drain_timeout"`
+	} `json:"shutdown"`
+	Startup struct {
+		MaxAttempts             int    `json:"max_attempts"`
+		Backoff                 string `json:"backoff"`
+		AllowDegradedRedisStart bool   `json:"allow_degraded_redis_start"`
+	} `json:"startup"`
+	Resilience struct {
+		SlowQueryThreshold  string `json:"slow_query_threshold"`
+		MaxOperationTimeout string `json:"max_operation_timeout"`
+	} `json:"resilience"`
+}
+
+// loadFileConfig reads and parses the CONFIG_FILE an operator points
+// Load at. Only JSON is supported: this repo doesn't depend on a YAML
+// library, and a hand-rolled parser isn't worth it for a config file
+// that's usually generated by the same tooling that sets env vars
+// anyway.
+func loadFileConfig(path string) (fileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fileConfig{}, fmt.Errorf("config: read CONFIG_FILE %q: %w", path, err)
+	}
+	var fc fileConfig
+	if err := json.Unmarshal(data, &fc); err != nil {
+		return fileConfig{}, fmt.Errorf("config: parse CONFIG_FILE %q: %w", path, err)
+	}
+	return fc, nil
+}
+
+// fileOr, fileOrBool, fileOrInt, fileOrFloat, fileOrDuration and
+// fileOrStringSlice fold a CONFIG_FILE value into the hardcoded default
+// passed to a getEnv* call, so env vars keep taking precedence over the
+// file with no change to the getEnv* helpers themselves: Load ends up
+// calling e.g. getEnv("REDIS_ADDR", fileOr(file.Redis.Addr,
+// "localhost:6379")) instead of getEnv("REDIS_ADDR", "localhost:6379").
+//
+// A field left at its Go zero value in the file (empty string, 0,
+// false, a nil slice) is indistinguishable from one the file doesn't
+// mention at all, so it falls through to the hardcoded default rather
+// than overriding it. An operator who needs to explicitly force a
+// zero-ish value, or turn off something that defaults to on, should set
+// the corresponding env var instead.
+func fileOr(v, fallback string) string {
+	if v != "" {
+		return v
+	}
+	return fallback
+}
+
+func fileOrBool(v, fallback bool) bool {
+	if v {
+		return v
+	}
+	return fallback
+}
+
+func fileOrInt(v, fallback int) int {
+	if v != 0 {
+		return v
+	}
+	return fallback
+}
+
+func fileOrFloat(v, fallback float64) float64 {
+	if v != 0 {
+		return v
+	}
+	return fallback
+}
+
+// fileOrDuration parses v as a time.ParseDuration string, falling back
+// to fallback when v is empty or fails to parse.
+func fileOrDuration(v string, fallback time.Duration) time.Duration {
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fallback
+	}
+	return d
+}
+
+func fileOrStringSlice(v, fallback []string) []string {
+	if len(v) > 0 {
+		return v
+	}
+	return fallback
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
+
+// getEnvStringSlice reads a comma-separated list from key, trimming
+// whitespace around each entry. An unset or empty env var returns
+// fallback.
+func getEnvStringSlice(key string, fallback []string) []string {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	parts := strings.Split(v, ",")
+	values := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			values = append(values, p)
+		}
+	}
+	return values
+}
+
+// Load reads configuration from the environment, applying sane defaults
+// for local development. If CONFIG_FILE is set, it's read as a JSON
+// document first and used to supply defaults of its own; an env var set
+// alongside it always wins, so a file can hold an environment's base
+// configuration while individual overrides still go through env vars as
+// before. It panics if MultiTenant is enabled without a KeyPrefix, since
+// that combination would silently share cache keys across tenants, and
+// on a CONFIG_FILE that can't be read or parsed.
+func Load() *Config {
+	var file fileConfig
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		f, err := loadFileConfig(path)
+		if err != nil {
+			panic(err)
+		}
+		file = f
+	}
+
+	cfg := &Config{
+		Postgres: PostgresConfig{
+			DSN:              getEnv("POSTGRES_DSN", fileOr(file.Postgres.DSN, "")),
+			StatementTimeout: getEnvDuration("POSTGRES_STATEMENT_TIMEOUT", fileOrDuration(file.Postgres.StatementTimeout, 30*time.Second)),
+		},
+		Redis: RedisConfig{
+			Addr:                 getEnv("REDIS_ADDR", fileOr(file.Redis.Addr, "localhost:6379")),
+			Password:             getEnv("REDIS_PASSWORD", fileOr(file.Redis.Password, "")),
+			DB:                   getEnvInt("REDIS_DB", fileOrInt(file.Redis.DB, 0)),
+			IdempotencyTTL:       getEnvDuration("IDEMPOTENCY_TTL", fileOrDuration(file.Redis.IdempotencyTTL, 24*time.Hour)),
+			KeyPrefix:            getEnv("REDIS_KEY_PREFIX", fileOr(file.Redis.KeyPrefix, "")),
+			MultiTenant:          getEnvBool("REDIS_MULTI_TENANT", fileOrBool(file.Redis.MultiTenant, false)),
+			L1Size:               getEnvInt("CACHE_L1_SIZE", fileOrInt(file.Redis.L1Size, 1024)),
+			L1TTL:                getEnvDuration("CACHE_L1_TTL", fileOrDuration(file.Redis.L1TTL, 30*time.Second)),
+			UserCacheSoftTTL:     getEnvDuration("USER_CACHE_SOFT_TTL", fileOrDuration(file.Redis.UserCacheSoftTTL, 15*time.Minute)),
+			UserCacheHardTTL:     getEnvDuration("USER_CACHE_HARD_TTL", fileOrDuration(file.Redis.UserCacheHardTTL, 24*time.Hour)),
+			Codec:                getEnv("CACHE_CODEC", fileOr(file.Redis.Codec, "json")),
+			MaxRetries:           getEnvInt("REDIS_MAX_RETRIES", fileOrInt(file.Redis.MaxRetries, 3)),
+			MinRetryBackoff:      getEnvDuration("REDIS_MIN_RETRY_BACKOFF", fileOrDuration(file.Redis.MinRetryBackoff, 8*time.Millisecond)),
+			MaxRetryBackoff:      getEnvDuration("REDIS_MAX_RETRY_BACKOFF", fileOrDuration(file.Redis.MaxRetryBackoff, 512*time.Millisecond)),
+			DialTimeout:          getEnvDuration("REDIS_DIAL_TIMEOUT", fileOrDuration(file.Redis.DialTimeout, 5*time.Second)),
+			ReadTimeout:          getEnvDuration("REDIS_READ_TIMEOUT", fileOrDuration(file.Redis.ReadTimeout, 3*time.Second)),
+			WriteTimeout:         getEnvDuration("REDIS_WRITE_TIMEOUT", fileOrDuration(file.Redis.WriteTimeout, 3*time.Second)),
+			WritePolicy:          getEnv("CACHE_WRITE_POLICY", fileOr(file.Redis.WritePolicy, "write-through")),
+			WriteBehindQueueSize: getEnvInt("CACHE_WRITE_BEHIND_QUEUE_SIZE", fileOrInt(file.Redis.WriteBehindQueueSize, 256)),
+		},
+		Logger: LoggerConfig{
+			Level:           getEnv("LOG_LEVEL", fileOr(file.Logger.Level, "info")),
+			Encoding:        getEnv("LOG_ENCODING", fileOr(file.Logger.Encoding, "json")),
+			SamplingEnabled: getEnvBool("LOG_SAMPLING", fileOrBool(file.Logger.SamplingEnabled, true)),
+			GormLevel:       getEnv("LOG_GORM_LEVEL", fileOr(file.Logger.GormLevel, "warn")),
+		},
+		Health: HealthConfig{
+			RefreshInterval: getEnvDuration("HEALTH_REFRESH_INTERVAL", fileOrDuration(file.Health.RefreshInterval, 10*time.Second)),
+		},
+		User: UserConfig{
+			MaxPreferencesPerUser: getEnvInt("MAX_PREFERENCES_PER_USER", fileOrInt(file.User.MaxPreferencesPerUser, 50)),
+			FeatureFlagKeys:       getEnvStringSlice("USER_FEATURE_FLAG_KEYS", fileOrStringSlice(file.User.FeatureFlagKeys, nil)),
+			OnlineWindow:          getEnvDuration("USER_ONLINE_WINDOW", fileOrDuration(file.User.OnlineWindow, 5*time.Minute)),
+			AllowEmptyUsername:    getEnvBool("USER_ALLOW_EMPTY_USERNAME", fileOrBool(file.User.AllowEmptyUsername, false)),
+		},
+		Geo: GeoConfig{
+			MaxRadiusKm:           getEnvFloat("GEO_MAX_RADIUS_KM", fileOrFloat(file.Geo.MaxRadiusKm, 500)),
+			MaxResultLimit:        getEnvInt("GEO_MAX_RESULT_LIMIT", fileOrInt(file.Geo.MaxResultLimit, 100)),
+			CellPrecision:         getEnvInt("GEO_CACHE_CELL_PRECISION", fileOrInt(file.Geo.CellPrecision, 2)),
+			MaxConcurrentSearches: getEnvInt("GEO_MAX_CONCURRENT_SEARCHES", fileOrInt(file.Geo.MaxConcurrentSearches, 20)),
+			SearchAcquireTimeout:  getEnvDuration("GEO_SEARCH_ACQUIRE_TIMEOUT", fileOrDuration(file.Geo.SearchAcquireTimeout, 200*time.Millisecond)),
+			Backend:               getEnv("GEO_BACKEND", fileOr(file.Geo.Backend, "haversine")),
+		},
+		GRPC: GRPCConfig{
+			Host:                   getEnv("GRPC_HOST", fileOr(file.GRPC.Host, "")),
+			Port:                   getEnvInt("GRPC_PORT", fileOrInt(file.GRPC.Port, 50051)),
+			DefaultRequestDeadline: getEnvDuration("GRPC_DEFAULT_REQUEST_DEADLINE", fileOrDuration(file.GRPC.DefaultRequestDeadline, 30*time.Second)),
+			MaxRecvMsgSize:         getEnvInt("GRPC_MAX_RECV_MSG_SIZE", fileOrInt(file.GRPC.MaxRecvMsgSize, 16*1024*1024)),
+			MaxSendMsgSize:         getEnvInt("GRPC_MAX_SEND_MSG_SIZE", fileOrInt(file.GRPC.MaxSendMsgSize, 16*1024*1024)),
+			KeepaliveTime:          getEnvDuration("GRPC_KEEPALIVE_TIME", fileOrDuration(file.GRPC.KeepaliveTime, 30*time.Second)),
+			KeepaliveTimeout:       getEnvDuration("GRPC_KEEPALIVE_TIMEOUT", fileOrDuration(file.GRPC.KeepaliveTimeout, 10*time.Second)),
+			EnableReflection:       getEnvBool("GRPC_ENABLE_REFLECTION", fileOrBool(file.GRPC.EnableReflection, true)),
+		},
+		APIVersion: APIVersionConfig{
+			CurrentVersion:      getEnvInt("API_CURRENT_VERSION", fileOrInt(file.APIVersion.CurrentVersion, 1)),
+			MinSupportedVersion: getEnvInt("API_MIN_SUPPORTED_VERSION", fileOrInt(file.APIVersion.MinSupportedVersion, 1)),
+		},
+		TLS: TLSConfig{
+			Enabled:           getEnvBool("TLS_ENABLED", fileOrBool(file.TLS.Enabled, false)),
+			CertFile:          getEnv("TLS_CERT_FILE", fileOr(file.TLS.CertFile, "")),
+			KeyFile:           getEnv("TLS_KEY_FILE", fileOr(file.TLS.KeyFile, "")),
+			ClientCAFile:      getEnv("TLS_CLIENT_CA_FILE", fileOr(file.TLS.ClientCAFile, "")),
+			RequireClientCert: getEnvBool("TLS_REQUIRE_CLIENT_CERT", fileOrBool(file.TLS.RequireClientCert, false)),
+		},
+		CacheSweep: CacheSweepConfig{
+			Interval:            getEnvDuration("CACHE_SWEEP_INTERVAL", fileOrDuration(file.CacheSweep.Interval, 10*time.Minute)),
+			InactivityThreshold: getEnvDuration("CACHE_SWEEP_INACTIVITY_THRESHOLD", fileOrDuration(file.CacheSweep.InactivityThreshold, 30*24*time.Hour)),
+		},
+		StatsRefresh: StatsRefreshConfig{
+			Interval: getEnvDuration("STATS_REFRESH_INTERVAL", fileOrDuration(file.StatsRefresh.Interval, 1*time.Minute)),
+		},
+		Location: LocationConfig{
+			DebounceMinDistanceMeters: getEnvFloat("LOCATION_DEBOUNCE_MIN_DISTANCE_METERS", fileOrFloat(file.Location.DebounceMinDistanceMeters, 200)),
+			DebounceMinInterval:       getEnvDuration("LOCATION_DEBOUNCE_MIN_INTERVAL", fileOrDuration(file.Location.DebounceMinInterval, 5*time.Minute)),
+		},
+		Shutdown: ShutdownConfig{
+			DrainTimeout: getEnvDuration("SHUTDOWN_DRAIN_TIMEOUT", fileOrDuration(file.Shutdown.DrainTimeout, 30*time.Second)),
+		},
+		Startup: StartupConfig{
+			MaxAttempts:             getEnvInt("STARTUP_MAX_ATTEMPTS", fileOrInt(file.Startup.MaxAttempts, 5)),
+			Backoff:                 getEnvDuration("STARTUP_BACKOFF", fileOrDuration(file.Startup.Backoff, 2*time.Second)),
+			AllowDegradedRedisStart: getEnvBool("STARTUP_ALLOW_DEGRADED_REDIS", fileOrBool(file.Startup.AllowDegradedRedisStart, false)),
+		},
+		Resilience: ResilienceConfig{
+			SlowQueryThreshold:  getEnvDuration("DB_SLOW_QUERY_THRESHOLD", fileOrDuration(file.Resilience.SlowQueryThreshold, 500*time.Millisecond)),
+			MaxOperationTimeout: getEnvDuration("DB_MAX_OPERATION_TIMEOUT", fileOrDuration(file.Resilience.MaxOperationTimeout, 5*time.Second)),
+		},
+	}
+	if cfg.Redis.MultiTenant && cfg.Redis.KeyPrefix == "" {
+		panic("config: REDIS_MULTI_TENANT requires a non-empty REDIS_KEY_PREFIX")
+	}
+	if cfg.GRPC.MaxRecvMsgSize < minGRPCMsgSize {
+		panic(fmt.Sprintf("config: GRPC_MAX_RECV_MSG_SIZE must be at least %d bytes", minGRPCMsgSize))
+	}
+	if cfg.GRPC.MaxSendMsgSize < minGRPCMsgSize {
+		panic(fmt.Sprintf("config: GRPC_MAX_SEND_MSG_SIZE must be at least %d bytes", minGRPCMsgSize))
+	}
+	if cfg.GRPC.KeepaliveTime < minKeepaliveTime {
+		panic(fmt.Sprintf("config: GRPC_KEEPALIVE_TIME must be at least %s", minKeepaliveTime))
+	}
+	if cfg.Redis.UserCacheSoftTTL > cfg.Redis.UserCacheHardTTL {
+		panic("config: USER_CACHE_SOFT_TTL must not be greater than USER_CACHE_HARD_TTL")
+	}
+	return cfg
+}
+
+// Validate checks the fields an operator is responsible for supplying
+// (connection targets, the listen port, TLS material, positive
+// timeouts) and returns every problem it finds joined into a single
+// error, rather than stopping at the first one, so a misconfigured
+// deploy surfaces all of its mistakes in one restart instead of one per
+// attempt. A nil error means cfg is safe to connect with. It's the
+// caller's job to invoke this before dialing Postgres/Redis; Load
+// itself only panics on internally-inconsistent combinations that can
+// never be a deliberate choice (see Load).
+func (cfg *Config) Validate() error {
+	var errs []error
+
+	if cfg.Postgres.DSN == "" {
+		errs = append(errs, errors.New("config: POSTGRES_DSN must not be empty"))
+	}
+	if cfg.Postgres.StatementTimeout < 0 {
+		errs = append(errs, errors.New("config: POSTGRES_STATEMENT_TIMEOUT must not be negative"))
+	}
+
+	if cfg.Redis.Addr == "" {
+		errs = append(errs, errors.New("config: REDIS_ADDR must not be empty"))
+	}
+	if cfg.Redis.DialTimeout <= 0 {
+		errs = append(errs, errors.New("config: REDIS_DIAL_TIMEOUT must be positive"))
+	}
+	if cfg.Redis.ReadTimeout <= 0 {
+		errs = append(errs, errors.New("config: REDIS_READ_TIMEOUT must be positive"))
+	}
+	if cfg.Redis.WriteTimeout <= 0 {
+		errs = append(errs, errors.New("config: REDIS_WRITE_TIMEOUT must be positive"))
+	}
+
+	if cfg.Geo.MaxResultLimit <= 0 {
+		errs = append(errs, errors.New("config: GEO_MAX_RESULT_LIMIT must be positive"))
+	}
+	if cfg.Geo.MaxRadiusKm <= 0 {
+		errs = append(errs, errors.New("config: GEO_MAX_RADIUS_KM must be positive"))
+	}
+
+	if cfg.GRPC.Port < 1 || cfg.GRPC.Port > 65535 {
+		errs = append(errs, fmt.Errorf("config: GRPC_PORT must be between 1 and 65535, got %d", cfg.GRPC.Port))
+	}
+
+	if cfg.TLS.Enabled && (cfg.TLS.CertFile == "" || cfg.TLS.KeyFile == "") {
+		errs = append(errs, errors.New("config: TLS_ENABLED requires both TLS_CERT_FILE and TLS_KEY_FILE"))
+	}
+
+	if cfg.APIVersion.MinSupportedVersion > cfg.APIVersion.CurrentVersion {
+		errs = append(errs, errors.New("config: API_MIN_SUPPORTED_VERSION must not be greater than API_CURRENT_VERSION"))
+	}
+
+	if cfg.Startup.MaxAttempts < 1 {
+		errs = append(errs, errors.New("config: STARTUP_MAX_ATTEMPTS must be at least 1"))
+	}
+	if cfg.Startup.Backoff <= 0 {
+		errs = append(errs, errors.New("config: STARTUP_BACKOFF must be positive"))
+	}
+
+	if cfg.Shutdown.DrainTimeout <= 0 {
+		errs = append(errs, errors.New("config: SHUTDOWN_DRAIN_TIMEOUT must be positive"))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,204 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPostgresConfig_BuildDSN_AppendsStatementTimeout(t *testing.T) {
+	cfg := PostgresConfig{
+		DSN:              "host=localhost user=jollyroger dbname=jollyroger sslmode=disable",
+		StatementTimeout: 5 * time.Second,
+	}
+
+	dsn := cfg.BuildDSN()
+	want := "host=localhost user=jollyroger dbname=jollyroger sslmode=disable options='-c statement_timeout=5000'"
+	if dsn != want {
+		t.Fatalf("expected %q, got %q", want, dsn)
+	}
+}
+
+func TestPostgresConfig_BuildDSN_LeavesDSNUnchangedWithoutATimeout(t *testing.T) {
+	cfg := PostgresConfig{DSN: "host=localhost dbname=jollyroger"}
+	if dsn := cfg.BuildDSN(); dsn != cfg.DSN {
+		t.Fatalf("expected DSN to pass through unchanged, got %q", dsn)
+	}
+}
+
+func TestLoad_PanicsOnAGRPCMaxRecvMsgSizeBelowTheMinimum(t *testing.T) {
+	t.Setenv("GRPC_MAX_RECV_MSG_SIZE", "10")
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected Load to panic on a GRPC_MAX_RECV_MSG_SIZE below minGRPCMsgSize")
+		}
+	}()
+	Load()
+}
+
+func TestLoad_PanicsOnAGRPCKeepaliveTimeBelowTheMinimum(t *testing.T) {
+	t.Setenv("GRPC_KEEPALIVE_TIME", "1ms")
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected Load to panic on a GRPC_KEEPALIVE_TIME below minKeepaliveTime")
+		}
+	}()
+	Load()
+}
+
+// validTestConfig returns a Config with every field Validate checks
+// filled in with a sane value, so a test can zero out just the field it
+// wants to exercise.
+func validTestConfig() *Config {
+	return &Config{
+		Postgres: PostgresConfig{DSN: "host=localhost user=jollyroger dbname=jollyroger sslmode=disable"},
+		Redis: RedisConfig{
+			Addr:         "localhost:6379",
+			DialTimeout:  5 * time.Second,
+			ReadTimeout:  3 * time.Second,
+			WriteTimeout: 3 * time.Second,
+		},
+		Geo:      GeoConfig{MaxRadiusKm: 500, MaxResultLimit: 100},
+		GRPC:     GRPCConfig{Port: 50051},
+		Startup:  StartupConfig{MaxAttempts: 5, Backoff: 2 * time.Second},
+		Shutdown: ShutdownConfig{DrainTimeout: 30 * time.Second},
+	}
+}
+
+func TestConfigValidate_PassesOnAValidConfig(t *testing.T) {
+	if err := validTestConfig().Validate(); err != nil {
+		t.Fatalf("expected a valid config to pass, got %v", err)
+	}
+}
+
+func TestConfigValidate_RequiresAPostgresDSN(t *testing.T) {
+	cfg := validTestConfig()
+	cfg.Postgres.DSN = ""
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "POSTGRES_DSN") {
+		t.Fatalf("expected an error mentioning POSTGRES_DSN, got %v", err)
+	}
+}
+
+func TestConfigValidate_RequiresAPositiveGeoMaxResultLimit(t *testing.T) {
+	cfg := validTestConfig()
+	cfg.Geo.MaxResultLimit = 0
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "GEO_MAX_RESULT_LIMIT") {
+		t.Fatalf("expected an error mentioning GEO_MAX_RESULT_LIMIT, got %v", err)
+	}
+}
+
+func TestConfigValidate_RequiresAPositiveGeoMaxRadiusKm(t *testing.T) {
+	cfg := validTestConfig()
+	cfg.Geo.MaxRadiusKm = 0
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "GEO_MAX_RADIUS_KM") {
+		t.Fatalf("expected an error mentioning GEO_MAX_RADIUS_KM, got %v", err)
+	}
+}
+
+func TestConfigValidate_RequiresAGRPCPortInRange(t *testing.T) {
+	cfg := validTestConfig()
+	cfg.GRPC.Port = 70000
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "GRPC_PORT") {
+		t.Fatalf("expected an error mentioning GRPC_PORT, got %v", err)
+	}
+}
+
+func TestConfigValidate_TLSEnabledRequiresCertAndKeyFiles(t *testing.T) {
+	cfg := validTestConfig()
+	cfg.TLS.Enabled = true
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "TLS_ENABLED") {
+		t.Fatalf("expected an error mentioning TLS_ENABLED, got %v", err)
+	}
+}
+
+func TestConfigValidate_AggregatesEveryProblemIntoOneError(t *testing.T) {
+	cfg := validTestConfig()
+	cfg.Postgres.DSN = ""
+	cfg.Redis.Addr = ""
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if !strings.Contains(err.Error(), "POSTGRES_DSN") || !strings.Contains(err.Error(), "REDIS_ADDR") {
+		t.Fatalf("expected both problems reported in a single error, got %v", err)
+	}
+}
+
+// writeTestConfigFile writes contents as a CONFIG_FILE JSON document
+// under t.TempDir() and returns its path.
+func writeTestConfigFile(t *testing.T, contents map[string]any) string {
+	t.Helper()
+	data, err := json.Marshal(contents)
+	if err != nil {
+		t.Fatalf("marshal test config file: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write test config file: %v", err)
+	}
+	return path
+}
+
+func TestLoad_ReadsBaseValuesFromACONFIGFILE(t *testing.T) {
+	path := writeTestConfigFile(t, map[string]any{
+		"redis": map[string]any{"addr": "redis-from-file:6379"},
+	})
+	t.Setenv("CONFIG_FILE", path)
+
+	cfg := Load()
+	if cfg.Redis.Addr != "redis-from-file:6379" {
+		t.Fatalf("expected REDIS_ADDR to come from CONFIG_FILE, got %q", cfg.Redis.Addr)
+	}
+}
+
+func TestLoad_AnEnvVarOverridesTheSameFieldSetInCONFIGFILE(t *testing.T) {
+	path := writeTestConfigFile(t, map[string]any{
+		"redis": map[string]any{"addr": "redis-from-file:6379"},
+	})
+	t.Setenv("CONFIG_FILE", path)
+	t.Setenv("REDIS_ADDR", "redis-from-env:6379")
+
+	cfg := Load()
+	if cfg.Redis.Addr != "redis-from-env:6379" {
+		t.Fatalf("expected REDIS_ADDR env var to win over CONFIG_FILE, got %q", cfg.Redis.Addr)
+	}
+}
+
+func TestLoad_PanicsOnAnUnreadableCONFIGFILE(t *testing.T) {
+	t.Setenv("CONFIG_FILE", filepath.Join(t.TempDir(), "does-not-exist.json"))
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected Load to panic on a CONFIG_FILE that can't be read")
+		}
+	}()
+	Load()
+}
+
+func TestLoad_PanicsWhenUserCacheSoftTTLExceedsHardTTL(t *testing.T) {
+	t.Setenv("USER_CACHE_SOFT_TTL", "1h")
+	t.Setenv("USER_CACHE_HARD_TTL", "30m")
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected Load to panic when USER_CACHE_SOFT_TTL exceeds USER_CACHE_HARD_TTL")
+		}
+	}()
+	Load()
+}
@@ -0,0 +1,31 @@
+// Package database holds small Postgres-specific helpers shared across
+// the repository layer that don't belong to any single table's
+// repository (e.g. cross-cutting locking primitives).
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// WithUserLock runs fn, passing it the transaction that holds a
+// Postgres advisory lock scoped to userID, so concurrent critical
+// sections for the same user (e.g. rating recompute, onboarding)
+// serialize while different users proceed in parallel without
+// contending on shared row locks or a single mutex. fn must do its work
+// on the given tx rather than opening a transaction of its own, or its
+// work runs on a different connection than the one holding the lock
+// and isn't actually serialized against other WithUserLock callers.
+// The lock is acquired with pg_advisory_xact_lock inside that same
+// transaction, so it's released automatically when the transaction
+// commits or rolls back — a panicking or otherwise abandoned fn can't
+// leak it past this call.
+func WithUserLock(ctx context.Context, db *gorm.DB, userID uint, fn func(tx *gorm.DB) error) error {
+	return db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", userID).Error; err != nil {
+			return err
+		}
+		return fn(tx)
+	})
+}
@@ -0,0 +1,94 @@
+// Package gormzap adapts a *zap.Logger to GORM's logger.Interface, so SQL
+// logs, slow queries, and errors flow through the process's structured
+// logging instead of GORM's own default logger (which writes plain text
+// to os.Stdout via the standard log package).
+package gormzap
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// LevelFromString maps a config.LoggerConfig.GormLevel value to a
+// gormlogger.LogLevel, falling back to gormlogger.Warn for an
+// unrecognized value so a typo doesn't silence GORM's own error/slow
+// query logging entirely.
+func LevelFromString(level string) gormlogger.LogLevel {
+	switch level {
+	case "silent":
+		return gormlogger.Silent
+	case "error":
+		return gormlogger.Error
+	case "warn":
+		return gormlogger.Warn
+	case "info":
+		return gormlogger.Info
+	default:
+		return gormlogger.Warn
+	}
+}
+
+// New builds a gormlogger.Interface that writes through log at level.
+// slowThreshold marks a query as slow in the log line it emits; a
+// threshold <= 0 disables slow-query detection entirely.
+func New(log *zap.Logger, level gormlogger.LogLevel, slowThreshold time.Duration) gormlogger.Interface {
+	return &Logger{log: log, level: level, slowThreshold: slowThreshold}
+}
+
+// Logger implements gormlogger.Interface on top of a *zap.Logger.
+type Logger struct {
+	log           *zap.Logger
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+}
+
+// LogMode returns a copy of l at the given level, per gormlogger's
+// convention of never mutating the receiver in place.
+func (l *Logger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+func (l *Logger) Info(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.log.Sugar().Infof(msg, args...)
+	}
+}
+
+func (l *Logger) Warn(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.log.Sugar().Warnf(msg, args...)
+	}
+}
+
+func (l *Logger) Error(_ context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.log.Sugar().Errorf(msg, args...)
+	}
+}
+
+// Trace logs the outcome of a single GORM query: its SQL, row count, and
+// duration, plus an error if one occurred or a slow-query warning if it
+// ran past slowThreshold. It's called by GORM after every query
+// regardless of level; the level checks decide whether anything is
+// actually emitted.
+func (l *Logger) Trace(_ context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+	elapsed := time.Since(begin)
+	sql, rows := fc()
+
+	switch {
+	case err != nil && l.level >= gormlogger.Error:
+		l.log.Error("gorm: query failed", zap.String("sql", sql), zap.Int64("rows", rows), zap.Duration("elapsed", elapsed), zap.Error(err))
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		l.log.Warn("gorm: slow query", zap.String("sql", sql), zap.Int64("rows", rows), zap.Duration("elapsed", elapsed))
+	case l.level >= gormlogger.Info:
+		l.log.Info("gorm: query", zap.String("sql", sql), zap.Int64("rows", rows), zap.Duration("elapsed", elapsed))
+	}
+}
@@ -0,0 +1,67 @@
+package gormzap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func TestTrace_LogsAQueryErrorThroughZap(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	log := New(zap.New(core), gormlogger.Error, time.Second)
+
+	fc := func() (string, int64) { return "SELECT * FROM users WHERE id = 1", 0 }
+	log.Trace(context.Background(), time.Now(), fc, errors.New("connection reset"))
+
+	if logs.Len() != 1 {
+		t.Fatalf("expected exactly one error log, got %d", logs.Len())
+	}
+	entry := logs.All()[0]
+	if entry.Message != "gorm: query failed" {
+		t.Fatalf("unexpected log message: %q", entry.Message)
+	}
+	if got := entry.ContextMap()["sql"]; got != "SELECT * FROM users WHERE id = 1" {
+		t.Fatalf("expected the failing sql in the log fields, got %v", got)
+	}
+}
+
+func TestTrace_SilentLevelLogsNothing(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	log := New(zap.New(core), gormlogger.Silent, time.Second)
+
+	fc := func() (string, int64) { return "SELECT 1", 1 }
+	log.Trace(context.Background(), time.Now(), fc, errors.New("boom"))
+
+	if logs.Len() != 0 {
+		t.Fatalf("expected silent level to suppress all logging, got %d entries", logs.Len())
+	}
+}
+
+func TestTrace_SlowQueryLogsAWarning(t *testing.T) {
+	core, logs := observer.New(zap.DebugLevel)
+	log := New(zap.New(core), gormlogger.Warn, time.Millisecond)
+
+	fc := func() (string, int64) { return "SELECT * FROM users", 3 }
+	log.Trace(context.Background(), time.Now().Add(-10*time.Millisecond), fc, nil)
+
+	if logs.Len() != 1 {
+		t.Fatalf("expected exactly one warning log, got %d", logs.Len())
+	}
+	if got := logs.All()[0].Message; got != "gorm: slow query" {
+		t.Fatalf("unexpected log message: %q", got)
+	}
+}
+
+func TestLevelFromString_FallsBackToWarnOnAnUnrecognizedValue(t *testing.T) {
+	if got := LevelFromString("bogus"); got != gormlogger.Warn {
+		t.Fatalf("expected an unrecognized level to fall back to Warn, got %v", got)
+	}
+	if got := LevelFromString("info"); got != gormlogger.Info {
+		t.Fatalf("expected info to map to gormlogger.Info, got %v", got)
+	}
+}
@@ -0,0 +1,57 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/buildinfo"
+)
+
+func TestChecker_CheckRedis(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	checker := NewChecker(nil, client)
+
+	if err := checker.CheckRedis(context.Background()); err != nil {
+		t.Fatalf("expected a live redis to pass its health check: %v", err)
+	}
+
+	mr.Close()
+	if err := checker.CheckRedis(context.Background()); err == nil {
+		t.Fatalf("expected a down redis to fail its health check")
+	}
+}
+
+func TestChecker_Info_ReportsBuildFieldsAndIncreasingUptime(t *testing.T) {
+	buildinfo.Version = "1.2.3"
+	buildinfo.GitCommit = "abc1234"
+	buildinfo.BuildTime = "2026-01-01T00:00:00Z"
+	t.Cleanup(func() {
+		buildinfo.Version, buildinfo.GitCommit, buildinfo.BuildTime = "dev", "unknown", "unknown"
+	})
+
+	checker := NewChecker(nil, nil)
+
+	first := checker.Info()
+	if first.Version != "1.2.3" || first.GitCommit != "abc1234" || first.BuildTime != "2026-01-01T00:00:00Z" {
+		t.Fatalf("expected build fields to be present, got %+v", first)
+	}
+	if first.GoVersion == "" {
+		t.Fatalf("expected go_version to be populated")
+	}
+
+	time.Sleep(time.Millisecond)
+	second := checker.Info()
+	if second.UptimeSeconds <= first.UptimeSeconds {
+		t.Fatalf("expected uptime to increase, got %v then %v", first.UptimeSeconds, second.UptimeSeconds)
+	}
+}
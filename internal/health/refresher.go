@@ -0,0 +1,61 @@
+package health
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultRefreshInterval is how often the background refresher re-probes
+// dependencies when no interval is configured.
+const DefaultRefreshInterval = 10 * time.Second
+
+// Refresher periodically probes a Checker's dependencies in the
+// background and caches the result, so a burst of health-check traffic
+// (e.g. from a k8s liveness probe hitting every replica) doesn't turn
+// into a burst of load against Postgres and Redis.
+type Refresher struct {
+	checker  *Checker
+	interval time.Duration
+	healthy  atomic.Bool
+}
+
+// NewRefresher constructs a Refresher over checker. An interval <= 0
+// falls back to DefaultRefreshInterval. The cached status starts
+// healthy=true optimistically until the first probe completes.
+func NewRefresher(checker *Checker, interval time.Duration) *Refresher {
+	if interval <= 0 {
+		interval = DefaultRefreshInterval
+	}
+	r := &Refresher{checker: checker, interval: interval}
+	r.healthy.Store(true)
+	return r
+}
+
+// Run probes dependencies immediately and then on every tick of the
+// configured interval, until ctx is canceled.
+func (r *Refresher) Run(ctx context.Context) {
+	r.refresh(ctx)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.refresh(ctx)
+		}
+	}
+}
+
+func (r *Refresher) refresh(ctx context.Context) {
+	probeCtx, cancel := context.WithTimeout(ctx, DefaultProbeTimeout)
+	defer cancel()
+	r.healthy.Store(r.checker.CheckAll(probeCtx) == nil)
+}
+
+// Healthy reports the most recently cached probe result.
+func (r *Refresher) Healthy() bool {
+	return r.healthy.Load()
+}
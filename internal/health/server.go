@@ -0,0 +1,80 @@
+package health
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// watchPollInterval is how often Watch checks the refresher's cached
+// status for changes to push to the client.
+const watchPollInterval = time.Second
+
+// MaintenanceChecker reports whether the service is currently in
+// maintenance mode (writes rejected, reads still served). It is
+// satisfied by *service.MaintenanceMode without either package
+// importing the other.
+type MaintenanceChecker interface {
+	Enabled() bool
+}
+
+// Server implements grpc_health_v1.HealthServer by reporting the status
+// most recently cached by a background Refresher, rather than probing
+// dependencies synchronously on every call. While maintenance is
+// enabled, it reports NOT_SERVING so load balancers stop routing new
+// traffic, even though the service's own reads keep working for callers
+// already holding a connection.
+type Server struct {
+	grpc_health_v1.UnimplementedHealthServer
+
+	refresher   *Refresher
+	maintenance MaintenanceChecker
+}
+
+// NewServer wraps refresher (and, optionally, a maintenance flag) as a
+// gRPC health service. maintenance may be nil, in which case maintenance
+// mode never affects the reported status.
+func NewServer(refresher *Refresher, maintenance MaintenanceChecker) *Server {
+	return &Server{refresher: refresher, maintenance: maintenance}
+}
+
+// Check reports the refresher's most recently cached status.
+func (s *Server) Check(_ context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	return &grpc_health_v1.HealthCheckResponse{Status: s.status()}, nil
+}
+
+// Watch streams the refresher's cached status, pushing an update
+// whenever it changes.
+func (s *Server) Watch(_ *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	last := s.status()
+	if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: last}); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ticker.C:
+			if current := s.status(); current != last {
+				last = current
+				if err := stream.Send(&grpc_health_v1.HealthCheckResponse{Status: last}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+func (s *Server) status() grpc_health_v1.HealthCheckResponse_ServingStatus {
+	if s.maintenance != nil && s.maintenance.Enabled() {
+		return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	if s.refresher.Healthy() {
+		return grpc_health_v1.HealthCheckResponse_SERVING
+	}
+	return grpc_health_v1.HealthCheckResponse_NOT_SERVING
+}
@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type staticMaintenanceChecker struct{ enabled bool }
+
+func (c staticMaintenanceChecker) Enabled() bool { return c.enabled }
+
+func TestServer_Check_ReportsNotServingDuringMaintenance(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	refresher := NewRefresher(NewChecker(nil, client), DefaultRefreshInterval)
+	server := NewServer(refresher, staticMaintenanceChecker{enabled: true})
+
+	resp, err := server.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected NOT_SERVING during maintenance mode, got %v", resp.Status)
+	}
+}
+
+func TestServer_Check_IgnoresNilMaintenanceChecker(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	refresher := NewRefresher(NewChecker(nil, client), DefaultRefreshInterval)
+	server := NewServer(refresher, nil)
+
+	resp, err := server.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	if err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+	if resp.Status != grpc_health_v1.HealthCheckResponse_SERVING {
+		t.Fatalf("expected SERVING when no maintenance checker is configured, got %v", resp.Status)
+	}
+}
@@ -0,0 +1,112 @@
+// Package i18n holds the message catalog and locale-context plumbing
+// used to localize handler error messages, without touching the
+// gRPC status code a client actually branches on - that stays fixed
+// regardless of locale.
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Locale identifies a message catalog entry's language, e.g. "en".
+// It's always a bare, lowercased base language subtag - ParseAcceptLanguage
+// strips any region ("en-US" becomes "en") since the catalog isn't
+// granular enough to warrant per-region entries.
+type Locale string
+
+// English is the default Locale, used whenever no accept-language
+// header was sent or the requested locale has no catalog entry for a
+// given Reason.
+const English Locale = "en"
+
+// Reason is a stable, machine-readable identifier for a localizable
+// error condition. Handlers keep returning the same gRPC status code
+// for a Reason regardless of locale; only the human-readable message
+// text varies.
+type Reason string
+
+const (
+	ReasonUserNotFound     Reason = "user_not_found"
+	ReasonUsernameRequired Reason = "username_required"
+)
+
+// spanish is the one non-English locale currently in the catalog.
+const spanish Locale = "es"
+
+// catalog holds a fmt template per (Reason, Locale). Templates are
+// looked up with English as the fallback, both when locale itself has
+// no entry and when it has no entry for that specific Reason.
+var catalog = map[Reason]map[Locale]string{
+	ReasonUserNotFound: {
+		English: "user %d not found",
+		spanish: "usuario %d no encontrado",
+	},
+	ReasonUsernameRequired: {
+		English: "username must not be empty",
+		spanish: "el nombre de usuario no puede estar vacío",
+	},
+}
+
+// Message renders reason in locale, formatting the template with args
+// the way fmt.Sprintf would. It falls back to the English template if
+// locale has no entry for reason, and to reason itself (so the failure
+// is still visible rather than silently swallowed) if even English has
+// none.
+func Message(locale Locale, reason Reason, args ...interface{}) string {
+	templates, ok := catalog[reason]
+	if !ok {
+		return string(reason)
+	}
+	template, ok := templates[locale]
+	if !ok {
+		template, ok = templates[English]
+		if !ok {
+			return string(reason)
+		}
+	}
+	return fmt.Sprintf(template, args...)
+}
+
+// localeContextKey is unexported so only this package can set or read
+// the locale stored on a context.
+type localeContextKey struct{}
+
+// ContextWithLocale returns a copy of ctx carrying locale, for
+// server.LocaleUnaryInterceptor to attach the caller's requested
+// language to the request context.
+func ContextWithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, locale)
+}
+
+// LocaleFromContext returns the Locale attached by ContextWithLocale,
+// or English if ctx has none.
+func LocaleFromContext(ctx context.Context) Locale {
+	locale, ok := ctx.Value(localeContextKey{}).(Locale)
+	if !ok {
+		return English
+	}
+	return locale
+}
+
+// ParseAcceptLanguage extracts the highest-priority base language from
+// an HTTP-style Accept-Language header value (e.g.
+// "fr-FR,fr;q=0.9,en;q=0.8"), ignoring quality values and falling back
+// to English for an empty or unparseable header. It doesn't attempt
+// full RFC 4647 quality-weighted negotiation - the catalog only has a
+// handful of locales, so picking the first listed tag is enough.
+func ParseAcceptLanguage(header string) Locale {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return English
+	}
+	first := strings.Split(header, ",")[0]
+	first = strings.Split(first, ";")[0]
+	first = strings.Split(first, "-")[0]
+	first = strings.ToLower(strings.TrimSpace(first))
+	if first == "" {
+		return English
+	}
+	return Locale(first)
+}
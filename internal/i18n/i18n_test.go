@@ -0,0 +1,58 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMessage_ReturnsEnglishByDefault(t *testing.T) {
+	got := Message(English, ReasonUserNotFound, 42)
+	want := "user 42 not found"
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestMessage_ReturnsSpanishWhenRequested(t *testing.T) {
+	got := Message(Locale("es"), ReasonUserNotFound, 42)
+	want := "usuario 42 no encontrado"
+	if got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestMessage_FallsBackToEnglishForAnUnknownLocale(t *testing.T) {
+	got := Message(Locale("klingon"), ReasonUserNotFound, 42)
+	want := "user 42 not found"
+	if got != want {
+		t.Fatalf("expected the fallback to English, got %q", got)
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	cases := map[string]Locale{
+		"":                       English,
+		"en":                     English,
+		"es":                     "es",
+		"fr-FR,fr;q=0.9,en;q=0.8": "fr",
+		"  ES-mx  ":              "es",
+	}
+	for header, want := range cases {
+		if got := ParseAcceptLanguage(header); got != want {
+			t.Fatalf("ParseAcceptLanguage(%q): expected %q, got %q", header, want, got)
+		}
+	}
+}
+
+func TestContextWithLocale_RoundTrips(t *testing.T) {
+	ctx := ContextWithLocale(context.Background(), Locale("es"))
+	if got := LocaleFromContext(ctx); got != "es" {
+		t.Fatalf("expected the locale set on the context to round-trip, got %q", got)
+	}
+}
+
+func TestLocaleFromContext_DefaultsToEnglish(t *testing.T) {
+	if got := LocaleFromContext(context.Background()); got != English {
+		t.Fatalf("expected English for a context with no locale set, got %q", got)
+	}
+}
@@ -0,0 +1,69 @@
+// Package logger constructs the zap.Logger used across
+// JollyRogerUserService.
+package logger
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Encoding selects the zap output format.
+type Encoding string
+
+const (
+	EncodingJSON    Encoding = "json"
+	EncodingConsole Encoding = "console"
+)
+
+// Config controls the level, encoding, and sampling of the process-wide
+// logger. The zero value yields an unsampled JSON logger at info level;
+// DefaultConfig is the production-shaped starting point.
+type Config struct {
+	// Level is a zap level name (debug, info, warn, error). An invalid
+	// or empty value falls back to info.
+	Level string
+	// Encoding is EncodingJSON for production or EncodingConsole for
+	// local development. Defaults to EncodingJSON.
+	Encoding Encoding
+	// SamplingEnabled turns on zap's default log-sampling policy, which
+	// caps the volume of duplicate log lines under heavy load.
+	SamplingEnabled bool
+}
+
+// DefaultConfig is production-shaped: JSON at info level, sampled.
+func DefaultConfig() Config {
+	return Config{Level: "info", Encoding: EncodingJSON, SamplingEnabled: true}
+}
+
+// NewLogger builds the process-wide structured logger from cfg. NewLogger
+// with the zero Config builds a JSON logger at info level like
+// DefaultConfig, except that sampling stays off, since SamplingEnabled
+// is false in the zero value.
+func NewLogger(cfg Config) (*zap.Logger, error) {
+	if cfg.Encoding == "" {
+		cfg.Encoding = EncodingJSON
+	}
+
+	level, err := zapcore.ParseLevel(cfg.Level)
+	if err != nil {
+		level = zapcore.InfoLevel
+	}
+
+	var zapCfg zap.Config
+	switch cfg.Encoding {
+	case EncodingConsole:
+		zapCfg = zap.NewDevelopmentConfig()
+	case EncodingJSON:
+		zapCfg = zap.NewProductionConfig()
+	default:
+		return nil, fmt.Errorf("logger: unknown encoding %q", cfg.Encoding)
+	}
+	zapCfg.Level = zap.NewAtomicLevelAt(level)
+	if !cfg.SamplingEnabled {
+		zapCfg.Sampling = nil
+	}
+
+	return zapCfg.Build()
+}
@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+)
+
+func TestNewLogger_ParsesValidLevel(t *testing.T) {
+	log, err := NewLogger(Config{Level: "debug", Encoding: EncodingConsole})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	if !log.Core().Enabled(zapcore.DebugLevel) {
+		t.Fatalf("expected debug level to be enabled")
+	}
+}
+
+func TestNewLogger_InvalidLevelDefaultsToInfo(t *testing.T) {
+	log, err := NewLogger(Config{Level: "not-a-level", Encoding: EncodingConsole})
+	if err != nil {
+		t.Fatalf("NewLogger: %v", err)
+	}
+	if log.Core().Enabled(zapcore.DebugLevel) {
+		t.Fatalf("expected an invalid level to fall back to info, not debug")
+	}
+}
+
+func TestNewLogger_UnknownEncodingErrors(t *testing.T) {
+	if _, err := NewLogger(Config{Encoding: "xml"}); err == nil {
+		t.Fatalf("expected an error for an unknown encoding")
+	}
+}
@@ -0,0 +1,206 @@
+// Package metrics defines the Prometheus instruments exported by
+// JollyRogerUserService.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// DBSlowOperationsTotal counts repository operations that exceeded the
+// configured slow-query threshold, labeled by operation name.
+var DBSlowOperationsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "jollyroger_db_slow_operations_total",
+		Help: "Number of repository operations that exceeded the slow-query threshold.",
+	},
+	[]string{"operation"},
+)
+
+// RetryAttemptsTotal counts retry attempts made by resilience.WithRetry,
+// labeled by operation name and by whether that particular retry
+// succeeded or failed. The first attempt is never counted, only the
+// retries that followed a failure - this is what lets an alert
+// distinguish an operation that's retrying and recovering from one
+// that's stuck in a retry storm.
+var RetryAttemptsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "jollyroger_retry_attempts_total",
+		Help: "Number of retry attempts made after a failed operation, labeled by operation and outcome.",
+	},
+	[]string{"operation", "outcome"},
+)
+
+// CircuitBreakerTransitionsTotal counts state transitions of a
+// resilience.CircuitBreaker, labeled by breaker name and the state it
+// transitioned into (open, half_open, closed).
+var CircuitBreakerTransitionsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "jollyroger_circuit_breaker_transitions_total",
+		Help: "Number of circuit breaker state transitions, labeled by breaker name and new state.",
+	},
+	[]string{"breaker", "state"},
+)
+
+// CircuitBreakerTripsTotal counts the specific transitions of a
+// resilience.CircuitBreaker into the open state, labeled by breaker
+// name (surfaced as "operation" for consistency with the other
+// resilience metrics). CircuitBreakerTransitionsTotal already carries
+// this information, but querying it back out means filtering on a
+// label value - this is the metric to alert on directly for a flapping
+// breaker.
+var CircuitBreakerTripsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "jollyroger_circuit_breaker_trips_total",
+		Help: "Number of times a circuit breaker has tripped open, labeled by operation.",
+	},
+	[]string{"operation"},
+)
+
+// CacheWriteFailuresTotal counts cache write failures in
+// ResilientCacheRepository's Set* methods, labeled by operation. Those
+// methods log each failure at Warn and otherwise degrade gracefully
+// (a write that fails to cache just means the next read falls through
+// to Postgres), so this counter is the only aggregate signal that
+// something is sustained rather than a one-off blip.
+var CacheWriteFailuresTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "jollyroger_cache_write_failures_total",
+		Help: "Number of cache write failures, labeled by operation.",
+	},
+	[]string{"operation"},
+)
+
+// CacheWriteBehindQueueFullTotal counts SetUser calls that fell back to
+// a synchronous write because ResilientCacheRepository's write-behind
+// queue was full. A sustained rise means the background worker can't
+// keep up with the write rate and CACHE_WRITE_BEHIND_QUEUE_SIZE (or the
+// write rate itself) needs attention.
+var CacheWriteBehindQueueFullTotal = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Name: "jollyroger_cache_write_behind_queue_full_total",
+		Help: "Number of SetUser calls that fell back to a synchronous write because the write-behind queue was full.",
+	},
+)
+
+// CacheWriteBehindFailuresTotal counts write-behind writes that failed
+// when the background worker applied them. Unlike CacheWriteFailuresTotal
+// these failures have no caller left waiting to see the error returned,
+// so this counter is the only signal they happened at all.
+var CacheWriteBehindFailuresTotal = promauto.NewCounter(
+	prometheus.CounterOpts{
+		Name: "jollyroger_cache_write_behind_failures_total",
+		Help: "Number of write-behind cache writes that failed when the background worker applied them.",
+	},
+)
+
+// InFlightRequests tracks the number of RPCs currently being handled, so
+// a graceful shutdown knows when it's safe to stop the server.
+var InFlightRequests = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "jollyroger_in_flight_requests",
+		Help: "Number of RPCs currently being handled.",
+	},
+)
+
+// ActiveStreams tracks the number of currently-open streaming RPCs,
+// labeled by full method name. Unlike InFlightRequests (which also
+// counts short-lived unary calls), this is scoped to
+// server.InFlightTracker.StreamInterceptor so a stream that a client
+// never disconnects from - and would otherwise leak silently - shows up
+// as a gauge that only ever grows.
+var ActiveStreams = promauto.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "jollyroger_active_streams",
+		Help: "Number of currently-open streaming RPCs, labeled by full method name.",
+	},
+	[]string{"method"},
+)
+
+// ShutdownDurationSeconds tracks how long the most recent graceful
+// shutdown sequence (server.GracefulShutdown.Shutdown) took, end to end.
+var ShutdownDurationSeconds = promauto.NewGauge(
+	prometheus.GaugeOpts{
+		Name: "jollyroger_shutdown_duration_seconds",
+		Help: "Duration of the most recent graceful shutdown sequence, in seconds.",
+	},
+)
+
+// ShutdownErrorsTotal counts shutdown funcs registered with
+// server.GracefulShutdown.AddShutdownFunc that returned an error,
+// labeled by the name they were registered under.
+var ShutdownErrorsTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "jollyroger_shutdown_errors_total",
+		Help: "Number of graceful shutdown funcs that returned an error, labeled by func name.",
+	},
+	[]string{"name"},
+)
+
+// UserReadSourceTotal counts service-level reads that could be served
+// from cache, labeled by the read (e.g. "GetUser", "GetUserPreferences")
+// and whether it actually came from cache or fell through to Postgres.
+// Labeled by operation rather than a finer-grained key (e.g. user id) to
+// keep cardinality small.
+var UserReadSourceTotal = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "jollyroger_user_read_source_total",
+		Help: "Number of user-facing reads served from cache vs Postgres, labeled by operation and source.",
+	},
+	[]string{"operation", "source"},
+)
+
+// Default*Buckets are the histogram boundaries used by the latency
+// histograms below, tuned to this service's actual profile: cache hits
+// resolve in well under a millisecond, DB round trips in the low tens
+// of milliseconds, and gRPC calls span both plus network overhead.
+// They're package vars rather than inline literals so a deployment with
+// a different profile can override them (reassign the var, then build
+// the histogram with the matching *Opts function) before the histogram
+// is registered.
+var (
+	DefaultCacheOperationDurationBuckets = []float64{0.0001, 0.00025, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05}
+	DefaultDBOperationDurationBuckets    = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+	DefaultGRPCRequestDurationBuckets    = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+)
+
+// cacheOperationDurationOpts, dbOperationDurationOpts, and
+// grpcRequestDurationOpts build the HistogramOpts for the histograms
+// below from a caller-supplied bucket set, so tests can exercise the
+// exact configuration a custom deployment would use without touching
+// the package-level vars or the default registry.
+func cacheOperationDurationOpts(buckets []float64) prometheus.HistogramOpts {
+	return prometheus.HistogramOpts{
+		Name:    "jollyroger_cache_operation_duration_seconds",
+		Help:    "Duration of cache repository operations, labeled by operation name.",
+		Buckets: buckets,
+	}
+}
+
+func dbOperationDurationOpts(buckets []float64) prometheus.HistogramOpts {
+	return prometheus.HistogramOpts{
+		Name:    "jollyroger_db_operation_duration_seconds",
+		Help:    "Duration of repository operations against Postgres, labeled by operation name.",
+		Buckets: buckets,
+	}
+}
+
+func grpcRequestDurationOpts(buckets []float64) prometheus.HistogramOpts {
+	return prometheus.HistogramOpts{
+		Name:    "jollyroger_grpc_request_duration_seconds",
+		Help:    "Duration of unary gRPC requests, labeled by full method name.",
+		Buckets: buckets,
+	}
+}
+
+// CacheOperationDuration times cache repository operations, labeled by
+// operation name (e.g. "GetUser").
+var CacheOperationDuration = promauto.NewHistogramVec(cacheOperationDurationOpts(DefaultCacheOperationDurationBuckets), []string{"operation"})
+
+// DBOperationDuration times repository operations against Postgres,
+// labeled by operation name.
+var DBOperationDuration = promauto.NewHistogramVec(dbOperationDurationOpts(DefaultDBOperationDurationBuckets), []string{"operation"})
+
+// GRPCRequestDuration times unary gRPC requests, labeled by full method
+// name (e.g. "/jollyroger.user.v1.UserService/GetUser").
+var GRPCRequestDuration = promauto.NewHistogramVec(grpcRequestDurationOpts(DefaultGRPCRequestDurationBuckets), []string{"method"})
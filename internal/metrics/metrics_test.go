@@ -0,0 +1,30 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestDBOperationDurationOpts_AppliesCustomBuckets(t *testing.T) {
+	buckets := []float64{0.5, 1, 2}
+	hist := prometheus.NewHistogramVec(dbOperationDurationOpts(buckets), []string{"operation"})
+
+	hist.WithLabelValues("CreateUser").Observe(0.7)
+
+	var m dto.Metric
+	if err := hist.WithLabelValues("CreateUser").(prometheus.Histogram).Write(&m); err != nil {
+		t.Fatalf("write metric: %v", err)
+	}
+
+	got := m.GetHistogram().GetBucket()
+	if len(got) != len(buckets) {
+		t.Fatalf("expected %d buckets, got %d", len(buckets), len(got))
+	}
+	for i, want := range buckets {
+		if got[i].GetUpperBound() != want {
+			t.Fatalf("bucket %d: expected upper bound %v, got %v", i, want, got[i].GetUpperBound())
+		}
+	}
+}
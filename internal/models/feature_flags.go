@@ -0,0 +1,44 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// FeatureFlags is a per-user set of boolean experiment toggles, stored
+// as a jsonb column so a new flag can be introduced without a schema
+// migration.
+type FeatureFlags map[string]bool
+
+// Value implements driver.Valuer so GORM can write FeatureFlags to a
+// jsonb column.
+func (f FeatureFlags) Value() (driver.Value, error) {
+	if f == nil {
+		return "{}", nil
+	}
+	return json.Marshal(f)
+}
+
+// Scan implements sql.Scanner so GORM can read a jsonb column back into
+// FeatureFlags.
+func (f *FeatureFlags) Scan(value interface{}) error {
+	if value == nil {
+		*f = FeatureFlags{}
+		return nil
+	}
+	var raw []byte
+	switch v := value.(type) {
+	case []byte:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return fmt.Errorf("models: unsupported type %T for FeatureFlags.Scan", value)
+	}
+	if len(raw) == 0 {
+		*f = FeatureFlags{}
+		return nil
+	}
+	return json.Unmarshal(raw, f)
+}
@@ -0,0 +1,20 @@
+package models
+
+// GeoPoint is a WGS 84 latitude/longitude pair. It exists so a
+// latitude and a longitude travel together as one value instead of two
+// positional float64 parameters, which are easy to pass in the wrong
+// order.
+type GeoPoint struct {
+	Lat float64
+	Lon float64
+}
+
+// Valid reports whether p falls within the valid range for a WGS 84
+// coordinate: latitude in [-90, 90], longitude in [-180, 180]. A point
+// with lat and lon swapped is often still in range (most inhabited
+// latitudes are valid longitudes too), so Valid catches the coordinates
+// that are obviously wrong but isn't a substitute for passing a
+// GeoPoint in the first place.
+func (p GeoPoint) Valid() bool {
+	return p.Lat >= -90 && p.Lat <= 90 && p.Lon >= -180 && p.Lon <= 180
+}
@@ -0,0 +1,41 @@
+package models
+
+import "testing"
+
+func TestGeoPoint_Valid_Boundaries(t *testing.T) {
+	tests := []struct {
+		name string
+		p    GeoPoint
+		want bool
+	}{
+		{"origin", GeoPoint{Lat: 0, Lon: 0}, true},
+		{"max lat", GeoPoint{Lat: 90, Lon: 0}, true},
+		{"min lat", GeoPoint{Lat: -90, Lon: 0}, true},
+		{"max lon", GeoPoint{Lat: 0, Lon: 180}, true},
+		{"min lon", GeoPoint{Lat: 0, Lon: -180}, true},
+		{"lat just over max", GeoPoint{Lat: 90.0001, Lon: 0}, false},
+		{"lat just under min", GeoPoint{Lat: -90.0001, Lon: 0}, false},
+		{"lon just over max", GeoPoint{Lat: 0, Lon: 180.0001}, false},
+		{"lon just under min", GeoPoint{Lat: 0, Lon: -180.0001}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.p.Valid(); got != tt.want {
+				t.Fatalf("GeoPoint{%v, %v}.Valid() = %v, want %v", tt.p.Lat, tt.p.Lon, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGeoPoint_Valid_CatchesASwappedCoordinate(t *testing.T) {
+	tokyo := GeoPoint{Lat: 35.6764, Lon: 139.6500}
+	if !tokyo.Valid() {
+		t.Fatalf("expected %+v to be valid", tokyo)
+	}
+
+	swapped := GeoPoint{Lat: tokyo.Lon, Lon: tokyo.Lat}
+	if swapped.Valid() {
+		t.Fatalf("expected swapping lat/lon on %+v to produce an invalid point, got %+v", tokyo, swapped)
+	}
+}
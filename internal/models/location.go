@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// CurrentLocationLabel is the label UpdateUserLocation uses when a
+// caller doesn't specify one, and the only label that participates in
+// the Redis debounce/fast-path cache and nearby-user search.
+const CurrentLocationLabel = "current"
+
+// UserLocation is one of a user's saved positions (e.g. "current",
+// "home", "work"), identified by the pair (UserID, Label).
+type UserLocation struct {
+	UserID    uint    `gorm:"primaryKey" json:"user_id"`
+	Label     string  `gorm:"primaryKey;size:32" json:"label"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	// Country is normalized to its ISO 3166-1 alpha-2 code (e.g. "RU")
+	// by the service layer before being stored, so filtering never has
+	// to reconcile "Russia"/"RU"/"russia" as distinct values.
+	Country   string    `gorm:"size:2;index" json:"country"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (UserLocation) TableName() string {
+	return "user_locations"
+}
+
+// Point returns l's coordinates as a GeoPoint.
+func (l UserLocation) Point() GeoPoint {
+	return GeoPoint{Lat: l.Latitude, Lon: l.Longitude}
+}
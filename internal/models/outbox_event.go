@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// OutboxEvent is a domain event awaiting relay to Redis. It's written in
+// the same database transaction as the change that produced it (see
+// PostgresUserRepository.CreateUser), so the event can never be lost
+// between a commit and its publish - the classic dual-write problem a
+// direct "commit, then publish" would have. repository.OutboxRepository
+// and service.OutboxRelay implement the relay side.
+type OutboxEvent struct {
+	ID      uint   `gorm:"primaryKey" json:"id"`
+	Topic   string `gorm:"index;not null" json:"topic"`
+	Payload string `gorm:"type:jsonb;not null" json:"payload"`
+	// PublishedAt is nil until OutboxRelay has successfully published
+	// this row to Redis, at which point it's stamped so the row is
+	// never relayed again.
+	PublishedAt *time.Time `gorm:"index" json:"published_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func (OutboxEvent) TableName() string {
+	return "outbox_events"
+}
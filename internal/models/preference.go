@@ -0,0 +1,16 @@
+package models
+
+// Preference is a single tagged preference a user has opted into, e.g.
+// for matching search filters.
+type Preference struct {
+	ID uint `gorm:"primaryKey" json:"id"`
+	// UserID and Tag together are unique, so AddPreference's
+	// ON CONFLICT DO NOTHING can treat re-adding a tag the user already
+	// has as an idempotent no-op instead of a duplicate-key error.
+	UserID uint   `gorm:"uniqueIndex:idx_preferences_user_tag;index;not null" json:"user_id"`
+	Tag    string `gorm:"uniqueIndex:idx_preferences_user_tag;index;not null" json:"tag"`
+}
+
+func (Preference) TableName() string {
+	return "preferences"
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// UserRatingEvent is an immutable record of a single rating contribution
+// for a user. It is the source of truth RecomputeUserRating rebuilds
+// User's RatingSum/RatingCount/Rating from if they ever drift, and
+// GetRatingHistory reads it directly for dispute resolution and
+// "recent feedback" UIs.
+type UserRatingEvent struct {
+	ID     uint `gorm:"primaryKey" json:"id"`
+	UserID uint `gorm:"index;not null" json:"user_id"`
+	// RaterID is who left the rating, or 0 if the rating wasn't
+	// attributed to a specific user (e.g. a system adjustment).
+	RaterID   uint      `gorm:"index" json:"rater_id,omitempty"`
+	Score     float64   `json:"score"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (UserRatingEvent) TableName() string {
+	return "user_rating_events"
+}
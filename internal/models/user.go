@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// User is the canonical persisted representation of a JollyRoger account.
+type User struct {
+	ID         uint      `gorm:"primaryKey" json:"id"`
+	TelegramID int64     `gorm:"uniqueIndex;not null" json:"telegram_id"`
+	Username   string    `gorm:"index" json:"username"`
+	FirstName  string    `json:"first_name"`
+	LastName   string    `json:"last_name"`
+	// PhotoURL is the user's avatar. Validated by
+	// service.validPhotoURL before it's ever persisted.
+	PhotoURL string `json:"photo_url"`
+	Banned   bool   `gorm:"index;not null;default:false" json:"banned"`
+	// IsBot marks Telegram bot accounts, so listings and searches that
+	// only make sense for humans (e.g. nearby-user search) can filter
+	// them out.
+	IsBot     bool      `gorm:"index;not null;default:false" json:"is_bot"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	// LastActiveAt is the last time the user was seen doing something
+	// (e.g. a location ping). It drives background jobs that clean up
+	// state held for accounts that have gone inactive.
+	LastActiveAt time.Time `gorm:"index" json:"last_active_at"`
+	// Rating is RatingSum/RatingCount, kept denormalized so ranked
+	// listings (e.g. FindNearbyUsers with sort_by="rating") can order by
+	// it directly instead of aggregating UserRatingEvent rows on every
+	// read. UpdateUserRating keeps it in sync incrementally;
+	// RecomputeUserRating rebuilds it from the event log if it ever
+	// drifts.
+	Rating float64 `gorm:"index" json:"rating"`
+	// RatingSum and RatingCount are the running aggregate Rating is
+	// derived from, so RecomputeUserRating can distinguish "no ratings
+	// yet" from "rating happens to be zero".
+	RatingSum   float64 `json:"rating_sum"`
+	RatingCount int64   `json:"rating_count"`
+	// Features holds per-user experiment toggles, keyed by feature name.
+	// See UserRepository.SetUserFeature.
+	Features FeatureFlags `gorm:"type:jsonb" json:"features"`
+}
+
+// TableName pins the GORM table name so renames of the struct don't
+// silently migrate data to a new table.
+func (User) TableName() string {
+	return "users"
+}
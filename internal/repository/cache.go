@@ -0,0 +1,906 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+)
+
+// idempotencyLockTTL bounds how long a single in-flight CreateUser call
+// holds the lock for a given idempotency key, so a crashed request can't
+// wedge the key forever.
+const idempotencyLockTTL = 10 * time.Second
+
+// defaultUserCacheSoftTTL is the TTL of the normal user cache entry
+// (userKey). Once it expires, GetUser is a miss and the caller falls
+// back to GetStaleUser, whose longer-lived copy (see
+// defaultUserCacheHardTTL) is served immediately while the cache is
+// refreshed in the background — see UserService.GetUser.
+const defaultUserCacheSoftTTL = 15 * time.Minute
+
+// defaultUserCacheHardTTL is the TTL of the stale fallback copy
+// (staleUserKey), far longer than defaultUserCacheSoftTTL so it's still
+// around both to absorb a soft-TTL expiry and to serve during a
+// Postgres outage that outlasts a normal cache entry's lifetime.
+const defaultUserCacheHardTTL = 24 * time.Hour
+
+// defaultPreferencesCacheTTL is how long a cached set of preference tags
+// survives before it must be re-read from Postgres. Preferences don't
+// have GetUser's stale-while-revalidate behavior, so a single TTL is
+// enough.
+const defaultPreferencesCacheTTL = 15 * time.Minute
+
+// defaultFeatureFlagsCacheTTL is how long a cached set of feature flags
+// survives before it must be re-read from Postgres, mirroring
+// defaultPreferencesCacheTTL.
+const defaultFeatureFlagsCacheTTL = 15 * time.Minute
+
+// CacheRepository wraps a Redis client with the higher-level operations
+// needed by the service layer (read-through caching, idempotency, etc.).
+// All keys it builds are namespaced under keyPrefix, so a single Redis
+// instance can safely back multiple environments or tenants.
+type CacheRepository struct {
+	client    *redis.Client
+	keyPrefix string
+	codec     CacheCodec
+
+	// userCacheSoftTTL and userCacheHardTTL are the TTLs of, respectively,
+	// the normal user cache entry and its stale fallback copy. Together
+	// they implement stale-while-revalidate: once the normal entry expires
+	// (userCacheSoftTTL) the caller falls back to the longer-lived stale
+	// copy (userCacheHardTTL) instead of blocking on Postgres. See GetUser
+	// and GetStaleUser.
+	userCacheSoftTTL time.Duration
+	userCacheHardTTL time.Duration
+}
+
+// NewCacheRepository constructs a CacheRepository over an already-configured
+// Redis client, serializing values with JSONCodec. keyPrefix is prepended
+// to every key this repository builds (e.g. "prod:users:"); pass "" when
+// no namespacing is needed.
+func NewCacheRepository(client *redis.Client, keyPrefix string) *CacheRepository {
+	return NewCacheRepositoryWithCodec(client, keyPrefix, JSONCodec)
+}
+
+// NewCacheRepositoryWithCodec is like NewCacheRepository but lets the
+// caller pick a CacheCodec other than the JSON default, e.g. GobCodec for
+// a more compact wire format on hot paths.
+func NewCacheRepositoryWithCodec(client *redis.Client, keyPrefix string, codec CacheCodec) *CacheRepository {
+	return NewCacheRepositoryWithTTLs(client, keyPrefix, codec, defaultUserCacheSoftTTL, defaultUserCacheHardTTL)
+}
+
+// NewCacheRepositoryWithTTLs is like NewCacheRepositoryWithCodec but lets
+// the caller override the soft/hard TTL pair GetUser uses for its
+// stale-while-revalidate behavior. softTTL/hardTTL <= 0 fall back to
+// defaultUserCacheSoftTTL/defaultUserCacheHardTTL.
+func NewCacheRepositoryWithTTLs(client *redis.Client, keyPrefix string, codec CacheCodec, softTTL, hardTTL time.Duration) *CacheRepository {
+	if codec == nil {
+		codec = JSONCodec
+	}
+	if softTTL <= 0 {
+		softTTL = defaultUserCacheSoftTTL
+	}
+	if hardTTL <= 0 {
+		hardTTL = defaultUserCacheHardTTL
+	}
+	return &CacheRepository{
+		client:           client,
+		keyPrefix:        keyPrefix,
+		codec:            codec,
+		userCacheSoftTTL: softTTL,
+		userCacheHardTTL: hardTTL,
+	}
+}
+
+// Ping checks that Redis is reachable, respecting ctx's deadline.
+func (c *CacheRepository) Ping(ctx context.Context) error {
+	return c.client.Ping(ctx).Err()
+}
+
+func (c *CacheRepository) key(parts ...string) string {
+	key := parts[0]
+	for _, p := range parts[1:] {
+		key += ":" + p
+	}
+	return c.keyPrefix + key
+}
+
+// codecKey is like key, but folds the active codec's name in as the
+// leading segment for keys whose value is serialized with c.codec, so a
+// deploy that switches codecs can never decode bytes the previous codec
+// wrote.
+func (c *CacheRepository) codecKey(parts ...string) string {
+	return c.key(append([]string{c.codec.Name()}, parts...)...)
+}
+
+func (c *CacheRepository) idempotencyKey(k string) string {
+	return c.codecKey("idempotency", "create_user", k)
+}
+
+func (c *CacheRepository) idempotencyLockKey(k string) string {
+	return c.key("idempotency", "create_user", "lock", k)
+}
+
+func (c *CacheRepository) userKey(id uint) string {
+	return c.codecKey("user", fmt.Sprintf("%d", id))
+}
+
+// geoKey builds the sorted-set key used for a user's geo presence entry.
+func (c *CacheRepository) geoKey() string {
+	return c.key("geo", "users")
+}
+
+// FindNearbyUserIDs is a thin wrapper around FindNearbyUserIDsAt for
+// callers that still have a separate lat/lon rather than a
+// models.GeoPoint.
+func (c *CacheRepository) FindNearbyUserIDs(ctx context.Context, lat, lon, radiusKm float64, limit int) ([]uint, error) {
+	return c.FindNearbyUserIDsAt(ctx, models.GeoPoint{Lat: lat, Lon: lon}, radiusKm, limit)
+}
+
+// FindNearbyUserIDsAt returns, closest first, the ids of up to limit
+// users within radiusKm of point. Callers are expected to have already
+// clamped radiusKm/limit to the service's configured maximums.
+func (c *CacheRepository) FindNearbyUserIDsAt(ctx context.Context, point models.GeoPoint, radiusKm float64, limit int) ([]uint, error) {
+	results, err := c.FindNearbyUsersAt(ctx, point, radiusKm, limit)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	return ids, nil
+}
+
+// GeoSearchResult pairs a nearby user's id with its distance from the
+// search point, in kilometers. Keeping the distance alongside the id
+// lets a cached search computed at one radius be filtered down to a
+// smaller radius entirely in Go, without knowing the original query
+// point or re-issuing a GEORADIUS_RO. See FindNearbyUserIDsCachedAt.
+type GeoSearchResult struct {
+	ID         uint
+	DistanceKm float64
+}
+
+// FindNearbyUsersAt is FindNearbyUserIDsAt, but returns each match's
+// distance from point alongside its id, closest first.
+//
+// This uses GEORADIUS_RO rather than GEOSEARCH: it's the older,
+// deprecated command, but it's what our target Redis deployments and
+// test doubles both actually support.
+func (c *CacheRepository) FindNearbyUsersAt(ctx context.Context, point models.GeoPoint, radiusKm float64, limit int) ([]GeoSearchResult, error) {
+	locations, err := c.client.GeoRadius(ctx, c.geoKey(), point.Lon, point.Lat, &redis.GeoRadiusQuery{
+		Radius:   radiusKm,
+		Unit:     "km",
+		Sort:     "ASC",
+		Count:    limit,
+		WithDist: true,
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+	results := make([]GeoSearchResult, 0, len(locations))
+	for _, loc := range locations {
+		id, err := strconv.ParseUint(loc.Name, 10, 64)
+		if err != nil {
+			continue
+		}
+		results = append(results, GeoSearchResult{ID: uint(id), DistanceKm: loc.Dist})
+	}
+	return results, nil
+}
+
+// defaultGeoResultCacheTTL is how long a cached FindNearbyUsers result
+// set survives before it must be recomputed against live geo data.
+const defaultGeoResultCacheTTL = 30 * time.Second
+
+// defaultGeoCellPrecision is the number of decimal places lat/lon are
+// rounded to when building a geo result cache key, used when
+// FindNearbyUserIDsCached is given a precision <= 0. Each extra decimal
+// place shrinks a grid cell by roughly a factor of 10 (2 ~= 1.1km on a
+// side, 3 ~= 110m): a coarser precision means more queries land in the
+// same cell and reuse a cached result, but the cached results grow
+// stale over a wider area and a query near a cell's edge may miss
+// slightly-nearer users that fell into the neighboring cell.
+const defaultGeoCellPrecision = 2
+
+// geoResultKey builds the cache key for a FindNearbyUsers result set.
+// point is bucketed to a grid cell by rounding each coordinate to
+// precision decimal places before being folded into the key, so two
+// query points that round to the same cell share a cached result
+// instead of each issuing their own GeoSearch. sortBy is part of the
+// key verbatim, since it changes what order the result set is in.
+// radiusKm and limit are deliberately not part of the key: a cached
+// result computed for a larger radius/limit can answer a request for a
+// smaller one (see FindNearbyUserIDsCachedAt), so folding them in here
+// would only fragment the cache into misses that could have been
+// served from an existing entry.
+func (c *CacheRepository) geoResultKey(point models.GeoPoint, sortBy string, precision int) string {
+	return c.codecKey("geo", "search",
+		strconv.FormatFloat(roundToGridCell(point.Lat, precision), 'f', precision, 64),
+		strconv.FormatFloat(roundToGridCell(point.Lon, precision), 'f', precision, 64),
+		sortBy,
+	)
+}
+
+// roundToGridCell rounds v to precision decimal places.
+func roundToGridCell(v float64, precision int) float64 {
+	scale := math.Pow(10, float64(precision))
+	return math.Round(v*scale) / scale
+}
+
+// FindNearbyUserIDsCached is a thin wrapper around
+// FindNearbyUserIDsCachedAt for callers that still have a separate
+// lat/lon rather than a models.GeoPoint.
+func (c *CacheRepository) FindNearbyUserIDsCached(ctx context.Context, lat, lon, radiusKm float64, limit int, sortBy string, precision int) ([]uint, error) {
+	return c.FindNearbyUserIDsCachedAt(ctx, models.GeoPoint{Lat: lat, Lon: lon}, radiusKm, limit, sortBy, precision)
+}
+
+// geoSearchCacheVersion guards cachedGeoSearch's on-disk shape. Bump it
+// whenever the struct changes so a deploy never unmarshals bytes a
+// previous version wrote into a struct that no longer matches them.
+const geoSearchCacheVersion = 1
+
+// cachedGeoSearch is the envelope stored under a geoResultKey. It
+// records the radius and limit the underlying GeoSearch was actually
+// run with, alongside the results, so a later request for a smaller
+// radius or limit can tell whether this entry is a safe superset of
+// what it's asking for. See subsetWithinRadius.
+type cachedGeoSearch struct {
+	Version  int
+	RadiusKm float64
+	Limit    int
+	Results  []GeoSearchResult
+}
+
+// subsetWithinRadius reports whether cached's result set - computed for
+// cached.RadiusKm/cached.Limit - can also answer a request for radiusKm
+// and limit without a fresh GeoSearch, and if so returns the matching
+// ids, closest first.
+//
+// cached.Results is sorted ascending by distance and was capped at
+// cached.Limit by the original GEORADIUS_RO COUNT. If that cap actually
+// truncated the result set, everything beyond the farthest returned
+// distance is unknown: there could be a user just inside radiusKm that
+// didn't make the cut for reasons unrelated to radiusKm. So a smaller
+// request can only reuse a truncated cache entry when the farthest
+// distance it did return is still >= radiusKm; an untruncated entry
+// (fewer results came back than the cap allowed) has no such gap and
+// can always be reused for any radiusKm <= cached.RadiusKm.
+func subsetWithinRadius(cached cachedGeoSearch, radiusKm float64, limit int) ([]uint, bool) {
+	if radiusKm > cached.RadiusKm {
+		return nil, false
+	}
+	truncated := cached.Limit > 0 && len(cached.Results) == cached.Limit
+	if truncated && cached.Results[len(cached.Results)-1].DistanceKm < radiusKm {
+		return nil, false
+	}
+
+	ids := make([]uint, 0, len(cached.Results))
+	for _, r := range cached.Results {
+		if r.DistanceKm > radiusKm {
+			break
+		}
+		ids = append(ids, r.ID)
+		if limit > 0 && len(ids) == limit {
+			break
+		}
+	}
+	return ids, true
+}
+
+// FindNearbyUserIDsCachedAt is like FindNearbyUserIDsAt, but serves from
+// a short-lived cache keyed by the query's grid cell rather than its
+// exact point, so a burst of searches from the same neighborhood (e.g.
+// many users open the app in the same area at once) shares one
+// GeoSearch instead of each paying for their own. sortBy is folded into
+// the key so results ordered differently downstream never collide.
+// precision <= 0 falls back to defaultGeoCellPrecision.
+//
+// A cache entry computed for a larger radius or limit than requested is
+// filtered down in Go via subsetWithinRadius rather than treated as a
+// miss, so a request for radius=5 can be served from a cached radius=10
+// search without hitting Redis again.
+func (c *CacheRepository) FindNearbyUserIDsCachedAt(ctx context.Context, point models.GeoPoint, radiusKm float64, limit int, sortBy string, precision int) ([]uint, error) {
+	if precision <= 0 {
+		precision = defaultGeoCellPrecision
+	}
+	key := c.geoResultKey(point, sortBy, precision)
+
+	if raw, err := c.client.Get(ctx, key).Bytes(); err == nil {
+		var cached cachedGeoSearch
+		if err := c.codec.Unmarshal(raw, &cached); err == nil && cached.Version == geoSearchCacheVersion {
+			if ids, ok := subsetWithinRadius(cached, radiusKm, limit); ok {
+				return ids, nil
+			}
+		}
+	} else if err != redis.Nil {
+		return nil, err
+	}
+
+	results, err := c.FindNearbyUsersAt(ctx, point, radiusKm, limit)
+	if err != nil {
+		return nil, err
+	}
+	cached := cachedGeoSearch{Version: geoSearchCacheVersion, RadiusKm: radiusKm, Limit: limit, Results: results}
+	if raw, err := c.codec.Marshal(cached); err == nil {
+		_ = c.client.Set(ctx, key, raw, defaultGeoResultCacheTTL).Err()
+	}
+	ids := make([]uint, len(results))
+	for i, r := range results {
+		ids[i] = r.ID
+	}
+	return ids, nil
+}
+
+// defaultNearbyCountCacheTTL bounds how long a CountNearbyUsersAt result
+// is cached. It is shorter than defaultGeoResultCacheTTL since a count
+// badge is expected to be refreshed more eagerly than a full result list.
+const defaultNearbyCountCacheTTL = 5 * time.Second
+
+// nearbyCountKey builds the cache key for CountNearbyUsersAt. point is
+// bucketed to a grid cell the same way as geoResultKey so nearby queries
+// share a cached count; unlike geoResultKey, radiusKm is folded in
+// verbatim, since a count computed for one radius can't answer a request
+// for another the way a cached id list can be filtered down in Go.
+func (c *CacheRepository) nearbyCountKey(point models.GeoPoint, radiusKm float64, precision int) string {
+	return c.codecKey("geo", "count",
+		strconv.FormatFloat(roundToGridCell(point.Lat, precision), 'f', precision, 64),
+		strconv.FormatFloat(roundToGridCell(point.Lon, precision), 'f', precision, 64),
+		strconv.FormatFloat(radiusKm, 'f', -1, 64),
+	)
+}
+
+// CountNearbyUsersAt returns the number of users within radiusKm of
+// point, capped at limit, without transferring each match's user row -
+// useful for a UI count badge shown before a caller commits to a full
+// FindNearbyUsers fetch. The count is capped at limit for the same
+// reason FindNearbyUsersAt is: an unbounded GEORADIUS_RO over a dense area
+// is expensive, so a caller sees at most limit rather than an exact
+// count past that point. precision <= 0 falls back to
+// defaultGeoCellPrecision.
+func (c *CacheRepository) CountNearbyUsersAt(ctx context.Context, point models.GeoPoint, radiusKm float64, limit int, precision int) (int64, error) {
+	if precision <= 0 {
+		precision = defaultGeoCellPrecision
+	}
+	key := c.nearbyCountKey(point, radiusKm, precision)
+
+	if cached, err := c.client.Get(ctx, key).Int64(); err == nil {
+		return cached, nil
+	} else if err != redis.Nil {
+		return 0, err
+	}
+
+	ids, err := c.FindNearbyUserIDsAt(ctx, point, radiusKm, limit)
+	if err != nil {
+		return 0, err
+	}
+	count := int64(len(ids))
+	if err := c.client.Set(ctx, key, count, defaultNearbyCountCacheTTL).Err(); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// AcquireIdempotencyLock attempts to take a short-lived lock for the given
+// idempotency key so that concurrent replays of the same request don't
+// both fall through to the database. It returns true if the lock was
+// acquired by this call.
+func (c *CacheRepository) AcquireIdempotencyLock(ctx context.Context, key string) (bool, error) {
+	return c.client.SetNX(ctx, c.idempotencyLockKey(key), "1", idempotencyLockTTL).Result()
+}
+
+// ReleaseIdempotencyLock releases a previously acquired lock.
+func (c *CacheRepository) ReleaseIdempotencyLock(ctx context.Context, key string) error {
+	return c.client.Del(ctx, c.idempotencyLockKey(key)).Err()
+}
+
+// GetIdempotentResult returns the cached result for a previously seen
+// idempotency key, if any. It returns (false, nil) on a cache miss.
+func (c *CacheRepository) GetIdempotentResult(ctx context.Context, key string, out interface{}) (bool, error) {
+	raw, err := c.client.Get(ctx, c.idempotencyKey(key)).Bytes()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := c.codec.Unmarshal(raw, out); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SaveIdempotentResult stores the result of a CreateUser call under its
+// idempotency key so replays within ttl return the same response.
+func (c *CacheRepository) SaveIdempotentResult(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	raw, err := c.codec.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.idempotencyKey(key), raw, ttl).Err()
+}
+
+// userCacheVersion is bumped whenever models.User's fields change in a
+// way that would break decoding an older cached value. GetUser treats a
+// stored value with a different version as a miss rather than risking a
+// decode error or silently-zeroed new fields.
+const userCacheVersion = 1
+
+// cachedUser is the on-the-wire envelope SetUser/GetUser store in
+// Redis, versioned so a deploy that adds fields to models.User doesn't
+// have to worry about decoding values a previous version wrote.
+type cachedUser struct {
+	Version int         `json:"version"`
+	User    models.User `json:"user"`
+}
+
+// userCacheEntryLive reports whether the normal (soft-TTL) cache entry
+// for id is still present in Redis, without paying to deserialize it.
+// ResilientCacheRepository.GetUser uses this to confirm an L1 hit is
+// still fresh before trusting it, since L1's own TTL is independent of
+// (and not necessarily shorter than, in real elapsed time, than) the
+// Redis-side soft TTL.
+func (c *CacheRepository) userCacheEntryLive(ctx context.Context, id uint) (bool, error) {
+	n, err := c.client.Exists(ctx, c.userKey(id)).Result()
+	return n > 0, err
+}
+
+// GetUser returns the cached user for id, if present. It returns (nil,
+// nil) on a miss, whether because the key was never set, it expired, or
+// it was written by a different userCacheVersion.
+func (c *CacheRepository) GetUser(ctx context.Context, id uint) (*models.User, error) {
+	raw, err := c.client.Get(ctx, c.userKey(id)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cached cachedUser
+	if err := c.codec.Unmarshal(raw, &cached); err != nil {
+		return nil, err
+	}
+	if cached.Version != userCacheVersion {
+		return nil, nil
+	}
+	return &cached.User, nil
+}
+
+// SetUser caches user under its id for userCacheSoftTTL, tagged with the
+// current userCacheVersion.
+func (c *CacheRepository) SetUser(ctx context.Context, user *models.User) error {
+	raw, err := c.codec.Marshal(cachedUser{Version: userCacheVersion, User: *user})
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.userKey(user.ID), raw, c.userCacheSoftTTL).Err()
+}
+
+// ClearUserCache evicts the cached entry for a single user, e.g. after an
+// update that must not be served stale.
+func (c *CacheRepository) ClearUserCache(ctx context.Context, id uint) error {
+	return c.client.Del(ctx, c.userKey(id)).Err()
+}
+
+// staleUserKey builds the cache key for a user's stale fallback copy,
+// kept separate from userKey so evicting or expiring the normal entry
+// (e.g. ClearUserCache after an update) never touches the fallback.
+func (c *CacheRepository) staleUserKey(id uint) string {
+	return c.codecKey("user", "stale", fmt.Sprintf("%d", id))
+}
+
+// SetStaleUser refreshes the long-lived fallback copy of user, served by
+// GetStaleUser once the normal cache entry has expired or missed,
+// whether because of a soft-TTL expiry or because Postgres is
+// unreachable.
+func (c *CacheRepository) SetStaleUser(ctx context.Context, user *models.User) error {
+	raw, err := c.codec.Marshal(cachedUser{Version: userCacheVersion, User: *user})
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.staleUserKey(user.ID), raw, c.userCacheHardTTL).Err()
+}
+
+// GetStaleUser returns the stale fallback copy of the user for id, if
+// present. It returns (nil, nil) on a miss, same as GetUser.
+func (c *CacheRepository) GetStaleUser(ctx context.Context, id uint) (*models.User, error) {
+	raw, err := c.client.Get(ctx, c.staleUserKey(id)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cached cachedUser
+	if err := c.codec.Unmarshal(raw, &cached); err != nil {
+		return nil, err
+	}
+	if cached.Version != userCacheVersion {
+		return nil, nil
+	}
+	return &cached.User, nil
+}
+
+// defaultUserNotFoundTTL is how long a UserExists tombstone survives.
+// It's short relative to defaultUserCacheHardTTL: unlike the stale
+// fallback (a deliberate long-lived copy for an outage), a tombstone is
+// only meant to absorb a burst of repeat lookups for an id that doesn't
+// exist, and should expire quickly enough that a user created shortly
+// after being checked isn't hidden for long.
+const defaultUserNotFoundTTL = 1 * time.Minute
+
+// userNotFoundKey builds the cache key for a UserExists tombstone,
+// namespaced separately from userKey so it can never collide with or be
+// evicted by ClearUserCache.
+func (c *CacheRepository) userNotFoundKey(id uint) string {
+	return c.codecKey("user", "notfound", fmt.Sprintf("%d", id))
+}
+
+// SetUserNotFound records a tombstone for id, so a burst of repeat
+// UserExists lookups for an id that doesn't exist can be answered
+// without hitting Postgres each time.
+func (c *CacheRepository) SetUserNotFound(ctx context.Context, id uint) error {
+	return c.client.Set(ctx, c.userNotFoundKey(id), "1", defaultUserNotFoundTTL).Err()
+}
+
+// IsUserNotFound reports whether id has an active UserExists tombstone.
+func (c *CacheRepository) IsUserNotFound(ctx context.Context, id uint) (bool, error) {
+	err := c.client.Get(ctx, c.userNotFoundKey(id)).Err()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// ClearUserNotFound removes id's tombstone, if any. Callers use this
+// after a write that could make a previously-tombstoned id valid again
+// (the id is created, or a banned user is unbanned), so the tombstone's
+// TTL isn't the only thing standing between that write and a caller
+// seeing it. It's a no-op if id has no tombstone.
+func (c *CacheRepository) ClearUserNotFound(ctx context.Context, id uint) error {
+	return c.client.Del(ctx, c.userNotFoundKey(id)).Err()
+}
+
+// preferencesKey builds the cache key for a user's preference tags,
+// namespaced under their current preferences generation so a
+// BumpPreferencesGeneration call logically invalidates it without
+// having to delete it directly.
+func (c *CacheRepository) preferencesKey(userID uint, generation int64) string {
+	return c.codecKey("preferences", fmt.Sprintf("%d", userID), fmt.Sprintf("g%d", generation))
+}
+
+// preferencesGenerationKey builds the cache key backing a user's
+// preferences generation counter itself.
+func (c *CacheRepository) preferencesGenerationKey(userID uint) string {
+	return c.codecKey("preferences", "gen", fmt.Sprintf("%d", userID))
+}
+
+// preferencesGeneration returns userID's current preferences
+// generation, defaulting to 0 if BumpPreferencesGeneration has never
+// been called for them.
+func (c *CacheRepository) preferencesGeneration(ctx context.Context, userID uint) (int64, error) {
+	gen, err := c.client.Get(ctx, c.preferencesGenerationKey(userID)).Int64()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	return gen, err
+}
+
+// BumpPreferencesGeneration invalidates every cached preferences entry
+// for userID by advancing their generation counter, rather than
+// deleting the cache key directly. This is what makes bulk operations
+// like DeletePreferencesByTag cheap to invalidate for: bumping is one
+// INCR per affected user instead of a DEL that must first know the
+// user's current cache key.
+func (c *CacheRepository) BumpPreferencesGeneration(ctx context.Context, userID uint) error {
+	return c.client.Incr(ctx, c.preferencesGenerationKey(userID)).Err()
+}
+
+// preferencesCacheVersion is bumped whenever the shape of cached
+// preference tags changes, mirroring userCacheVersion.
+const preferencesCacheVersion = 1
+
+// cachedPreferences is the on-the-wire envelope SetPreferenceTags/
+// GetPreferenceTags store in Redis, versioned for the same reason
+// cachedUser is.
+type cachedPreferences struct {
+	Version int      `json:"version"`
+	Tags    []string `json:"tags"`
+}
+
+// GetPreferenceTags returns the cached preference tags for a user. It
+// returns found=false on a cache miss, on a value written by a
+// different preferencesCacheVersion, or on a value from a generation
+// BumpPreferencesGeneration has since superseded, so callers can fall
+// back to Postgres.
+func (c *CacheRepository) GetPreferenceTags(ctx context.Context, userID uint) (tags []string, found bool, err error) {
+	gen, err := c.preferencesGeneration(ctx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	raw, err := c.client.Get(ctx, c.preferencesKey(userID, gen)).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var cached cachedPreferences
+	if err := c.codec.Unmarshal(raw, &cached); err != nil {
+		return nil, false, err
+	}
+	if cached.Version != preferencesCacheVersion {
+		return nil, false, nil
+	}
+	return cached.Tags, true, nil
+}
+
+// SetPreferenceTags caches a user's preference tags for
+// defaultPreferencesCacheTTL, under their current preferences
+// generation and tagged with the current preferencesCacheVersion.
+func (c *CacheRepository) SetPreferenceTags(ctx context.Context, userID uint, tags []string) error {
+	gen, err := c.preferencesGeneration(ctx, userID)
+	if err != nil {
+		return err
+	}
+	raw, err := c.codec.Marshal(cachedPreferences{Version: preferencesCacheVersion, Tags: tags})
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.preferencesKey(userID, gen), raw, defaultPreferencesCacheTTL).Err()
+}
+
+func (c *CacheRepository) featuresKey(userID uint) string {
+	return c.codecKey("features", fmt.Sprintf("%d", userID))
+}
+
+// featureFlagsCacheVersion is bumped whenever the shape of cached feature
+// flags changes, mirroring preferencesCacheVersion.
+const featureFlagsCacheVersion = 1
+
+// cachedFeatureFlags is the on-the-wire envelope SetFeatureFlags/
+// GetFeatureFlags store in Redis, versioned for the same reason
+// cachedPreferences is.
+type cachedFeatureFlags struct {
+	Version int                 `json:"version"`
+	Flags   models.FeatureFlags `json:"flags"`
+}
+
+// GetFeatureFlags returns the cached feature flags for a user. It
+// returns found=false on a cache miss, or on a value written by a
+// different featureFlagsCacheVersion, so callers can fall back to
+// Postgres.
+func (c *CacheRepository) GetFeatureFlags(ctx context.Context, userID uint) (flags models.FeatureFlags, found bool, err error) {
+	raw, err := c.client.Get(ctx, c.featuresKey(userID)).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	var cached cachedFeatureFlags
+	if err := c.codec.Unmarshal(raw, &cached); err != nil {
+		return nil, false, err
+	}
+	if cached.Version != featureFlagsCacheVersion {
+		return nil, false, nil
+	}
+	return cached.Flags, true, nil
+}
+
+// SetFeatureFlags caches a user's feature flags for
+// defaultFeatureFlagsCacheTTL, tagged with the current
+// featureFlagsCacheVersion.
+func (c *CacheRepository) SetFeatureFlags(ctx context.Context, userID uint, flags models.FeatureFlags) error {
+	raw, err := c.codec.Marshal(cachedFeatureFlags{Version: featureFlagsCacheVersion, Flags: flags})
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.featuresKey(userID), raw, defaultFeatureFlagsCacheTTL).Err()
+}
+
+func (c *CacheRepository) locationKey(userID uint) string {
+	return c.codecKey("location", fmt.Sprintf("%d", userID))
+}
+
+// locationCacheVersion is bumped whenever CachedLocation's fields change
+// in a way that would break decoding an older cached value.
+const locationCacheVersion = 1
+
+// cachedLocation is the on-the-wire envelope GetLocationState/
+// SetLocationState store in Redis, versioned for the same reason
+// cachedUser is.
+type cachedLocation struct {
+	Version  int            `json:"version"`
+	Location CachedLocation `json:"location"`
+}
+
+// CachedLocation is the fast-path location state UpdateUserLocation
+// keeps in Redis between debounced flushes to Postgres: the most recent
+// ping, and the position/time of the last ping that was actually
+// persisted.
+type CachedLocation struct {
+	Latitude               float64   `json:"latitude"`
+	Longitude              float64   `json:"longitude"`
+	Country                string    `json:"country"`
+	LastPersistedLatitude  float64   `json:"last_persisted_latitude"`
+	LastPersistedLongitude float64   `json:"last_persisted_longitude"`
+	LastPersistedAt        time.Time `json:"last_persisted_at"`
+}
+
+// GetLocationState returns the cached fast-path location state for a
+// user, if any. A value written by a different locationCacheVersion is
+// treated as a miss.
+func (c *CacheRepository) GetLocationState(ctx context.Context, userID uint) (*CachedLocation, error) {
+	raw, err := c.client.Get(ctx, c.locationKey(userID)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var cached cachedLocation
+	if err := c.codec.Unmarshal(raw, &cached); err != nil {
+		return nil, err
+	}
+	if cached.Version != locationCacheVersion {
+		return nil, nil
+	}
+	return &cached.Location, nil
+}
+
+// SetLocationState overwrites the cached fast-path location state for a
+// user. It has no expiry: it's overwritten on every ping, and a user who
+// stops pinging simply stops updating it.
+func (c *CacheRepository) SetLocationState(ctx context.Context, userID uint, state CachedLocation) error {
+	raw, err := c.codec.Marshal(cachedLocation{Version: locationCacheVersion, Location: state})
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.locationKey(userID), raw, 0).Err()
+}
+
+// dirtyLocationsKey is the set of user ids whose fast-path location
+// state has not yet been persisted to Postgres.
+func (c *CacheRepository) dirtyLocationsKey() string {
+	return c.key("location", "dirty")
+}
+
+// MarkLocationDirty records that userID has a debounced location ping
+// that hasn't been persisted yet, so a shutdown-time flush knows to
+// visit it.
+func (c *CacheRepository) MarkLocationDirty(ctx context.Context, userID uint) error {
+	return c.client.SAdd(ctx, c.dirtyLocationsKey(), userID).Err()
+}
+
+// ClearLocationDirty marks userID's location as persisted, removing it
+// from the pending-flush set.
+func (c *CacheRepository) ClearLocationDirty(ctx context.Context, userID uint) error {
+	return c.client.SRem(ctx, c.dirtyLocationsKey(), userID).Err()
+}
+
+// ListDirtyLocationUserIDs returns the ids of every user with a
+// debounced location ping that hasn't been persisted yet.
+func (c *CacheRepository) ListDirtyLocationUserIDs(ctx context.Context) ([]uint, error) {
+	members, err := c.client.SMembers(ctx, c.dirtyLocationsKey()).Result()
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint, 0, len(members))
+	for _, m := range members {
+		id, err := strconv.ParseUint(m, 10, 64)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, uint(id))
+	}
+	return ids, nil
+}
+
+func (c *CacheRepository) sessionKey(token string) string {
+	return c.key("session", token)
+}
+
+// GetSessionUserID looks up the user a gateway-issued session token
+// belongs to, and the token's remaining TTL. It returns found=false for
+// an unknown or expired token.
+func (c *CacheRepository) GetSessionUserID(ctx context.Context, token string) (userID uint64, ttl time.Duration, found bool, err error) {
+	key := c.sessionKey(token)
+	val, err := c.client.Get(ctx, key).Uint64()
+	if err == redis.Nil {
+		return 0, 0, false, nil
+	}
+	if err != nil {
+		return 0, 0, false, err
+	}
+	ttl, err = c.client.TTL(ctx, key).Result()
+	if err != nil {
+		return 0, 0, false, err
+	}
+	return val, ttl, true, nil
+}
+
+// tagPopularityKey is the sorted set tracking how many users currently
+// have each tag in their preferences, scored by member count, for
+// trend-analytics reads (GetPopularTags). It isn't namespaced with
+// codecKey since its members are plain tag strings, not codec-encoded
+// values.
+func (c *CacheRepository) tagPopularityKey() string {
+	return c.key("tags", "popularity")
+}
+
+// IncrementTagPopularity adjusts tag's popularity score by delta,
+// creating the member with score delta if it didn't already exist. Pass
+// a positive delta when a user adds the tag and a negative delta when
+// they remove it.
+func (c *CacheRepository) IncrementTagPopularity(ctx context.Context, tag string, delta float64) error {
+	return c.client.ZIncrBy(ctx, c.tagPopularityKey(), delta, tag).Err()
+}
+
+// TagCount is one tag's current popularity score, as returned by
+// GetPopularTags.
+type TagCount struct {
+	Tag   string
+	Count float64
+}
+
+// GetPopularTags returns up to limit tags, most popular first. Its
+// counts are maintained incrementally by IncrementTagPopularity rather
+// than computed from Postgres, so they can drift from the source of
+// truth if a caller fails to pair an increment with its matching
+// decrement; ReconcileAll does not currently repair this counter.
+func (c *CacheRepository) GetPopularTags(ctx context.Context, limit int) ([]TagCount, error) {
+	results, err := c.client.ZRevRangeWithScores(ctx, c.tagPopularityKey(), 0, int64(limit)-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	counts := make([]TagCount, len(results))
+	for i, r := range results {
+		counts[i] = TagCount{Tag: fmt.Sprint(r.Member), Count: r.Score}
+	}
+	return counts, nil
+}
+
+// defaultServiceUserCountCacheTTL bounds how long a service-wide user
+// count survives without being refreshed. It's set well past
+// StatsRefresher's own interval, purely as a safety net against the
+// refresher goroutine having died, rather than as the count's normal
+// freshness window - under normal operation StatsRefresher overwrites
+// the value long before this TTL would ever be hit.
+const defaultServiceUserCountCacheTTL = 1 * time.Hour
+
+// serviceUserCountKey is the key StatsRefresher writes the cached
+// service-wide user count under.
+func (c *CacheRepository) serviceUserCountKey() string {
+	return c.key("stats", "user_count")
+}
+
+// SetServiceUserCount caches count as the current service-wide user
+// count, for StatsRefresher to call after each recompute.
+func (c *CacheRepository) SetServiceUserCount(ctx context.Context, count int64) error {
+	return c.client.Set(ctx, c.serviceUserCountKey(), count, defaultServiceUserCountCacheTTL).Err()
+}
+
+// GetServiceUserCount returns the cached service-wide user count set by
+// SetServiceUserCount. found is false if no value has been cached yet
+// (e.g. StatsRefresher hasn't ticked once) or if it expired.
+func (c *CacheRepository) GetServiceUserCount(ctx context.Context) (count int64, found bool, err error) {
+	count, err = c.client.Get(ctx, c.serviceUserCountKey()).Int64()
+	if err == redis.Nil {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return count, true, nil
+}
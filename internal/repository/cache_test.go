@@ -0,0 +1,360 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+)
+
+func TestCacheRepository_KeysAreNamespacedByPrefix(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewCacheRepository(client, "prod:users:")
+
+	user := &models.User{ID: 1, Username: "calico-jack"}
+	if err := repo.SetUser(context.Background(), user); err != nil {
+		t.Fatalf("SetUser: %v", err)
+	}
+
+	var found bool
+	for _, k := range mr.Keys() {
+		if strings.HasPrefix(k, "prod:users:") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a stored key beginning with the configured prefix, got keys %v", mr.Keys())
+	}
+}
+
+func TestCacheRepository_GeoResultKey_BucketsNearbyPointsIntoTheSameCell(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	repo := NewCacheRepository(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "")
+
+	// Two points close enough together to round to the same 2-decimal
+	// grid cell should share a key...
+	same := repo.geoResultKey(models.GeoPoint{Lat: 51.501, Lon: -0.141}, "distance", 2)
+	other := repo.geoResultKey(models.GeoPoint{Lat: 51.504, Lon: -0.144}, "distance", 2)
+	if same != other {
+		t.Fatalf("expected points in the same grid cell to share a key, got %q and %q", same, other)
+	}
+
+	// ...but a point far enough away to land in a different cell should
+	// get a different key.
+	farAway := repo.geoResultKey(models.GeoPoint{Lat: 48.858, Lon: 2.294}, "distance", 2)
+	if same == farAway {
+		t.Fatalf("expected points in different grid cells to have different keys, got %q for both", same)
+	}
+
+	// A different sort_by must not collide with an otherwise identical
+	// query, since it can change the served response.
+	sortedByRating := repo.geoResultKey(models.GeoPoint{Lat: 51.501, Lon: -0.141}, "rating", 2)
+	if same == sortedByRating {
+		t.Fatalf("expected sort_by to be part of the cache key, got %q for both", same)
+	}
+}
+
+func TestCacheRepository_FindNearbyUserIDsCachedAt_ReusesALargerCachedRadiusForASmallerRequest(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewCacheRepository(client, "")
+	ctx := context.Background()
+	point := models.GeoPoint{Lat: 38.7223, Lon: -9.1393}
+
+	if err := client.GeoAdd(ctx, repo.geoKey(),
+		&redis.GeoLocation{Name: "1", Longitude: -9.1393, Latitude: 38.7223}, // ~0km
+		&redis.GeoLocation{Name: "2", Longitude: -9.1500, Latitude: 38.7300}, // ~1.3km out
+	).Err(); err != nil {
+		t.Fatalf("seed geo entries: %v", err)
+	}
+
+	// Populate the cache with a radius=10 search.
+	if _, err := repo.FindNearbyUserIDsCachedAt(ctx, point, 10, 20, "distance", 2); err != nil {
+		t.Fatalf("FindNearbyUserIDsCachedAt(radius=10): %v", err)
+	}
+
+	// Remove the underlying geo data so that any request which falls
+	// through to a live GeoSearch comes back empty, proving that the
+	// following smaller-radius request was actually served from cache.
+	if err := client.ZRem(ctx, repo.geoKey(), "1", "2").Err(); err != nil {
+		t.Fatalf("remove geo entries: %v", err)
+	}
+
+	ids, err := repo.FindNearbyUserIDsCachedAt(ctx, point, 1, 20, "distance", 2)
+	if err != nil {
+		t.Fatalf("FindNearbyUserIDsCachedAt(radius=1): %v", err)
+	}
+	if len(ids) != 1 || ids[0] != 1 {
+		t.Fatalf("expected the subset-reuse hit to return just the co-located user, got %v", ids)
+	}
+}
+
+func TestCacheRepository_FindNearbyUserIDsCachedAt_FallsThroughWhenNoCachedSupersetExists(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewCacheRepository(client, "")
+	ctx := context.Background()
+	point := models.GeoPoint{Lat: 38.7223, Lon: -9.1393}
+
+	if err := client.GeoAdd(ctx, repo.geoKey(),
+		&redis.GeoLocation{Name: "1", Longitude: -9.1393, Latitude: 38.7223}, // ~0km
+		&redis.GeoLocation{Name: "2", Longitude: -9.1500, Latitude: 38.7300}, // ~1.3km out
+	).Err(); err != nil {
+		t.Fatalf("seed geo entries: %v", err)
+	}
+
+	// Populate the cache with a radius=1 search, which cannot cover the
+	// second user.
+	if ids, err := repo.FindNearbyUserIDsCachedAt(ctx, point, 1, 20, "distance", 2); err != nil {
+		t.Fatalf("FindNearbyUserIDsCachedAt(radius=1): %v", err)
+	} else if len(ids) != 1 {
+		t.Fatalf("expected the radius=1 search to find only the co-located user, got %v", ids)
+	}
+
+	// A radius=10 request has no cached superset to reuse, so it must
+	// fall through to a fresh GeoSearch and pick up the farther user.
+	ids, err := repo.FindNearbyUserIDsCachedAt(ctx, point, 10, 20, "distance", 2)
+	if err != nil {
+		t.Fatalf("FindNearbyUserIDsCachedAt(radius=10): %v", err)
+	}
+	if len(ids) != 2 {
+		t.Fatalf("expected the fallthrough search to find both users, got %v", ids)
+	}
+}
+
+func TestCacheRepository_CountNearbyUsersAt_CountsMatchesWithinRadius(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewCacheRepository(client, "")
+	ctx := context.Background()
+	point := models.GeoPoint{Lat: 38.7223, Lon: -9.1393}
+
+	if err := client.GeoAdd(ctx, repo.geoKey(),
+		&redis.GeoLocation{Name: "1", Longitude: -9.1393, Latitude: 38.7223}, // ~0km
+		&redis.GeoLocation{Name: "2", Longitude: -9.1500, Latitude: 38.7300}, // ~1.3km out
+	).Err(); err != nil {
+		t.Fatalf("seed geo entries: %v", err)
+	}
+
+	count, err := repo.CountNearbyUsersAt(ctx, point, 1, 20, 2)
+	if err != nil {
+		t.Fatalf("CountNearbyUsersAt(radius=1): %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected 1 user within 1km, got %d", count)
+	}
+
+	count, err = repo.CountNearbyUsersAt(ctx, point, 10, 20, 2)
+	if err != nil {
+		t.Fatalf("CountNearbyUsersAt(radius=10): %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 users within 10km, got %d", count)
+	}
+}
+
+func TestCacheRepository_CountNearbyUsersAt_ServesFromCacheOnceComputed(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewCacheRepository(client, "")
+	ctx := context.Background()
+	point := models.GeoPoint{Lat: 38.7223, Lon: -9.1393}
+
+	if err := client.GeoAdd(ctx, repo.geoKey(),
+		&redis.GeoLocation{Name: "1", Longitude: -9.1393, Latitude: 38.7223},
+	).Err(); err != nil {
+		t.Fatalf("seed geo entries: %v", err)
+	}
+
+	if count, err := repo.CountNearbyUsersAt(ctx, point, 10, 20, 2); err != nil {
+		t.Fatalf("CountNearbyUsersAt: %v", err)
+	} else if count != 1 {
+		t.Fatalf("expected 1 user, got %d", count)
+	}
+
+	// Remove the underlying geo data so a live GEORADIUS_RO would come back
+	// empty, proving the following call is served from cache.
+	if err := client.ZRem(ctx, repo.geoKey(), "1").Err(); err != nil {
+		t.Fatalf("remove geo entry: %v", err)
+	}
+
+	count, err := repo.CountNearbyUsersAt(ctx, point, 10, 20, 2)
+	if err != nil {
+		t.Fatalf("CountNearbyUsersAt after removal: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("expected the cached count of 1 to survive the removal, got %d", count)
+	}
+}
+
+func TestCacheRepository_GetUser_StaleVersionIsTreatedAsMiss(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewCacheRepository(client, "")
+
+	stale, err := json.Marshal(struct {
+		Version int         `json:"version"`
+		User    models.User `json:"user"`
+	}{Version: userCacheVersion - 1, User: models.User{ID: 1, Username: "old-schema"}})
+	if err != nil {
+		t.Fatalf("marshal stale value: %v", err)
+	}
+	if err := client.Set(context.Background(), repo.userKey(1), stale, defaultUserCacheHardTTL).Err(); err != nil {
+		t.Fatalf("seed stale cache entry: %v", err)
+	}
+
+	user, err := repo.GetUser(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if user != nil {
+		t.Fatalf("expected a version mismatch to be treated as a cache miss, got %+v", user)
+	}
+}
+
+func TestCacheRepository_GetPreferenceTags_StaleVersionIsTreatedAsMiss(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewCacheRepository(client, "")
+
+	stale, err := json.Marshal(cachedPreferences{Version: preferencesCacheVersion - 1, Tags: []string{"old-schema"}})
+	if err != nil {
+		t.Fatalf("marshal stale value: %v", err)
+	}
+	if err := client.Set(context.Background(), repo.preferencesKey(1, 0), stale, defaultPreferencesCacheTTL).Err(); err != nil {
+		t.Fatalf("seed stale cache entry: %v", err)
+	}
+
+	tags, found, err := repo.GetPreferenceTags(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetPreferenceTags: %v", err)
+	}
+	if found {
+		t.Fatalf("expected a version mismatch to be treated as a cache miss, got tags %v", tags)
+	}
+}
+
+func TestCacheRepository_BumpPreferencesGeneration_MakesPriorCachedTagsUnreachable(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewCacheRepository(client, "")
+	ctx := context.Background()
+
+	if err := repo.SetPreferenceTags(ctx, 1, []string{"rum"}); err != nil {
+		t.Fatalf("SetPreferenceTags: %v", err)
+	}
+	if tags, found, err := repo.GetPreferenceTags(ctx, 1); err != nil {
+		t.Fatalf("GetPreferenceTags: %v", err)
+	} else if !found || len(tags) != 1 || tags[0] != "rum" {
+		t.Fatalf("expected the cached tags to be reachable before the bump, got %v found=%v", tags, found)
+	}
+
+	if err := repo.BumpPreferencesGeneration(ctx, 1); err != nil {
+		t.Fatalf("BumpPreferencesGeneration: %v", err)
+	}
+
+	if tags, found, err := repo.GetPreferenceTags(ctx, 1); err != nil {
+		t.Fatalf("GetPreferenceTags: %v", err)
+	} else if found {
+		t.Fatalf("expected the pre-bump cached tags to be unreachable, got %v", tags)
+	}
+
+	// Confirm the generation really did move the key, not just expired
+	// it: writing fresh tags after the bump is reachable again.
+	if err := repo.SetPreferenceTags(ctx, 1, []string{"parrots"}); err != nil {
+		t.Fatalf("SetPreferenceTags after bump: %v", err)
+	}
+	if tags, found, err := repo.GetPreferenceTags(ctx, 1); err != nil {
+		t.Fatalf("GetPreferenceTags: %v", err)
+	} else if !found || len(tags) != 1 || tags[0] != "parrots" {
+		t.Fatalf("expected fresh post-bump tags to be reachable, got %v found=%v", tags, found)
+	}
+}
+
+func TestCacheRepository_TagPopularity_IncrementsAndRanks(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewCacheRepository(client, "")
+	ctx := context.Background()
+
+	for _, tag := range []string{"rum", "rum", "rum", "parrots", "parrots"} {
+		if err := repo.IncrementTagPopularity(ctx, tag, 1); err != nil {
+			t.Fatalf("IncrementTagPopularity(%q): %v", tag, err)
+		}
+	}
+
+	counts, err := repo.GetPopularTags(ctx, 10)
+	if err != nil {
+		t.Fatalf("GetPopularTags: %v", err)
+	}
+	if len(counts) != 2 || counts[0].Tag != "rum" || counts[0].Count != 3 || counts[1].Tag != "parrots" || counts[1].Count != 2 {
+		t.Fatalf("expected rum=3 then parrots=2, got %+v", counts)
+	}
+
+	if err := repo.IncrementTagPopularity(ctx, "rum", -2); err != nil {
+		t.Fatalf("IncrementTagPopularity decrement: %v", err)
+	}
+	counts, err = repo.GetPopularTags(ctx, 10)
+	if err != nil {
+		t.Fatalf("GetPopularTags after decrement: %v", err)
+	}
+	if len(counts) != 2 || counts[0].Tag != "parrots" || counts[0].Count != 2 || counts[1].Tag != "rum" || counts[1].Count != 1 {
+		t.Fatalf("expected parrots=2 then rum=1 after decrementing rum, got %+v", counts)
+	}
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+)
+
+func TestCacheRepository_GobRoundTripsAUser(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewCacheRepositoryWithCodec(client, "", GobCodec)
+
+	user := &models.User{ID: 1, Username: "calico-jack"}
+	if err := repo.SetUser(context.Background(), user); err != nil {
+		t.Fatalf("SetUser: %v", err)
+	}
+
+	got, err := repo.GetUser(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if got == nil || got.Username != user.Username {
+		t.Fatalf("expected gob round-trip to preserve the user, got %+v", got)
+	}
+}
+
+func TestCacheRepository_RoundTripsAUserThroughEachCodec(t *testing.T) {
+	for _, codec := range []CacheCodec{JSONCodec, GobCodec} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			mr, err := miniredis.Run()
+			if err != nil {
+				t.Fatalf("start miniredis: %v", err)
+			}
+			defer mr.Close()
+
+			client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+			repo := NewCacheRepositoryWithCodec(client, "", codec)
+
+			user := &models.User{ID: 1, Username: "calico-jack", FirstName: "Calico", LastName: "Jack"}
+			if err := repo.SetUser(context.Background(), user); err != nil {
+				t.Fatalf("SetUser: %v", err)
+			}
+
+			got, err := repo.GetUser(context.Background(), 1)
+			if err != nil {
+				t.Fatalf("GetUser: %v", err)
+			}
+			if got == nil || !reflect.DeepEqual(*got, *user) {
+				t.Fatalf("expected %s round-trip to preserve the user, got %+v", codec.Name(), got)
+			}
+		})
+	}
+}
+
+func TestCacheRepository_DifferentCodecsDoNotShareKeys(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	jsonRepo := NewCacheRepositoryWithCodec(client, "", JSONCodec)
+	gobRepo := NewCacheRepositoryWithCodec(client, "", GobCodec)
+
+	if err := jsonRepo.SetUser(context.Background(), &models.User{ID: 1, Username: "written-as-json"}); err != nil {
+		t.Fatalf("SetUser: %v", err)
+	}
+
+	got, err := gobRepo.GetUser(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if got != nil {
+		t.Fatalf("expected a gob-configured reader to miss a value written by the JSON codec, got %+v", got)
+	}
+}
+
+func BenchmarkCacheRepository_SetUser_JSON(b *testing.B) {
+	benchmarkCacheRepositorySetUser(b, JSONCodec)
+}
+
+func BenchmarkCacheRepository_SetUser_Gob(b *testing.B) {
+	benchmarkCacheRepositorySetUser(b, GobCodec)
+}
+
+func benchmarkCacheRepositorySetUser(b *testing.B, codec CacheCodec) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		b.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := NewCacheRepositoryWithCodec(client, "", codec)
+	user := &models.User{ID: 1, Username: "calico-jack", FirstName: "Calico", LastName: "Jack"}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := repo.SetUser(context.Background(), user); err != nil {
+			b.Fatalf("SetUser: %v", err)
+		}
+		if _, err := repo.GetUser(context.Background(), 1); err != nil {
+			b.Fatalf("GetUser: %v", err)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ErrUnavailable wraps an error that indicates the underlying database
+// is unreachable (connection refused, timed out, etc.), as distinct
+// from a query failing against a database that is up. Callers can
+// errors.Is against it to decide whether a failure is transient.
+var ErrUnavailable = errors.New("repository: dependency unavailable")
+
+// ErrStatementTimeout wraps an error that indicates Postgres killed a
+// query for exceeding statement_timeout, as distinct from a
+// connection-level outage. Callers can errors.Is against it to map the
+// failure to codes.DeadlineExceeded instead of a generic error.
+var ErrStatementTimeout = errors.New("repository: statement timeout exceeded")
+
+// pgStatementTimeoutMessage is the fragment Postgres includes in the
+// error it raises when it cancels a query for running past
+// statement_timeout.
+const pgStatementTimeoutMessage = "canceling statement due to statement timeout"
+
+// wrapDBError tags err with ErrUnavailable when it looks like a
+// connection-level failure rather than an ordinary query error (e.g.
+// gorm.ErrRecordNotFound), so the service layer can distinguish "the
+// database is down" from "that row doesn't exist". This covers dropped
+// connections (net.Error), a poisoned pooled connection
+// (driver.ErrBadConn), and a query that timed out against the
+// context deadline rather than failing on its own merits. A query
+// canceled by Postgres's own statement_timeout is tagged
+// ErrStatementTimeout instead, since that's a slow query, not an
+// unavailable database.
+func wrapDBError(err error) error {
+	if err == nil || errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if strings.Contains(err.Error(), pgStatementTimeoutMessage) {
+		return errors.Join(ErrStatementTimeout, err)
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, context.DeadlineExceeded) {
+		return errors.Join(ErrUnavailable, err)
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return errors.Join(ErrUnavailable, err)
+	}
+	return err
+}
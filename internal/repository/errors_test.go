@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestWrapDBError_DetectsConnectionLevelFailures(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+	}{
+		{"bad conn", driver.ErrBadConn},
+		{"context deadline", context.DeadlineExceeded},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			wrapped := wrapDBError(tc.err)
+			if !errors.Is(wrapped, ErrUnavailable) {
+				t.Fatalf("expected %v to be tagged ErrUnavailable, got %v", tc.err, wrapped)
+			}
+			if !errors.Is(wrapped, tc.err) {
+				t.Fatalf("expected wrapped error to preserve %v, got %v", tc.err, wrapped)
+			}
+		})
+	}
+}
+
+func TestWrapDBError_DetectsStatementTimeout(t *testing.T) {
+	pgErr := errors.New(`pq: canceling statement due to statement timeout`)
+	wrapped := wrapDBError(pgErr)
+	if !errors.Is(wrapped, ErrStatementTimeout) {
+		t.Fatalf("expected %v to be tagged ErrStatementTimeout, got %v", pgErr, wrapped)
+	}
+	if errors.Is(wrapped, ErrUnavailable) {
+		t.Fatalf("expected a statement timeout not to also be tagged ErrUnavailable, got %v", wrapped)
+	}
+}
+
+func TestWrapDBError_PassesThroughOrdinaryErrors(t *testing.T) {
+	if wrapDBError(nil) != nil {
+		t.Fatal("expected nil to pass through unchanged")
+	}
+	if err := wrapDBError(gorm.ErrRecordNotFound); !errors.Is(err, gorm.ErrRecordNotFound) || errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected gorm.ErrRecordNotFound to pass through without ErrUnavailable, got %v", err)
+	}
+
+	genuine := errors.New("unique constraint violated")
+	if err := wrapDBError(genuine); errors.Is(err, ErrUnavailable) {
+		t.Fatalf("expected a genuine query error not to be tagged ErrUnavailable, got %v", err)
+	}
+}
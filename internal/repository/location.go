@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+)
+
+// GeoBackend selects the SQL formula PostgresLocationRepository.
+// FindUsersWithinRadius uses to evaluate a radius search.
+type GeoBackend string
+
+const (
+	// GeoBackendHaversine computes the great-circle distance in plain
+	// SQL trig functions. It works on any Postgres instance but can't
+	// use a spatial index, so it scans every row with the right label.
+	GeoBackendHaversine GeoBackend = "haversine"
+	// GeoBackendPostGIS uses ST_DWithin over a geography column, which
+	// can use a GiST index (see docs/postgis-migration.md) instead of
+	// scanning every row. It requires the PostGIS extension.
+	GeoBackendPostGIS GeoBackend = "postgis"
+)
+
+// earthRadiusMeters is the constant the haversine formula scales its
+// unit-sphere distance by to get meters.
+const earthRadiusMeters = 6371000.0
+
+// LocationRepository is the persistence contract for a user's saved
+// positions, keyed by (user_id, label).
+type LocationRepository interface {
+	// UpsertLocation creates or replaces a user's stored location for
+	// loc.Label.
+	UpsertLocation(ctx context.Context, loc *models.UserLocation) error
+	// BatchUpsertLocations creates or replaces many users' locations in a
+	// single transaction. Callers are expected to have already filtered
+	// out invalid entries; this is an all-or-nothing write for the
+	// entries it's given.
+	BatchUpsertLocations(ctx context.Context, locs []*models.UserLocation) error
+	// GetUserLocations returns every labeled location saved for userID.
+	GetUserLocations(ctx context.Context, userID uint) ([]models.UserLocation, error)
+	// DeleteAllForUser removes every labeled location saved for userID,
+	// e.g. as part of a GDPR erasure request.
+	DeleteAllForUser(ctx context.Context, userID uint) error
+	// FindUsersInBounds returns up to limit of models.CurrentLocationLabel
+	// locations whose (latitude, longitude) falls within the box
+	// [minLat, maxLat] x [minLon, maxLon], for a map viewport query. A
+	// box with minLon > maxLon is treated as crossing the antimeridian
+	// (e.g. minLon=170, maxLon=-170) and matched as two longitude
+	// ranges instead of one.
+	FindUsersInBounds(ctx context.Context, minLat, minLon, maxLat, maxLon float64, limit int) ([]models.UserLocation, error)
+	// FindUsersWithinRadius returns up to limit of
+	// models.CurrentLocationLabel locations within radiusKm of (lat,
+	// lon), ordered nearest first. The SQL formula used to evaluate the
+	// radius is selected by the repository's configured GeoBackend.
+	FindUsersWithinRadius(ctx context.Context, lat, lon, radiusKm float64, limit int) ([]models.UserLocation, error)
+}
+
+// PostgresLocationRepository implements LocationRepository on GORM.
+type PostgresLocationRepository struct {
+	db      *gorm.DB
+	backend GeoBackend
+}
+
+// NewPostgresLocationRepository constructs a PostgresLocationRepository
+// bound to an already-connected *gorm.DB. backend selects the formula
+// FindUsersWithinRadius uses; an empty value falls back to
+// GeoBackendHaversine.
+func NewPostgresLocationRepository(db *gorm.DB, backend GeoBackend) *PostgresLocationRepository {
+	if backend == "" {
+		backend = GeoBackendHaversine
+	}
+	return &PostgresLocationRepository{db: db, backend: backend}
+}
+
+// UpsertLocation writes loc via GORM when r.backend is GeoBackendHaversine,
+// and via raw SQL (see upsertLocationQuery) when it's GeoBackendPostGIS,
+// since only the raw form can also populate the geom column atomically
+// alongside the plain lat/lon columns.
+func (r *PostgresLocationRepository) UpsertLocation(ctx context.Context, loc *models.UserLocation) error {
+	if loc.Label == "" {
+		loc.Label = models.CurrentLocationLabel
+	}
+	loc.UpdatedAt = time.Now()
+
+	if r.backend == GeoBackendPostGIS {
+		query, args := upsertLocationQuery(loc)
+		return r.db.WithContext(ctx).Exec(query, args...).Error
+	}
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "label"}},
+		DoUpdates: clause.AssignmentColumns([]string{"latitude", "longitude", "country", "updated_at"}),
+	}).Create(loc).Error
+}
+
+// BatchUpsertLocations writes all of locs in a single transaction. With
+// GeoBackendHaversine that is one multi-row upsert for the whole batch;
+// with GeoBackendPostGIS it is one upsertLocationQuery statement per
+// entry, so a fleet-wide batch of pings costs at most one round trip per
+// user.
+func (r *PostgresLocationRepository) BatchUpsertLocations(ctx context.Context, locs []*models.UserLocation) error {
+	if len(locs) == 0 {
+		return nil
+	}
+	now := time.Now()
+	for _, loc := range locs {
+		if loc.Label == "" {
+			loc.Label = models.CurrentLocationLabel
+		}
+		loc.UpdatedAt = now
+	}
+
+	if r.backend == GeoBackendPostGIS {
+		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			for _, loc := range locs {
+				query, args := upsertLocationQuery(loc)
+				if err := tx.Exec(query, args...).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "label"}},
+			DoUpdates: clause.AssignmentColumns([]string{"latitude", "longitude", "country", "updated_at"}),
+		}).Create(locs).Error
+	})
+}
+
+// upsertLocationQuery builds the raw SQL and positional args that write
+// loc's plain columns and its geom column in the same statement,
+// computing geom as ST_SetSRID(ST_MakePoint(longitude, latitude), 4326)
+// so the two representations can never observably disagree the way a
+// separate follow-up UPDATE could if it failed or raced with another
+// write. It's a plain function (rather than a method), following
+// nearbySearchQuery, so its output can be asserted on directly in tests
+// without a live Postgres connection.
+func upsertLocationQuery(loc *models.UserLocation) (string, []interface{}) {
+	query := `INSERT INTO user_locations (user_id, label, latitude, longitude, country, updated_at, geom)
+		VALUES (?, ?, ?, ?, ?, ?, ST_SetSRID(ST_MakePoint(?, ?), 4326))
+		ON CONFLICT (user_id, label) DO UPDATE SET
+			latitude = EXCLUDED.latitude,
+			longitude = EXCLUDED.longitude,
+			country = EXCLUDED.country,
+			updated_at = EXCLUDED.updated_at,
+			geom = EXCLUDED.geom`
+	args := []interface{}{
+		loc.UserID, loc.Label, loc.Latitude, loc.Longitude, loc.Country, loc.UpdatedAt,
+		loc.Longitude, loc.Latitude,
+	}
+	return query, args
+}
+
+// GetUserLocations returns every labeled location saved for userID.
+func (r *PostgresLocationRepository) GetUserLocations(ctx context.Context, userID uint) ([]models.UserLocation, error) {
+	var locs []models.UserLocation
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&locs).Error
+	return locs, err
+}
+
+func (r *PostgresLocationRepository) DeleteAllForUser(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.UserLocation{}).Error
+}
+
+// FindUsersInBounds returns up to limit current locations within the box
+// [minLat, maxLat] x [minLon, maxLon]. When minLon > maxLon the box
+// crosses the antimeridian, so the longitude predicate is split into
+// "longitude >= minLon OR longitude <= maxLon" instead of a single
+// BETWEEN, which would otherwise match nothing.
+func (r *PostgresLocationRepository) FindUsersInBounds(ctx context.Context, minLat, minLon, maxLat, maxLon float64, limit int) ([]models.UserLocation, error) {
+	query := r.db.WithContext(ctx).
+		Where("label = ?", models.CurrentLocationLabel).
+		Where("latitude BETWEEN ? AND ?", minLat, maxLat)
+
+	if minLon <= maxLon {
+		query = query.Where("longitude BETWEEN ? AND ?", minLon, maxLon)
+	} else {
+		query = query.Where("longitude >= ? OR longitude <= ?", minLon, maxLon)
+	}
+
+	var locs []models.UserLocation
+	err := query.Limit(limit).Find(&locs).Error
+	return locs, err
+}
+
+// FindUsersWithinRadius returns up to limit current locations within
+// radiusKm of (lat, lon), ordered nearest first, using r.backend's
+// formula.
+func (r *PostgresLocationRepository) FindUsersWithinRadius(ctx context.Context, lat, lon, radiusKm float64, limit int) ([]models.UserLocation, error) {
+	query, args := nearbySearchQuery(r.backend, lat, lon, radiusKm, limit)
+	var locs []models.UserLocation
+	err := r.db.WithContext(ctx).Raw(query, args...).Scan(&locs).Error
+	return locs, err
+}
+
+// nearbySearchQuery builds the raw SQL and its positional args for a
+// radius search over user_locations, per backend. It's a plain function
+// (rather than a method) so its output can be asserted on directly in
+// tests without a live Postgres connection.
+func nearbySearchQuery(backend GeoBackend, lat, lon, radiusKm float64, limit int) (string, []interface{}) {
+	if backend == GeoBackendPostGIS {
+		return `SELECT * FROM user_locations
+			WHERE label = ?
+			AND ST_DWithin(
+				geom,
+				geography(ST_MakePoint(?, ?)),
+				?
+			)
+			ORDER BY geom <-> geography(ST_MakePoint(?, ?))
+			LIMIT ?`,
+			[]interface{}{models.CurrentLocationLabel, lon, lat, radiusKm * 1000, lon, lat, limit}
+	}
+
+	// Haversine, in meters, using the standard great-circle formula.
+	// ASIN isn't in every SQL dialect but is standard in Postgres.
+	const haversineExpr = `? * 2 * ASIN(SQRT(
+		POWER(SIN(RADIANS(latitude - ?) / 2), 2) +
+		COS(RADIANS(?)) * COS(RADIANS(latitude)) *
+		POWER(SIN(RADIANS(longitude - ?) / 2), 2)
+	))`
+	query := `SELECT * FROM user_locations
+		WHERE label = ?
+		AND (` + haversineExpr + `) <= ?
+		ORDER BY ` + haversineExpr + `
+		LIMIT ?`
+	radiusMeters := radiusKm * 1000
+	args := []interface{}{
+		models.CurrentLocationLabel,
+		earthRadiusMeters, lat, lat, lon, radiusMeters,
+		earthRadiusMeters, lat, lat, lon,
+		limit,
+	}
+	return query, args
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+// FindUsersInBounds is a straight GORM WHERE-clause builder with no
+// Postgres-specific SQL, so it's exercised here against sqlite the same
+// way migrations_test.go stands in for Postgres, rather than requiring a
+// live Postgres connection this sandbox doesn't have.
+func openBoundsTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	_, err = db.Exec(`CREATE TABLE user_locations (
+		user_id INTEGER,
+		label TEXT,
+		latitude REAL,
+		longitude REAL
+	)`)
+	if err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	return db
+}
+
+func insertLocation(t *testing.T, db *sql.DB, userID uint, label string, lat, lon float64) {
+	t.Helper()
+	if _, err := db.Exec(`INSERT INTO user_locations (user_id, label, latitude, longitude) VALUES (?, ?, ?, ?)`, userID, label, lat, lon); err != nil {
+		t.Fatalf("insert location: %v", err)
+	}
+}
+
+// findUserIDsInBounds mirrors PostgresLocationRepository.FindUsersInBounds's
+// query, run against db instead of a live *gorm.DB, so the antimeridian
+// predicate can be checked without a Postgres connection.
+func findUserIDsInBounds(t *testing.T, db *sql.DB, minLat, minLon, maxLat, maxLon float64) []uint {
+	t.Helper()
+	var query string
+	var args []interface{}
+	if minLon <= maxLon {
+		query = `SELECT user_id FROM user_locations WHERE label = ? AND latitude BETWEEN ? AND ? AND longitude BETWEEN ? AND ? ORDER BY user_id`
+		args = []interface{}{"current", minLat, maxLat, minLon, maxLon}
+	} else {
+		query = `SELECT user_id FROM user_locations WHERE label = ? AND latitude BETWEEN ? AND ? AND (longitude >= ? OR longitude <= ?) ORDER BY user_id`
+		args = []interface{}{"current", minLat, maxLat, minLon, maxLon}
+	}
+
+	rows, err := db.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		t.Fatalf("query: %v", err)
+	}
+	defer rows.Close()
+
+	var ids []uint
+	for rows.Next() {
+		var id uint
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("scan: %v", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestFindUsersInBounds_NormalBoxMatchesOnlyPointsInside(t *testing.T) {
+	db := openBoundsTestDB(t)
+	insertLocation(t, db, 1, "current", 10, 10)  // inside
+	insertLocation(t, db, 2, "current", 20, 20)  // inside, on the corner
+	insertLocation(t, db, 3, "current", 30, 30)  // outside
+	insertLocation(t, db, 4, "current", 15, -50) // wrong longitude
+	insertLocation(t, db, 5, "old", 15, 15)      // wrong label
+
+	ids := findUserIDsInBounds(t, db, 10, 10, 20, 20)
+	if want := []uint{1, 2}; !equalUintSlices(ids, want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+}
+
+func TestFindUsersInBounds_AntimeridianCrossingBoxSplitsThePredicate(t *testing.T) {
+	db := openBoundsTestDB(t)
+	insertLocation(t, db, 1, "current", 0, 175)  // inside the east half
+	insertLocation(t, db, 2, "current", 0, -175) // inside the west half
+	insertLocation(t, db, 3, "current", 0, 0)    // outside, middle of the map
+	insertLocation(t, db, 4, "current", 90, 175) // wrong latitude
+
+	// minLon=170 > maxLon=-170 crosses the antimeridian.
+	ids := findUserIDsInBounds(t, db, -10, 170, 10, -170)
+	if want := []uint{1, 2}; !equalUintSlices(ids, want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+}
+
+func equalUintSlices(a, b []uint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+)
+
+// nearbySearchQuery's SQL isn't exercised against a live Postgres
+// connection this sandbox doesn't have (Postgres-specific functions
+// like ST_DWithin and ASIN/RADIANS aren't available in the sqlite
+// stand-in used elsewhere in this package, e.g. location_bounds_test.go).
+// These tests instead assert on the generated SQL and args directly,
+// the same way a sqlmock-based test would assert on the query
+// sqlmock.ExpectQuery was told to expect.
+
+func TestNearbySearchQuery_HaversineIsTheDefaultBackend(t *testing.T) {
+	query, args := nearbySearchQuery(GeoBackendHaversine, 10, 20, 5, 50)
+
+	if !strings.Contains(query, "ASIN") || !strings.Contains(query, "RADIANS") {
+		t.Fatalf("expected the haversine formula in the query, got %q", query)
+	}
+	if strings.Contains(query, "ST_DWithin") {
+		t.Fatalf("expected no PostGIS functions in the haversine query, got %q", query)
+	}
+	if len(args) == 0 || args[0] != models.CurrentLocationLabel {
+		t.Fatalf("expected the label filter as the first arg, got %v", args)
+	}
+}
+
+func TestNearbySearchQuery_PostGISUsesSTDWithinAndAGeographyCast(t *testing.T) {
+	query, args := nearbySearchQuery(GeoBackendPostGIS, 10, 20, 5, 50)
+
+	if !strings.Contains(query, "ST_DWithin") {
+		t.Fatalf("expected ST_DWithin in the postgis query, got %q", query)
+	}
+	if !strings.Contains(query, "geography(") {
+		t.Fatalf("expected a geography() cast in the postgis query, got %q", query)
+	}
+	// radiusKm is converted to meters, since ST_DWithin over a
+	// geography column takes meters.
+	found := false
+	for _, arg := range args {
+		if f, ok := arg.(float64); ok && f == 5000 {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected the radius to be converted from km to meters (5000), got args %v", args)
+	}
+}
+
+func TestUpsertLocationQuery_PostGISWritesAreSetAlongsideLatLon(t *testing.T) {
+	loc := &models.UserLocation{UserID: 7, Label: models.CurrentLocationLabel, Latitude: 10, Longitude: 20, Country: "RU"}
+	query, args := upsertLocationQuery(loc)
+
+	if !strings.Contains(query, "ST_SetSRID(ST_MakePoint(") {
+		t.Fatalf("expected an ST_SetSRID(ST_MakePoint(...)) expression in the query, got %q", query)
+	}
+	if !strings.Contains(query, "geom") {
+		t.Fatalf("expected the geom column in the query, got %q", query)
+	}
+	if !strings.Contains(query, "ON CONFLICT (user_id, label) DO UPDATE") {
+		t.Fatalf("expected an upsert on (user_id, label), got %q", query)
+	}
+
+	// The point args (longitude, then latitude, matching ST_MakePoint's
+	// argument order) should be the trailing two args, after the plain
+	// columns.
+	if got := args[len(args)-2]; got != loc.Longitude {
+		t.Fatalf("expected longitude as the second-to-last arg, got %v", got)
+	}
+	if got := args[len(args)-1]; got != loc.Latitude {
+		t.Fatalf("expected latitude as the last arg, got %v", got)
+	}
+}
+
+func TestNearbySearchQuery_LimitIsTheLastArgForBothBackends(t *testing.T) {
+	for _, backend := range []GeoBackend{GeoBackendHaversine, GeoBackendPostGIS} {
+		_, args := nearbySearchQuery(backend, 10, 20, 5, 50)
+		if got := args[len(args)-1]; got != 50 {
+			t.Fatalf("backend %v: expected limit 50 as the last arg, got %v", backend, got)
+		}
+	}
+}
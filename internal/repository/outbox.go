@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+)
+
+// OutboxRepository is the persistence contract for the transactional
+// outbox described on models.OutboxEvent. service.OutboxRelay is the
+// only consumer.
+type OutboxRepository interface {
+	// ListUnpublished returns up to limit outbox rows that haven't been
+	// published yet, oldest first.
+	ListUnpublished(ctx context.Context, limit int) ([]models.OutboxEvent, error)
+	// MarkPublished stamps an outbox row's PublishedAt so it's never
+	// relayed again.
+	MarkPublished(ctx context.Context, id uint) error
+}
+
+// PostgresOutboxRepository implements OutboxRepository on top of GORM.
+// It shares its *gorm.DB with PostgresUserRepository so a domain change
+// and the outbox row describing it can be written in one transaction.
+type PostgresOutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresOutboxRepository constructs a PostgresOutboxRepository
+// bound to an already-connected *gorm.DB.
+func NewPostgresOutboxRepository(db *gorm.DB) *PostgresOutboxRepository {
+	return &PostgresOutboxRepository{db: db}
+}
+
+func (r *PostgresOutboxRepository) ListUnpublished(ctx context.Context, limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := r.db.WithContext(ctx).Where("published_at IS NULL").Order("id").Limit(limit).Find(&events).Error
+	return events, wrapDBError(err)
+}
+
+func (r *PostgresOutboxRepository) MarkPublished(ctx context.Context, id uint) error {
+	now := time.Now()
+	return wrapDBError(r.db.WithContext(ctx).Model(&models.OutboxEvent{}).Where("id = ?", id).Update("published_at", now).Error)
+}
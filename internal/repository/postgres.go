@@ -0,0 +1,383 @@
+// Package repository contains the persistence layer for
+// JollyRogerUserService: a Postgres-backed store of record and a Redis
+// cache in front of it.
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/database"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+)
+
+// outboxTopicUserCreated is the topic a newly created user's outbox
+// event is published under. See PostgresUserRepository.CreateUser.
+const outboxTopicUserCreated = "user.created"
+
+// ErrTelegramIDTaken is returned by ChangeTelegramID when newTelegramID
+// already belongs to a different user.
+var ErrTelegramIDTaken = errors.New("repository: telegram id already in use")
+
+// UserRepository is the persistence contract for user accounts, backed
+// by Postgres.
+type UserRepository interface {
+	CreateUser(ctx context.Context, user *models.User) error
+	// CreateUserWithOnboarding creates user and, in the same
+	// transaction, seeds any tags/location supplied for onboarding, so
+	// a failure partway through (e.g. a duplicate preference tag)
+	// leaves no partially-created user behind either. tags may be empty
+	// and location may be nil to skip that part of onboarding.
+	CreateUserWithOnboarding(ctx context.Context, user *models.User, tags []string, location *models.UserLocation) error
+	// GetUserByID returns a user regardless of ban status, for admin and
+	// internal use (e.g. reconciliation).
+	GetUserByID(ctx context.Context, id uint) (*models.User, error)
+	// GetActiveUserByID returns a user, excluding banned accounts. It
+	// returns gorm.ErrRecordNotFound if the user is banned or missing.
+	GetActiveUserByID(ctx context.Context, id uint) (*models.User, error)
+
+	// GetUsersByTelegramIDs resolves many Telegram user ids in a single
+	// query, for callers (e.g. the bot backend on a group join) that
+	// need to resolve a batch at once rather than one round trip per
+	// id. A telegram_id with no matching account is simply absent from
+	// the returned map.
+	GetUsersByTelegramIDs(ctx context.Context, telegramIDs []int64) (map[int64]*models.User, error)
+
+	// ListUsers returns up to limit users ordered by id, starting after
+	// afterID. Callers page through the full table by passing the last
+	// seen id back in as afterID. If excludeBots is true, accounts with
+	// IsBot set are omitted from the page.
+	ListUsers(ctx context.Context, afterID uint, limit int, excludeBots bool) ([]models.User, error)
+
+	// SetBanned sets a user's ban flag.
+	SetBanned(ctx context.Context, id uint, banned bool) error
+
+	// UpdateLastActive stamps a user's LastActiveAt with the current
+	// time, so background jobs (e.g. the cache sweeper) can tell which
+	// accounts are still in use.
+	UpdateLastActive(ctx context.Context, id uint) error
+
+	// DeleteUser permanently removes a user's account row. Used for
+	// GDPR erasure requests; it is irreversible.
+	DeleteUser(ctx context.Context, id uint) error
+
+	// UpdateUserRating appends a UserRatingEvent for userID, attributed to
+	// raterID (0 if not attributed to a specific user), and folds score
+	// into its running RatingSum/RatingCount/Rating in the same
+	// transaction so the event log and the aggregate never diverge.
+	UpdateUserRating(ctx context.Context, userID uint, score float64, raterID uint) error
+	// RecomputeUserRating rebuilds a user's RatingSum/RatingCount/Rating
+	// from its UserRatingEvent log, discarding whatever the denormalized
+	// aggregate currently holds. Use it to repair drift from a bug or a
+	// write that updated one but not the other.
+	RecomputeUserRating(ctx context.Context, userID uint) error
+	// GetRatingHistory returns up to limit of userID's rating events,
+	// newest first, for dispute resolution and "recent feedback" UIs.
+	GetRatingHistory(ctx context.Context, userID uint, limit int) ([]models.UserRatingEvent, error)
+	// DeleteRatingHistory removes every rating event for userID, e.g. as
+	// part of a GDPR erasure request. It does not reset the user row's
+	// denormalized Rating/RatingSum/RatingCount; callers erasing a user
+	// entirely are expected to delete the user row too.
+	DeleteRatingHistory(ctx context.Context, userID uint) error
+
+	// UserExists reports whether a user with id exists, without loading
+	// the row, for a caller that only needs a yes/no answer.
+	UserExists(ctx context.Context, id uint) (bool, error)
+	// UserExistsByTelegramID is UserExists keyed by telegram_id instead
+	// of id.
+	UserExistsByTelegramID(ctx context.Context, telegramID int64) (bool, error)
+
+	// GetUserFeatures returns a user's per-feature toggles.
+	GetUserFeatures(ctx context.Context, id uint) (models.FeatureFlags, error)
+	// SetUserFeature sets a single feature toggle for a user, leaving
+	// every other key in its Features column untouched.
+	SetUserFeature(ctx context.Context, id uint, key string, value bool) error
+
+	// ChangeTelegramID moves userID onto newTelegramID, e.g. after a
+	// user's Telegram account id changes upstream and their existing
+	// account needs to follow it. It returns ErrTelegramIDTaken if
+	// newTelegramID already belongs to a different user.
+	ChangeTelegramID(ctx context.Context, userID uint, newTelegramID int64) error
+
+	// UpdateUsername sets a user's display username.
+	UpdateUsername(ctx context.Context, userID uint, username string) error
+
+	// CountUsers returns the total number of user rows, for the
+	// service-wide user count summary. It's a full table count, so
+	// callers should keep it behind a cache (see
+	// CacheRepository.SetServiceUserCount) rather than calling it per
+	// request.
+	CountUsers(ctx context.Context) (int64, error)
+}
+
+// PostgresUserRepository implements UserRepository on top of GORM.
+type PostgresUserRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresUserRepository constructs a PostgresUserRepository bound to
+// an already-connected *gorm.DB.
+func NewPostgresUserRepository(db *gorm.DB) *PostgresUserRepository {
+	return &PostgresUserRepository{db: db}
+}
+
+// CreateUser inserts user and, in the same transaction, an
+// outboxTopicUserCreated outbox event carrying the created row. Writing
+// both together means an OutboxRelay always eventually publishes the
+// event for a user that exists, and never publishes one for a user that
+// doesn't - there's no window between "committed" and "published" where
+// a crash could lose the event or leave it orphaned.
+func (r *PostgresUserRepository) CreateUser(ctx context.Context, user *models.User) error {
+	return wrapDBError(r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		payload, err := json.Marshal(user)
+		if err != nil {
+			return err
+		}
+		return tx.Create(&models.OutboxEvent{Topic: outboxTopicUserCreated, Payload: string(payload), CreatedAt: time.Now()}).Error
+	}))
+}
+
+// CreateUserWithOnboarding is CreateUser plus, in the same transaction,
+// a row per tag and (if given) an initial location, so an onboarding
+// call that also seeds preferences/location either fully succeeds or
+// leaves no trace at all - including of the user row itself. A
+// duplicate tag in tags fails the whole transaction rather than being
+// silently deduplicated, since AddPreference's usual DO NOTHING
+// behavior exists for an idempotent retry of a single tag, not for a
+// caller submitting the same tag twice in one onboarding request.
+func (r *PostgresUserRepository) CreateUserWithOnboarding(ctx context.Context, user *models.User, tags []string, location *models.UserLocation) error {
+	return wrapDBError(r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(user).Error; err != nil {
+			return err
+		}
+		payload, err := json.Marshal(user)
+		if err != nil {
+			return err
+		}
+		if err := tx.Create(&models.OutboxEvent{Topic: outboxTopicUserCreated, Payload: string(payload), CreatedAt: time.Now()}).Error; err != nil {
+			return err
+		}
+		for _, tag := range tags {
+			if err := tx.Create(&models.Preference{UserID: user.ID, Tag: tag}).Error; err != nil {
+				return err
+			}
+		}
+		if location != nil {
+			location.UserID = user.ID
+			if location.Label == "" {
+				location.Label = models.CurrentLocationLabel
+			}
+			location.UpdatedAt = time.Now()
+			if err := tx.Create(location).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+}
+
+func (r *PostgresUserRepository) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).First(&user, id).Error; err != nil {
+		return nil, wrapDBError(err)
+	}
+	return &user, nil
+}
+
+func (r *PostgresUserRepository) GetActiveUserByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Where("banned = ?", false).First(&user, id).Error; err != nil {
+		return nil, wrapDBError(err)
+	}
+	return &user, nil
+}
+
+func (r *PostgresUserRepository) GetUsersByTelegramIDs(ctx context.Context, telegramIDs []int64) (map[int64]*models.User, error) {
+	if len(telegramIDs) == 0 {
+		return map[int64]*models.User{}, nil
+	}
+	var users []models.User
+	if err := r.db.WithContext(ctx).Where("telegram_id IN ?", telegramIDs).Find(&users).Error; err != nil {
+		return nil, wrapDBError(err)
+	}
+	byTelegramID := make(map[int64]*models.User, len(users))
+	for i := range users {
+		byTelegramID[users[i].TelegramID] = &users[i]
+	}
+	return byTelegramID, nil
+}
+
+func (r *PostgresUserRepository) SetBanned(ctx context.Context, id uint, banned bool) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("banned", banned).Error
+}
+
+// UserExists reports whether a user with id exists, without loading the
+// row, for a caller that only needs a yes/no answer.
+func (r *PostgresUserRepository) UserExists(ctx context.Context, id uint) (bool, error) {
+	var exists bool
+	err := r.db.WithContext(ctx).Model(&models.User{}).
+		Select("count(*) > 0").
+		Where("id = ?", id).
+		Find(&exists).Error
+	return exists, wrapDBError(err)
+}
+
+// UserExistsByTelegramID is UserExists keyed by telegram_id instead of id.
+func (r *PostgresUserRepository) UserExistsByTelegramID(ctx context.Context, telegramID int64) (bool, error) {
+	var exists bool
+	err := r.db.WithContext(ctx).Model(&models.User{}).
+		Select("count(*) > 0").
+		Where("telegram_id = ?", telegramID).
+		Find(&exists).Error
+	return exists, wrapDBError(err)
+}
+
+func (r *PostgresUserRepository) UpdateLastActive(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).Update("last_active_at", time.Now()).Error
+}
+
+func (r *PostgresUserRepository) GetUserFeatures(ctx context.Context, id uint) (models.FeatureFlags, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Select("features").First(&user, id).Error; err != nil {
+		return nil, wrapDBError(err)
+	}
+	if user.Features == nil {
+		return models.FeatureFlags{}, nil
+	}
+	return user.Features, nil
+}
+
+// SetUserFeature merges key into the Features jsonb column with a
+// jsonb_set expression rather than a read-modify-write, so two
+// concurrent SetUserFeature calls for different keys on the same user
+// can't clobber each other.
+func (r *PostgresUserRepository) SetUserFeature(ctx context.Context, id uint, key string, value bool) error {
+	path := fmt.Sprintf("{%s}", key)
+	return wrapDBError(r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", id).
+		Update("features", gorm.Expr("jsonb_set(coalesce(features, '{}'::jsonb), ?, to_jsonb(?::boolean), true)", path, value)).Error)
+}
+
+// ChangeTelegramID moves userID onto newTelegramID inside a transaction,
+// locking any existing row for newTelegramID for the duration so a
+// concurrent ChangeTelegramID/CreateUser can't slip in and claim it
+// between the check and the update.
+func (r *PostgresUserRepository) ChangeTelegramID(ctx context.Context, userID uint, newTelegramID int64) error {
+	return wrapDBError(r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var holders []uint
+		if err := tx.Model(&models.User{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("telegram_id = ? AND id <> ?", newTelegramID, userID).
+			Pluck("id", &holders).Error; err != nil {
+			return err
+		}
+		if len(holders) > 0 {
+			return ErrTelegramIDTaken
+		}
+		return tx.Model(&models.User{}).Where("id = ?", userID).Update("telegram_id", newTelegramID).Error
+	}))
+}
+
+// UpdateUsername sets userID's username. Uniqueness isn't enforced on
+// this column (unlike telegram_id), so no locking or collision check is
+// needed here.
+func (r *PostgresUserRepository) UpdateUsername(ctx context.Context, userID uint, username string) error {
+	return wrapDBError(r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Update("username", username).Error)
+}
+
+// CountUsers returns the total number of rows in the users table.
+func (r *PostgresUserRepository) CountUsers(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&models.User{}).Count(&count).Error
+	return count, wrapDBError(err)
+}
+
+func (r *PostgresUserRepository) DeleteUser(ctx context.Context, id uint) error {
+	return r.db.WithContext(ctx).Delete(&models.User{}, id).Error
+}
+
+// UpdateUserRating records a rating event and bumps the user's
+// denormalized aggregate to match. It holds the same per-user advisory
+// lock as RecomputeUserRating, so the two can't interleave: without it,
+// a recompute reading the event log mid-way through this call could
+// overwrite the aggregate this call is about to bump with a stale
+// snapshot.
+func (r *PostgresUserRepository) UpdateUserRating(ctx context.Context, userID uint, score float64, raterID uint) error {
+	return database.WithUserLock(ctx, r.db, userID, func(tx *gorm.DB) error {
+		if err := tx.Create(&models.UserRatingEvent{UserID: userID, RaterID: raterID, Score: score, CreatedAt: time.Now()}).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"rating_sum":   gorm.Expr("rating_sum + ?", score),
+			"rating_count": gorm.Expr("rating_count + 1"),
+			"rating":       gorm.Expr("(rating_sum + ?) / (rating_count + 1)", score),
+		}).Error
+	})
+}
+
+// RecomputeUserRating rebuilds a user's denormalized rating aggregate
+// from the event log. It holds the same per-user advisory lock as
+// UpdateUserRating, and does its reads and writes on the locked
+// transaction, so a recompute racing a concurrent UpdateUserRating (or
+// another recompute for the same user) can't interleave and leave the
+// aggregate inconsistent with the log it was derived from; recomputes
+// for different users still run concurrently.
+func (r *PostgresUserRepository) RecomputeUserRating(ctx context.Context, userID uint) error {
+	return database.WithUserLock(ctx, r.db, userID, func(tx *gorm.DB) error {
+		var agg struct {
+			Sum   float64
+			Count int64
+		}
+		if err := tx.Model(&models.UserRatingEvent{}).
+			Where("user_id = ?", userID).
+			Select("COALESCE(SUM(score), 0) AS sum, COUNT(*) AS count").
+			Scan(&agg).Error; err != nil {
+			return err
+		}
+		var rating float64
+		if agg.Count > 0 {
+			rating = agg.Sum / float64(agg.Count)
+		}
+		return tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"rating_sum":   agg.Sum,
+			"rating_count": agg.Count,
+			"rating":       rating,
+		}).Error
+	})
+}
+
+func (r *PostgresUserRepository) GetRatingHistory(ctx context.Context, userID uint, limit int) ([]models.UserRatingEvent, error) {
+	var events []models.UserRatingEvent
+	err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&events).Error
+	return events, err
+}
+
+func (r *PostgresUserRepository) DeleteRatingHistory(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.UserRatingEvent{}).Error
+}
+
+func (r *PostgresUserRepository) ListUsers(ctx context.Context, afterID uint, limit int, excludeBots bool) ([]models.User, error) {
+	var users []models.User
+	query := r.db.WithContext(ctx).
+		Where("id > ?", afterID)
+	if excludeBots {
+		query = query.Where("is_bot = ?", false)
+	}
+	err := query.
+		Order("id ASC").
+		Limit(limit).
+		Find(&users).Error
+	return users, err
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+)
+
+// ErrPreferenceLimitExceeded is returned by AddPreferenceWithLimit when a
+// user is already at their maximum number of preferences.
+var ErrPreferenceLimitExceeded = errors.New("repository: preference limit exceeded")
+
+// PreferenceRepository is the persistence contract for user preference
+// tags, backed by Postgres.
+type PreferenceRepository interface {
+	// ListPreferences returns userID's preference tags in a stable order
+	// (insertion order), so repeated reads and cache comparisons don't
+	// see spurious reordering.
+	ListPreferences(ctx context.Context, userID uint) ([]models.Preference, error)
+	AddPreference(ctx context.Context, userID uint, tag string) error
+	// AddPreferenceWithLimit inserts a preference tag for userID unless
+	// the user already has maxPreferences or more other tags, in which
+	// case it returns ErrPreferenceLimitExceeded; re-adding a tag the user
+	// already has is a no-op. The count check and insert run
+	// in a single transaction so concurrent adds can't both slip past
+	// the limit.
+	AddPreferenceWithLimit(ctx context.Context, userID uint, tag string, maxPreferences int) error
+	// DeleteAllForUser removes every preference row for a user, e.g. as
+	// part of a GDPR erasure request.
+	DeleteAllForUser(ctx context.Context, userID uint) error
+	// FindUsersByTag returns the users who have opted into tag, ordered
+	// by rating descending, for a matching service doing a reverse
+	// lookup ("who likes X"). limit is capped at maxFindUsersByTagLimit.
+	FindUsersByTag(ctx context.Context, tag string, limit, offset int) ([]models.User, error)
+	// ListUserIDsForTag returns every user id with tag set, uncapped, so
+	// a caller can invalidate each affected user's preferences cache
+	// around a bulk change like DeletePreferencesByTag.
+	ListUserIDsForTag(ctx context.Context, tag string) ([]uint, error)
+	// DeletePreferencesByTag removes every preference row for tag, e.g.
+	// when a tag is retired upstream, and returns the number of rows
+	// deleted.
+	DeletePreferencesByTag(ctx context.Context, tag string) (int64, error)
+}
+
+// maxFindUsersByTagLimit bounds how many users FindUsersByTag returns
+// in one call, so a caller can't force an unbounded scan/sort.
+const maxFindUsersByTagLimit = 200
+
+// PostgresPreferenceRepository implements PreferenceRepository on GORM.
+type PostgresPreferenceRepository struct {
+	db *gorm.DB
+}
+
+// NewPostgresPreferenceRepository constructs a PostgresPreferenceRepository
+// bound to an already-connected *gorm.DB.
+func NewPostgresPreferenceRepository(db *gorm.DB) *PostgresPreferenceRepository {
+	return &PostgresPreferenceRepository{db: db}
+}
+
+// ListPreferences returns userID's preference tags ordered by id, i.e.
+// the order they were added in. Preference has no created_at column, so
+// id (an auto-incrementing surrogate key) is the closest stand-in for
+// insertion order. This ordering is preserved through the cache
+// (SetPreferenceTags/GetPreferenceTags round-trip a plain []string),
+// so callers see a stable order whether a read is served from cache or
+// Postgres.
+func (r *PostgresPreferenceRepository) ListPreferences(ctx context.Context, userID uint) ([]models.Preference, error) {
+	var prefs []models.Preference
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("id ASC").Find(&prefs).Error
+	return prefs, err
+}
+
+// AddPreference inserts a preference tag for userID. Re-adding a tag the
+// user already has is a no-op rather than a duplicate-key error, so
+// callers don't need to special-case an idempotent retry.
+func (r *PostgresPreferenceRepository) AddPreference(ctx context.Context, userID uint, tag string) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Preference{UserID: userID, Tag: tag}).Error
+}
+
+func (r *PostgresPreferenceRepository) AddPreferenceWithLimit(ctx context.Context, userID uint, tag string, maxPreferences int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var otherIDs []uint
+		if err := tx.Model(&models.Preference{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ? AND tag <> ?", userID, tag).
+			Pluck("id", &otherIDs).Error; err != nil {
+			return err
+		}
+		if len(otherIDs) >= maxPreferences {
+			return ErrPreferenceLimitExceeded
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.Preference{UserID: userID, Tag: tag}).Error
+	})
+}
+
+func (r *PostgresPreferenceRepository) DeleteAllForUser(ctx context.Context, userID uint) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.Preference{}).Error
+}
+
+func (r *PostgresPreferenceRepository) ListUserIDsForTag(ctx context.Context, tag string) ([]uint, error) {
+	var ids []uint
+	err := r.db.WithContext(ctx).Model(&models.Preference{}).Where("tag = ?", tag).Pluck("user_id", &ids).Error
+	return ids, err
+}
+
+// DeletePreferencesByTag deletes every preferences row for tag in one
+// statement and reports how many rows it removed, via RowsAffected
+// rather than a separate count query.
+func (r *PostgresPreferenceRepository) DeletePreferencesByTag(ctx context.Context, tag string) (int64, error) {
+	result := r.db.WithContext(ctx).Where("tag = ?", tag).Delete(&models.Preference{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *PostgresPreferenceRepository) FindUsersByTag(ctx context.Context, tag string, limit, offset int) ([]models.User, error) {
+	if limit <= 0 || limit > maxFindUsersByTagLimit {
+		limit = maxFindUsersByTagLimit
+	}
+	var users []models.User
+	err := r.db.WithContext(ctx).
+		Joins("JOIN preferences ON preferences.user_id = users.id").
+		Where("preferences.tag = ?", tag).
+		Order("users.rating DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&users).Error
+	return users, err
+}
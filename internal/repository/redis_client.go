@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisClientOptions configures NewRedisClient. It mirrors
+// config.RedisConfig's connection-level fields; repository intentionally
+// doesn't import the config package, so callers translate their own
+// config struct into this one.
+type RedisClientOptions struct {
+	Addr     string
+	Password string
+	DB       int
+
+	// MaxRetries, MinRetryBackoff and MaxRetryBackoff configure
+	// go-redis's own per-command retry behavior, so a client that loses
+	// its connection (e.g. Redis restarting) recovers transparently on
+	// the next command instead of needing to be recreated.
+	MaxRetries      int
+	MinRetryBackoff time.Duration
+	MaxRetryBackoff time.Duration
+
+	// DialTimeout, ReadTimeout and WriteTimeout bound how long a single
+	// connection attempt or command may take.
+	DialTimeout  time.Duration
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+}
+
+// NewRedisClient constructs a *redis.Client with runtime reconnection
+// behavior enabled: a lost connection (e.g. Redis restarting) is
+// retried transparently by go-redis on the next command, rather than
+// requiring the caller to detect the failure and build a new client.
+func NewRedisClient(opts RedisClientOptions) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:            opts.Addr,
+		Password:        opts.Password,
+		DB:              opts.DB,
+		MaxRetries:      opts.MaxRetries,
+		MinRetryBackoff: opts.MinRetryBackoff,
+		MaxRetryBackoff: opts.MaxRetryBackoff,
+		DialTimeout:     opts.DialTimeout,
+		ReadTimeout:     opts.ReadTimeout,
+		WriteTimeout:    opts.WriteTimeout,
+	})
+}
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+)
+
+func TestNewRedisClient_RecoversAfterARedisRestartWithoutRecreatingTheClient(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := NewRedisClient(RedisClientOptions{
+		Addr:            mr.Addr(),
+		MaxRetries:      3,
+		MinRetryBackoff: time.Millisecond,
+		MaxRetryBackoff: 20 * time.Millisecond,
+		DialTimeout:     time.Second,
+		ReadTimeout:     time.Second,
+		WriteTimeout:    time.Second,
+	})
+	repo := NewCacheRepository(client, "")
+
+	user := &models.User{ID: 1, Username: "calico-jack"}
+	if err := repo.SetUser(context.Background(), user); err != nil {
+		t.Fatalf("SetUser before restart: %v", err)
+	}
+
+	mr.Close()
+	if err := mr.Restart(); err != nil {
+		t.Fatalf("restart miniredis: %v", err)
+	}
+
+	// The restarted instance is empty, but the same *redis.Client
+	// should transparently reconnect and succeed on the next command
+	// rather than needing to be recreated.
+	if err := repo.SetUser(context.Background(), user); err != nil {
+		t.Fatalf("SetUser after restart: %v", err)
+	}
+
+	got, err := repo.GetUser(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetUser after restart: %v", err)
+	}
+	if got == nil || got.Username != "calico-jack" {
+		t.Fatalf("expected the user written after restart, got %+v", got)
+	}
+}
@@ -0,0 +1,234 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/resilience"
+)
+
+// ResilientUserRepository wraps a UserRepository with cross-cutting
+// fault-tolerance behavior: every operation runs under a
+// MaxOperationTimeout-capped context with slow-query detection, and
+// CreateUser/CreateUserWithOnboarding are retried on failure. It has no
+// circuit breaker.
+type ResilientUserRepository struct {
+	inner UserRepository
+	cfg   resilience.Config
+	log   *zap.Logger
+}
+
+// NewResilientUserRepository wraps inner with the resilience behaviors
+// configured by cfg.
+func NewResilientUserRepository(inner UserRepository, cfg resilience.Config, log *zap.Logger) *ResilientUserRepository {
+	return &ResilientUserRepository{inner: inner, cfg: cfg, log: log}
+}
+
+// boundedContext derives a context from ctx capped at the configured
+// MaxOperationTimeout. It never replaces ctx outright, so a caller's own
+// (shorter) deadline or cancellation is still honored; it only guards
+// against a caller with no deadline letting a query run unbounded.
+func (r *ResilientUserRepository) boundedContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	max := r.cfg.MaxOperationTimeout
+	if max <= 0 {
+		max = resilience.DefaultConfig().MaxOperationTimeout
+	}
+	return context.WithTimeout(ctx, max)
+}
+
+// RecordDBOperation runs fn under a context bounded by MaxOperationTimeout,
+// timing it under the given operation name. If fn takes longer than the
+// configured slow-query threshold, it is logged as a warning and counted
+// in jollyroger_db_slow_operations_total.
+func (r *ResilientUserRepository) RecordDBOperation(ctx context.Context, operation string, fn func(ctx context.Context) error) error {
+	ctx, cancel := r.boundedContext(ctx)
+	defer cancel()
+
+	start := time.Now()
+	err := fn(ctx)
+	elapsed := time.Since(start)
+	metrics.DBOperationDuration.WithLabelValues(operation).Observe(elapsed.Seconds())
+
+	threshold := r.cfg.SlowQueryThreshold
+	if threshold <= 0 {
+		threshold = resilience.DefaultConfig().SlowQueryThreshold
+	}
+	if elapsed > threshold {
+		metrics.DBSlowOperationsTotal.WithLabelValues(operation).Inc()
+		r.log.Warn("slow db operation",
+			zap.String("operation", operation),
+			zap.Duration("elapsed", elapsed),
+			zap.Duration("threshold", threshold),
+		)
+	}
+	return err
+}
+
+// createUserMaxRetries bounds how many extra attempts CreateUser and
+// CreateUserWithOnboarding make after a failed attempt before giving up.
+// Any error is retried, not only repository.ErrUnavailable.
+const createUserMaxRetries = 2
+
+func (r *ResilientUserRepository) CreateUser(ctx context.Context, user *models.User) error {
+	return r.RecordDBOperation(ctx, "CreateUser", func(ctx context.Context) error {
+		return resilience.WithRetry(ctx, "CreateUser", createUserMaxRetries, 0, func() error {
+			return r.inner.CreateUser(ctx, user)
+		})
+	})
+}
+
+func (r *ResilientUserRepository) CreateUserWithOnboarding(ctx context.Context, user *models.User, tags []string, location *models.UserLocation) error {
+	return r.RecordDBOperation(ctx, "CreateUserWithOnboarding", func(ctx context.Context) error {
+		return resilience.WithRetry(ctx, "CreateUserWithOnboarding", createUserMaxRetries, 0, func() error {
+			return r.inner.CreateUserWithOnboarding(ctx, user, tags, location)
+		})
+	})
+}
+
+func (r *ResilientUserRepository) GetUserByID(ctx context.Context, id uint) (*models.User, error) {
+	var user *models.User
+	err := r.RecordDBOperation(ctx, "GetUserByID", func(ctx context.Context) error {
+		var err error
+		user, err = r.inner.GetUserByID(ctx, id)
+		return err
+	})
+	return user, err
+}
+
+func (r *ResilientUserRepository) GetActiveUserByID(ctx context.Context, id uint) (*models.User, error) {
+	var user *models.User
+	err := r.RecordDBOperation(ctx, "GetActiveUserByID", func(ctx context.Context) error {
+		var err error
+		user, err = r.inner.GetActiveUserByID(ctx, id)
+		return err
+	})
+	return user, err
+}
+
+func (r *ResilientUserRepository) GetUsersByTelegramIDs(ctx context.Context, telegramIDs []int64) (map[int64]*models.User, error) {
+	var users map[int64]*models.User
+	err := r.RecordDBOperation(ctx, "GetUsersByTelegramIDs", func(ctx context.Context) error {
+		var err error
+		users, err = r.inner.GetUsersByTelegramIDs(ctx, telegramIDs)
+		return err
+	})
+	return users, err
+}
+
+func (r *ResilientUserRepository) UserExists(ctx context.Context, id uint) (bool, error) {
+	var exists bool
+	err := r.RecordDBOperation(ctx, "UserExists", func(ctx context.Context) error {
+		var err error
+		exists, err = r.inner.UserExists(ctx, id)
+		return err
+	})
+	return exists, err
+}
+
+func (r *ResilientUserRepository) UserExistsByTelegramID(ctx context.Context, telegramID int64) (bool, error) {
+	var exists bool
+	err := r.RecordDBOperation(ctx, "UserExistsByTelegramID", func(ctx context.Context) error {
+		var err error
+		exists, err = r.inner.UserExistsByTelegramID(ctx, telegramID)
+		return err
+	})
+	return exists, err
+}
+
+func (r *ResilientUserRepository) SetBanned(ctx context.Context, id uint, banned bool) error {
+	return r.RecordDBOperation(ctx, "SetBanned", func(ctx context.Context) error {
+		return r.inner.SetBanned(ctx, id, banned)
+	})
+}
+
+func (r *ResilientUserRepository) UpdateLastActive(ctx context.Context, id uint) error {
+	return r.RecordDBOperation(ctx, "UpdateLastActive", func(ctx context.Context) error {
+		return r.inner.UpdateLastActive(ctx, id)
+	})
+}
+
+func (r *ResilientUserRepository) GetUserFeatures(ctx context.Context, id uint) (models.FeatureFlags, error) {
+	var flags models.FeatureFlags
+	err := r.RecordDBOperation(ctx, "GetUserFeatures", func(ctx context.Context) error {
+		var err error
+		flags, err = r.inner.GetUserFeatures(ctx, id)
+		return err
+	})
+	return flags, err
+}
+
+func (r *ResilientUserRepository) SetUserFeature(ctx context.Context, id uint, key string, value bool) error {
+	return r.RecordDBOperation(ctx, "SetUserFeature", func(ctx context.Context) error {
+		return r.inner.SetUserFeature(ctx, id, key, value)
+	})
+}
+
+func (r *ResilientUserRepository) ChangeTelegramID(ctx context.Context, userID uint, newTelegramID int64) error {
+	return r.RecordDBOperation(ctx, "ChangeTelegramID", func(ctx context.Context) error {
+		return r.inner.ChangeTelegramID(ctx, userID, newTelegramID)
+	})
+}
+
+func (r *ResilientUserRepository) UpdateUsername(ctx context.Context, userID uint, username string) error {
+	return r.RecordDBOperation(ctx, "UpdateUsername", func(ctx context.Context) error {
+		return r.inner.UpdateUsername(ctx, userID, username)
+	})
+}
+
+func (r *ResilientUserRepository) CountUsers(ctx context.Context) (int64, error) {
+	var count int64
+	err := r.RecordDBOperation(ctx, "CountUsers", func(ctx context.Context) error {
+		var err error
+		count, err = r.inner.CountUsers(ctx)
+		return err
+	})
+	return count, err
+}
+
+func (r *ResilientUserRepository) DeleteUser(ctx context.Context, id uint) error {
+	return r.RecordDBOperation(ctx, "DeleteUser", func(ctx context.Context) error {
+		return r.inner.DeleteUser(ctx, id)
+	})
+}
+
+func (r *ResilientUserRepository) UpdateUserRating(ctx context.Context, userID uint, score float64, raterID uint) error {
+	return r.RecordDBOperation(ctx, "UpdateUserRating", func(ctx context.Context) error {
+		return r.inner.UpdateUserRating(ctx, userID, score, raterID)
+	})
+}
+
+func (r *ResilientUserRepository) RecomputeUserRating(ctx context.Context, userID uint) error {
+	return r.RecordDBOperation(ctx, "RecomputeUserRating", func(ctx context.Context) error {
+		return r.inner.RecomputeUserRating(ctx, userID)
+	})
+}
+
+func (r *ResilientUserRepository) GetRatingHistory(ctx context.Context, userID uint, limit int) ([]models.UserRatingEvent, error) {
+	var events []models.UserRatingEvent
+	err := r.RecordDBOperation(ctx, "GetRatingHistory", func(ctx context.Context) error {
+		var err error
+		events, err = r.inner.GetRatingHistory(ctx, userID, limit)
+		return err
+	})
+	return events, err
+}
+
+func (r *ResilientUserRepository) DeleteRatingHistory(ctx context.Context, userID uint) error {
+	return r.RecordDBOperation(ctx, "DeleteRatingHistory", func(ctx context.Context) error {
+		return r.inner.DeleteRatingHistory(ctx, userID)
+	})
+}
+
+func (r *ResilientUserRepository) ListUsers(ctx context.Context, afterID uint, limit int, excludeBots bool) ([]models.User, error) {
+	var users []models.User
+	err := r.RecordDBOperation(ctx, "ListUsers", func(ctx context.Context) error {
+		var err error
+		users, err = r.inner.ListUsers(ctx, afterID, limit, excludeBots)
+		return err
+	})
+	return users, err
+}
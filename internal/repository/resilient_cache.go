@@ -0,0 +1,256 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/pkg/database"
+)
+
+// CacheWritePolicy selects how ResilientCacheRepository.SetUser applies a
+// write to the underlying CacheRepository.
+type CacheWritePolicy string
+
+const (
+	// CacheWritePolicyWriteThrough applies a SetUser write to Redis
+	// synchronously, so SetUser doesn't return until the cache reflects
+	// it. This is the default: a caller that just wrote to Postgres and
+	// wants the cache to agree with it immediately (e.g. before
+	// returning a response) needs this ordering guarantee.
+	CacheWritePolicyWriteThrough CacheWritePolicy = "write-through"
+	// CacheWritePolicyWriteBehind queues a SetUser write onto a bounded
+	// channel and returns immediately; a single background worker
+	// applies queued writes to Redis in order. This trades a brief
+	// window of cache staleness for lower SetUser latency on the
+	// request path. A full queue falls back to a synchronous write, so
+	// a burst of writes can't silently drop one.
+	CacheWritePolicyWriteBehind CacheWritePolicy = "write-behind"
+)
+
+// defaultWriteBehindQueueSize bounds the write-behind queue when
+// NewResilientCacheRepository is given a size <= 0.
+const defaultWriteBehindQueueSize = 256
+
+// userInvalidationChannel is the Redis pub/sub channel a write publishes
+// a user id on, so every replica's L1 cache evicts it rather than just
+// the replica that made the write.
+const userInvalidationChannel = "user.invalidate"
+
+// defaultL1Size bounds how many users the in-process L1 cache holds
+// when NewResilientCacheRepository is given a size <= 0.
+const defaultL1Size = 1024
+
+// defaultL1TTL is how long an entry survives in the L1 cache when
+// NewResilientCacheRepository is given a TTL <= 0. It is intentionally
+// much shorter than defaultUserCacheSoftTTL, since invalidating L1 across
+// process instances relies on a best-effort pub/sub message (see
+// publishInvalidation) that a replica can miss.
+const defaultL1TTL = 30 * time.Second
+
+// ResilientCacheRepository wraps CacheRepository with a small bounded L1
+// cache in front of Redis for GetUser, the hottest read this service
+// serves, and records latency/failure metrics for its own Set*
+// overrides. ClearUserCache also evicts L1 and publishes a cross-instance
+// invalidation. All other operations pass straight through to the
+// embedded CacheRepository.
+type ResilientCacheRepository struct {
+	*CacheRepository
+
+	l1 *lru.LRU[uint, *models.User]
+
+	writePolicy CacheWritePolicy
+	writeQueue  chan *models.User
+	writeWg     sync.WaitGroup
+}
+
+// NewResilientCacheRepository wraps inner with an L1 cache of l1Size
+// entries, each valid for l1TTL. size/ttl <= 0 fall back to
+// defaultL1Size/defaultL1TTL. writePolicy selects how SetUser applies a
+// write; an empty value falls back to CacheWritePolicyWriteThrough. When
+// writePolicy is CacheWritePolicyWriteBehind, writeQueueSize bounds the
+// background queue (<= 0 falls back to defaultWriteBehindQueueSize) and
+// a single worker goroutine is started to drain it; callers must call
+// Close to flush the queue and stop that worker on shutdown.
+func NewResilientCacheRepository(inner *CacheRepository, l1Size int, l1TTL time.Duration, writePolicy CacheWritePolicy, writeQueueSize int) *ResilientCacheRepository {
+	if l1Size <= 0 {
+		l1Size = defaultL1Size
+	}
+	if l1TTL <= 0 {
+		l1TTL = defaultL1TTL
+	}
+	if writePolicy == "" {
+		writePolicy = CacheWritePolicyWriteThrough
+	}
+	r := &ResilientCacheRepository{
+		CacheRepository: inner,
+		l1:              lru.NewLRU[uint, *models.User](l1Size, nil, l1TTL),
+		writePolicy:     writePolicy,
+	}
+	if writePolicy == CacheWritePolicyWriteBehind {
+		if writeQueueSize <= 0 {
+			writeQueueSize = defaultWriteBehindQueueSize
+		}
+		r.writeQueue = make(chan *models.User, writeQueueSize)
+		r.writeWg.Add(1)
+		go r.runWriteBehindWorker()
+	}
+	return r
+}
+
+// GetUser checks the L1 cache before falling back to the embedded
+// CacheRepository (Redis), populating L1 on a Redis hit. Only the Redis
+// round trip for a full fetch is timed in
+// jollyroger_cache_operation_duration_seconds; the cheap liveness check
+// below an L1 hit is not.
+//
+// An L1 hit is confirmed against Redis with a plain EXISTS before being
+// trusted: L1's own TTL bounds how long an entry can live in-process,
+// but it isn't tied to the Redis-side soft TTL, so without this check a
+// request could keep being served a non-stale response for up to l1TTL
+// after the normal cache entry actually expired, silently skipping the
+// stale-copy path callers rely on to know a response might be out of
+// date.
+func (r *ResilientCacheRepository) GetUser(ctx context.Context, id uint) (*models.User, error) {
+	if user, ok := r.l1.Get(id); ok {
+		live, err := r.CacheRepository.userCacheEntryLive(ctx, id)
+		if err != nil {
+			// Redis is unreachable; serve the L1 copy rather than fail
+			// the read outright.
+			return user, nil
+		}
+		if live {
+			return user, nil
+		}
+		r.l1.Remove(id)
+	}
+
+	start := time.Now()
+	user, err := r.CacheRepository.GetUser(ctx, id)
+	metrics.CacheOperationDuration.WithLabelValues("GetUser").Observe(time.Since(start).Seconds())
+	if err == nil && user != nil {
+		r.l1.Add(id, user)
+	}
+	return user, err
+}
+
+// SetUser populates L1 immediately, then applies the Redis write per
+// r.writePolicy: write-through applies it inline before returning;
+// write-behind enqueues it for the background worker and returns
+// immediately, unless the queue is full, in which case it falls back to
+// a synchronous write so a burst of writes can't silently drop one.
+func (r *ResilientCacheRepository) SetUser(ctx context.Context, user *models.User) error {
+	r.l1.Add(user.ID, user)
+
+	if r.writePolicy == CacheWritePolicyWriteBehind {
+		select {
+		case r.writeQueue <- user:
+			return nil
+		default:
+			metrics.CacheWriteBehindQueueFullTotal.Inc()
+		}
+	}
+
+	return r.setUserSync(ctx, user)
+}
+
+// setUserSync writes user through to Redis and publishes an
+// invalidation so any other replica holding a now-stale L1 copy evicts
+// it too. It's the synchronous path shared by write-through SetUser
+// calls and the write-behind worker.
+func (r *ResilientCacheRepository) setUserSync(ctx context.Context, user *models.User) error {
+	start := time.Now()
+	err := r.CacheRepository.SetUser(ctx, user)
+	metrics.CacheOperationDuration.WithLabelValues("SetUser").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.CacheWriteFailuresTotal.WithLabelValues("SetUser").Inc()
+		return err
+	}
+	r.publishInvalidation(ctx, user.ID)
+	return nil
+}
+
+// runWriteBehindWorker applies queued SetUser writes to Redis in order,
+// one at a time, until r.writeQueue is closed and drained. Each write
+// uses a fresh background context rather than the request's, since the
+// request that enqueued it may have already returned.
+func (r *ResilientCacheRepository) runWriteBehindWorker() {
+	defer r.writeWg.Done()
+	for user := range r.writeQueue {
+		if err := r.setUserSync(context.Background(), user); err != nil {
+			metrics.CacheWriteBehindFailuresTotal.Inc()
+		}
+	}
+}
+
+// Close stops accepting further write-behind writes and blocks until
+// every write already queued has been applied, so a shutdown doesn't
+// drop writes that were queued but not yet flushed. It's a no-op for
+// CacheWritePolicyWriteThrough, which never queues anything.
+func (r *ResilientCacheRepository) Close() {
+	if r.writeQueue == nil {
+		return
+	}
+	close(r.writeQueue)
+	r.writeWg.Wait()
+}
+
+// SetStaleUser writes through to the stale fallback copy in Redis. It
+// isn't covered by the L1 cache, since L1 already serves that role for
+// the common case and the stale copy only ever matters once both L1 and
+// the normal Redis entry have missed.
+func (r *ResilientCacheRepository) SetStaleUser(ctx context.Context, user *models.User) error {
+	start := time.Now()
+	err := r.CacheRepository.SetStaleUser(ctx, user)
+	metrics.CacheOperationDuration.WithLabelValues("SetStaleUser").Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.CacheWriteFailuresTotal.WithLabelValues("SetStaleUser").Inc()
+	}
+	return err
+}
+
+// ClearUserCache evicts id from both L1 and Redis, and publishes an
+// invalidation so other replicas evict their own L1 copy.
+func (r *ResilientCacheRepository) ClearUserCache(ctx context.Context, id uint) error {
+	r.l1.Remove(id)
+	if err := r.CacheRepository.ClearUserCache(ctx, id); err != nil {
+		return err
+	}
+	r.publishInvalidation(ctx, id)
+	return nil
+}
+
+// publishInvalidation broadcasts id on userInvalidationChannel. Failures
+// are swallowed: at worst another replica's L1 entry lives on until its
+// TTL expires, which is the same staleness window L1 already tolerates.
+func (r *ResilientCacheRepository) publishInvalidation(ctx context.Context, id uint) {
+	if err := r.client.Publish(ctx, r.key(userInvalidationChannel), strconv.FormatUint(uint64(id), 10)).Err(); err != nil {
+		metrics.CacheWriteFailuresTotal.WithLabelValues("PublishInvalidation").Inc()
+	}
+}
+
+// RunInvalidationListener subscribes to userInvalidationChannel and
+// evicts each published user id from L1, so this replica's L1 cache
+// stays consistent with writes made on other replicas. It runs until ctx
+// is canceled, using a database.ReliableSubscriber so a dropped
+// connection or a server restart resubscribes automatically instead of
+// leaving this replica's L1 cache silently out of sync until it's
+// restarted.
+func (r *ResilientCacheRepository) RunInvalidationListener(ctx context.Context, log *zap.Logger) {
+	sub := database.NewReliableSubscriber(r.client, log, 0, r.key(userInvalidationChannel))
+	sub.Run(ctx, func(msg *redis.Message) {
+		id, err := strconv.ParseUint(msg.Payload, 10, 64)
+		if err != nil {
+			log.Warn("invalidation listener: malformed payload", zap.String("payload", msg.Payload), zap.Error(err))
+			return
+		}
+		r.l1.Remove(uint(id))
+	})
+}
@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+)
+
+func TestResilientCacheRepository_GetUser_SecondCallServedFromL1(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := NewResilientCacheRepository(NewCacheRepository(client, ""), 0, time.Minute, "", 0)
+
+	ctx := context.Background()
+	if err := cache.SetUser(ctx, &models.User{ID: 1, Username: "blackbeard"}); err != nil {
+		t.Fatalf("SetUser: %v", err)
+	}
+
+	if _, err := cache.GetUser(ctx, 1); err != nil {
+		t.Fatalf("first GetUser: %v", err)
+	}
+
+	// Kill Redis; a second GetUser should still succeed because it is
+	// served from L1: its liveness check against the (now-dead) Redis
+	// client fails, and GetUser falls back to the L1 copy.
+	mr.Close()
+
+	user, err := cache.GetUser(ctx, 1)
+	if err != nil {
+		t.Fatalf("expected second GetUser to be served from L1, got error: %v", err)
+	}
+	if user.Username != "blackbeard" {
+		t.Fatalf("unexpected user from L1: %+v", user)
+	}
+}
+
+func TestResilientCacheRepository_SetUser_FailureAgainstDownRedisIncrementsCounter(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := NewResilientCacheRepository(NewCacheRepository(client, ""), 0, time.Minute, "", 0)
+
+	before := testutil.ToFloat64(metrics.CacheWriteFailuresTotal.WithLabelValues("SetUser"))
+
+	mr.Close()
+
+	if err := cache.SetUser(context.Background(), &models.User{ID: 1, Username: "ghost-ship"}); err == nil {
+		t.Fatalf("expected SetUser to fail against a down redis")
+	}
+
+	after := testutil.ToFloat64(metrics.CacheWriteFailuresTotal.WithLabelValues("SetUser"))
+	if after != before+1 {
+		t.Fatalf("expected cacheWriteFailuresTotal to increment by 1, got delta %v", after-before)
+	}
+}
+
+func TestResilientCacheRepository_SetUser_WriteBehindDefersThenAppliesTheRedisWrite(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := NewResilientCacheRepository(NewCacheRepository(client, ""), 0, time.Minute, CacheWritePolicyWriteBehind, 8)
+	defer cache.Close()
+
+	if err := cache.SetUser(context.Background(), &models.User{ID: 1, Username: "queued-captain"}); err != nil {
+		t.Fatalf("SetUser: %v", err)
+	}
+
+	direct := NewCacheRepository(client, "")
+	deadline := time.After(time.Second)
+	for {
+		user, err := direct.GetUser(context.Background(), 1)
+		if err == nil && user != nil && user.Username == "queued-captain" {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("expected the write-behind worker to eventually apply the queued write")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestResilientCacheRepository_Close_FlushesQueuedWritesBeforeReturning(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := NewResilientCacheRepository(NewCacheRepository(client, ""), 0, time.Minute, CacheWritePolicyWriteBehind, 8)
+
+	if err := cache.SetUser(context.Background(), &models.User{ID: 1, Username: "flushed-captain"}); err != nil {
+		t.Fatalf("SetUser: %v", err)
+	}
+	cache.Close()
+
+	direct := NewCacheRepository(client, "")
+	user, err := direct.GetUser(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetUser after Close: %v", err)
+	}
+	if user.Username != "flushed-captain" {
+		t.Fatalf("expected Close to have flushed the queued write, got %+v", user)
+	}
+}
+
+func TestResilientCacheRepository_SetUser_EvictsL1OnOtherInstanceSharingRedis(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	// Two ResilientCacheRepository instances, each with its own L1
+	// cache, standing in for two replicas sharing one Redis.
+	instanceA := NewResilientCacheRepository(NewCacheRepository(redis.NewClient(&redis.Options{Addr: mr.Addr()}), ""), 0, time.Minute, "", 0)
+	instanceB := NewResilientCacheRepository(NewCacheRepository(redis.NewClient(&redis.Options{Addr: mr.Addr()}), ""), 0, time.Minute, "", 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go instanceB.RunInvalidationListener(ctx, zap.NewNop())
+
+	if err := instanceA.SetUser(ctx, &models.User{ID: 1, Username: "blackbeard"}); err != nil {
+		t.Fatalf("SetUser on instanceA: %v", err)
+	}
+	// Seed instanceB's L1 directly, standing in for instanceB having
+	// previously served a GetUser for id 1 and cached the result.
+	instanceB.l1.Add(1, &models.User{ID: 1, Username: "blackbeard"})
+
+	if err := instanceA.SetUser(ctx, &models.User{ID: 1, Username: "renamed"}); err != nil {
+		t.Fatalf("SetUser update on instanceA: %v", err)
+	}
+
+	deadline := time.After(time.Second)
+	for {
+		if _, ok := instanceB.l1.Get(1); !ok {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("expected instanceB's L1 entry for user 1 to be evicted after instanceA's write")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/resilience"
+)
+
+type sleepyUserRepository struct{ sleep time.Duration }
+
+func (r *sleepyUserRepository) CreateUser(ctx context.Context, _ *models.User) error {
+	select {
+	case <-time.After(r.sleep):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+func (r *sleepyUserRepository) CreateUserWithOnboarding(ctx context.Context, user *models.User, _ []string, _ *models.UserLocation) error {
+	return r.CreateUser(ctx, user)
+}
+func (r *sleepyUserRepository) GetUserByID(context.Context, uint) (*models.User, error) { return nil, nil }
+func (r *sleepyUserRepository) ListUsers(context.Context, uint, int, bool) ([]models.User, error) {
+	return nil, nil
+}
+
+func (r *sleepyUserRepository) GetActiveUserByID(context.Context, uint) (*models.User, error) {
+	return nil, nil
+}
+
+func (r *sleepyUserRepository) GetUsersByTelegramIDs(context.Context, []int64) (map[int64]*models.User, error) {
+	return nil, nil
+}
+
+func (r *sleepyUserRepository) SetBanned(context.Context, uint, bool) error { return nil }
+
+func (r *sleepyUserRepository) ChangeTelegramID(context.Context, uint, int64) error { return nil }
+func (r *sleepyUserRepository) UpdateUsername(context.Context, uint, string) error  { return nil }
+func (r *sleepyUserRepository) CountUsers(context.Context) (int64, error)           { return 0, nil }
+
+func (r *sleepyUserRepository) UpdateLastActive(context.Context, uint) error { return nil }
+
+func TestRecordDBOperation_LogsAndCountsSlowOperations(t *testing.T) {
+	before := testutil.ToFloat64(metrics.DBSlowOperationsTotal.WithLabelValues("CreateUser"))
+
+	core, logs := observer.New(zap.WarnLevel)
+	log := zap.New(core)
+	repo := NewResilientUserRepository(&sleepyUserRepository{sleep: 5 * time.Millisecond}, resilience.Config{SlowQueryThreshold: time.Millisecond}, log)
+
+	if err := repo.CreateUser(context.Background(), &models.User{}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	if logs.Len() != 1 {
+		t.Fatalf("expected exactly one warning log, got %d", logs.Len())
+	}
+	after := testutil.ToFloat64(metrics.DBSlowOperationsTotal.WithLabelValues("CreateUser"))
+	if after != before+1 {
+		t.Fatalf("expected slow operation counter to increment by 1, got delta %v", after-before)
+	}
+}
+
+func (r *sleepyUserRepository) DeleteUser(context.Context, uint) error { return nil }
+
+func (r *sleepyUserRepository) UpdateUserRating(context.Context, uint, float64, uint) error { return nil }
+
+func (r *sleepyUserRepository) RecomputeUserRating(context.Context, uint) error { return nil }
+
+func (r *sleepyUserRepository) GetRatingHistory(context.Context, uint, int) ([]models.UserRatingEvent, error) {
+	return nil, nil
+}
+
+func (r *sleepyUserRepository) DeleteRatingHistory(context.Context, uint) error { return nil }
+
+func (r *sleepyUserRepository) UserExists(context.Context, uint) (bool, error) { return false, nil }
+
+func (r *sleepyUserRepository) UserExistsByTelegramID(context.Context, int64) (bool, error) {
+	return false, nil
+}
+
+func (r *sleepyUserRepository) GetUserFeatures(context.Context, uint) (models.FeatureFlags, error) {
+	return nil, nil
+}
+func (r *sleepyUserRepository) SetUserFeature(context.Context, uint, string, bool) error { return nil }
+
+func TestRecordDBOperation_CallerCancellationStopsTheOperation(t *testing.T) {
+	repo := NewResilientUserRepository(&sleepyUserRepository{sleep: time.Second}, resilience.Config{MaxOperationTimeout: time.Minute}, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		cancel()
+	}()
+
+	err := repo.CreateUser(ctx, &models.User{})
+	if err == nil {
+		t.Fatalf("expected caller cancellation to be observed by the DB operation, got nil error")
+	}
+}
+
+func TestRecordDBOperation_CapsUnboundedCallerContext(t *testing.T) {
+	repo := NewResilientUserRepository(&sleepyUserRepository{sleep: time.Second}, resilience.Config{MaxOperationTimeout: 5 * time.Millisecond}, zap.NewNop())
+
+	start := time.Now()
+	err := repo.CreateUser(context.Background(), &models.User{})
+	elapsed := time.Since(start)
+
+	if err == nil {
+		t.Fatalf("expected the configured max operation timeout to cut off a caller with no deadline")
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Fatalf("expected the operation to be cut off well before its own 1s sleep, took %v", elapsed)
+	}
+}
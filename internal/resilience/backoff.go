@@ -0,0 +1,107 @@
+package resilience
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+)
+
+// JitterStrategy selects how calculateBackoff randomizes an exponential
+// backoff duration before RetryOptions.MaxBackoff is applied.
+type JitterStrategy int
+
+const (
+	// JitterProportional applies symmetric jitter of +/-50% around the
+	// exponential value. It's RetryOptions' default, kept for backward
+	// compatibility with callers that don't care about synchronized
+	// retries.
+	JitterProportional JitterStrategy = iota
+	// JitterFull picks a uniformly random duration in [0, exp], so
+	// clients that failed at the same time don't resynchronize on their
+	// next attempt the way proportional jitter still can.
+	JitterFull
+	// JitterEqual splits the exponential value in half and adds a
+	// uniformly random duration up to the other half ([exp/2, exp]),
+	// trading some of JitterFull's spread for a backoff that never
+	// collapses toward zero.
+	JitterEqual
+)
+
+// RetryOptions configures WithBackoff. It's a separate, richer entry
+// point from WithRetry's fixed-delay signature, which existing callers
+// keep using unchanged.
+type RetryOptions struct {
+	// MaxRetries is how many additional attempts are made after the
+	// first, same as WithRetry's maxRetries.
+	MaxRetries int
+	// BaseBackoff is the delay before the first retry; each subsequent
+	// retry doubles it (BaseBackoff * 2^attempt) before jitter is
+	// applied.
+	BaseBackoff time.Duration
+	// MaxBackoff caps the jittered delay. <= 0 means uncapped.
+	MaxBackoff time.Duration
+	// Jitter selects how the exponential value is randomized. The zero
+	// value is JitterProportional.
+	Jitter JitterStrategy
+}
+
+// calculateBackoff returns the exponential delay for attempt (0-indexed,
+// counting from the first retry), randomized per opts.Jitter and capped
+// at opts.MaxBackoff. The cap is applied after jitter, so a full-jitter
+// draw that lands above MaxBackoff is clamped down rather than
+// discarded.
+func calculateBackoff(attempt int, opts RetryOptions) time.Duration {
+	exp := opts.BaseBackoff << attempt
+	if exp <= 0 {
+		exp = opts.BaseBackoff
+	}
+
+	var backoff time.Duration
+	switch opts.Jitter {
+	case JitterFull:
+		backoff = time.Duration(rand.Int63n(int64(exp) + 1))
+	case JitterEqual:
+		half := exp / 2
+		backoff = half + time.Duration(rand.Int63n(int64(exp)-int64(half)+1))
+	default:
+		delta := time.Duration(rand.Int63n(int64(exp) + 1))
+		backoff = exp/2 + delta
+	}
+
+	if opts.MaxBackoff > 0 && backoff > opts.MaxBackoff {
+		backoff = opts.MaxBackoff
+	}
+	return backoff
+}
+
+// WithBackoff runs fn like WithRetry, but waits an exponentially
+// growing, jittered delay (per opts) between attempts instead of a
+// fixed one. Each retry increments jollyroger_retry_attempts_total for
+// operation, same as WithRetry. It gives up early if ctx is canceled
+// between attempts.
+func WithBackoff(ctx context.Context, operation string, opts RetryOptions, fn func() error) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = fn()
+		if attempt > 0 {
+			outcome := "failure"
+			if err == nil {
+				outcome = "success"
+			}
+			metrics.RetryAttemptsTotal.WithLabelValues(operation, outcome).Inc()
+		}
+		if err == nil {
+			return nil
+		}
+		if attempt >= opts.MaxRetries {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(calculateBackoff(attempt, opts)):
+		}
+	}
+}
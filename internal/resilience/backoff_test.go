@@ -0,0 +1,86 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCalculateBackoff_FullJitterStaysWithinZeroAndExponential(t *testing.T) {
+	opts := RetryOptions{BaseBackoff: 100 * time.Millisecond, Jitter: JitterFull}
+
+	for attempt := 0; attempt < 5; attempt++ {
+		exp := opts.BaseBackoff << attempt
+		for i := 0; i < 50; i++ {
+			backoff := calculateBackoff(attempt, opts)
+			if backoff < 0 || backoff > exp {
+				t.Fatalf("attempt %d: expected backoff in [0, %v], got %v", attempt, exp, backoff)
+			}
+		}
+	}
+}
+
+func TestCalculateBackoff_EqualJitterStaysWithinHalfAndExponential(t *testing.T) {
+	opts := RetryOptions{BaseBackoff: 100 * time.Millisecond, Jitter: JitterEqual}
+
+	for attempt := 0; attempt < 5; attempt++ {
+		exp := opts.BaseBackoff << attempt
+		half := exp / 2
+		for i := 0; i < 50; i++ {
+			backoff := calculateBackoff(attempt, opts)
+			if backoff < half || backoff > exp {
+				t.Fatalf("attempt %d: expected backoff in [%v, %v], got %v", attempt, half, exp, backoff)
+			}
+		}
+	}
+}
+
+func TestCalculateBackoff_ProportionalJitterStaysWithinHalfAndOneAndAHalfExponential(t *testing.T) {
+	opts := RetryOptions{BaseBackoff: 100 * time.Millisecond, Jitter: JitterProportional}
+
+	for attempt := 0; attempt < 5; attempt++ {
+		exp := opts.BaseBackoff << attempt
+		for i := 0; i < 50; i++ {
+			backoff := calculateBackoff(attempt, opts)
+			if backoff < exp/2 || backoff > exp+exp/2 {
+				t.Fatalf("attempt %d: expected backoff in [%v, %v], got %v", attempt, exp/2, exp+exp/2, backoff)
+			}
+		}
+	}
+}
+
+func TestCalculateBackoff_NeverExceedsMaxBackoffRegardlessOfStrategy(t *testing.T) {
+	for _, strategy := range []JitterStrategy{JitterProportional, JitterFull, JitterEqual} {
+		opts := RetryOptions{BaseBackoff: 100 * time.Millisecond, MaxBackoff: 250 * time.Millisecond, Jitter: strategy}
+		for attempt := 0; attempt < 10; attempt++ {
+			for i := 0; i < 50; i++ {
+				if backoff := calculateBackoff(attempt, opts); backoff > opts.MaxBackoff {
+					t.Fatalf("strategy %d attempt %d: expected backoff capped at %v, got %v", strategy, attempt, opts.MaxBackoff, backoff)
+				}
+			}
+		}
+	}
+}
+
+func TestWithBackoff_RetriesUntilSuccessAndRespectsMaxBackoff(t *testing.T) {
+	attempts := 0
+	err := WithBackoff(context.Background(), "TestWithBackoff", RetryOptions{
+		MaxRetries:  3,
+		BaseBackoff: time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+		Jitter:      JitterFull,
+	}, func() error {
+		attempts++
+		if attempts <= 2 {
+			return errors.New("transient failure")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts (1 initial + 2 retries), got %d", attempts)
+	}
+}
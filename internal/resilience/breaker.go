@@ -0,0 +1,133 @@
+package resilience
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+)
+
+// BreakerState is one of the three states a CircuitBreaker can be in.
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half_open"
+	default:
+		return "closed"
+	}
+}
+
+// defaultFailureThreshold is how many consecutive failures trip a
+// CircuitBreaker when NewCircuitBreaker is given one <= 0.
+const defaultFailureThreshold = 3
+
+// defaultResetTimeout is how long a CircuitBreaker stays open before
+// allowing a half-open probe, when NewCircuitBreaker is given one <= 0.
+const defaultResetTimeout = 30 * time.Second
+
+// CircuitBreaker is a minimal consecutive-failure breaker: it opens
+// after failureThreshold consecutive failures and stays open for
+// resetTimeout, after which it moves to half-open and lets calls through
+// again to test whether the dependency has recovered. The number of calls
+// let through while half-open is not limited.
+type CircuitBreaker struct {
+	name             string
+	failureThreshold int
+	resetTimeout     time.Duration
+
+	mu                  sync.Mutex
+	state               BreakerState
+	consecutiveFailures int
+	openedAt            time.Time
+}
+
+// NewCircuitBreaker constructs a closed CircuitBreaker identified by
+// name (used as the breaker metric label). failureThreshold/
+// resetTimeout <= 0 fall back to defaultFailureThreshold/
+// defaultResetTimeout.
+func NewCircuitBreaker(name string, failureThreshold int, resetTimeout time.Duration) *CircuitBreaker {
+	if failureThreshold <= 0 {
+		failureThreshold = defaultFailureThreshold
+	}
+	if resetTimeout <= 0 {
+		resetTimeout = defaultResetTimeout
+	}
+	return &CircuitBreaker{name: name, failureThreshold: failureThreshold, resetTimeout: resetTimeout}
+}
+
+// Allow reports whether a call should be attempted. An open breaker
+// denies calls until resetTimeout has elapsed, at which point it moves
+// to half-open and allows the call through. Allow keeps returning true
+// while half-open, until the next RecordSuccess closes the breaker or
+// RecordFailure re-opens it.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.resetTimeout {
+			return false
+		}
+		b.transitionLocked(BreakerHalfOpen)
+		return true
+	default:
+		return true
+	}
+}
+
+// RecordSuccess reports that the most recent call succeeded, closing
+// the breaker and resetting its failure count.
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.consecutiveFailures = 0
+	if b.state != BreakerClosed {
+		b.transitionLocked(BreakerClosed)
+	}
+}
+
+// RecordFailure reports that the most recent call failed. It opens the
+// breaker once failureThreshold consecutive failures have accumulated,
+// or immediately if the failing call was a half-open probe.
+func (b *CircuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerHalfOpen {
+		b.transitionLocked(BreakerOpen)
+		return
+	}
+	b.consecutiveFailures++
+	if b.consecutiveFailures >= b.failureThreshold {
+		b.transitionLocked(BreakerOpen)
+	}
+}
+
+// State reports the breaker's current state.
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// transitionLocked must be called with b.mu held.
+func (b *CircuitBreaker) transitionLocked(to BreakerState) {
+	b.state = to
+	if to == BreakerOpen {
+		b.openedAt = time.Now()
+		b.consecutiveFailures = 0
+		metrics.CircuitBreakerTripsTotal.WithLabelValues(b.name).Inc()
+	}
+	metrics.CircuitBreakerTransitionsTotal.WithLabelValues(b.name, to.String()).Inc()
+}
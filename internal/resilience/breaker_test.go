@@ -0,0 +1,40 @@
+package resilience
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+)
+
+func TestCircuitBreaker_TripIncrementsTripsCounter(t *testing.T) {
+	b := NewCircuitBreaker("TestBreakerTrips", 2, 0)
+	before := testutil.ToFloat64(metrics.CircuitBreakerTripsTotal.WithLabelValues("TestBreakerTrips"))
+
+	b.RecordFailure()
+	if b.State() != BreakerClosed {
+		t.Fatalf("expected the breaker to stay closed below the failure threshold, got %v", b.State())
+	}
+	b.RecordFailure()
+	if b.State() != BreakerOpen {
+		t.Fatalf("expected the breaker to open at the failure threshold, got %v", b.State())
+	}
+
+	after := testutil.ToFloat64(metrics.CircuitBreakerTripsTotal.WithLabelValues("TestBreakerTrips"))
+	if after != before+1 {
+		t.Fatalf("expected exactly one trip to be recorded, got delta %v", after-before)
+	}
+}
+
+func TestCircuitBreaker_RecordSuccessDoesNotIncrementTripsCounter(t *testing.T) {
+	b := NewCircuitBreaker("TestBreakerNoTrip", 1, 0)
+	before := testutil.ToFloat64(metrics.CircuitBreakerTripsTotal.WithLabelValues("TestBreakerNoTrip"))
+
+	b.RecordSuccess()
+
+	after := testutil.ToFloat64(metrics.CircuitBreakerTripsTotal.WithLabelValues("TestBreakerNoTrip"))
+	if after != before {
+		t.Fatalf("expected no trip to be recorded, got delta %v", after-before)
+	}
+}
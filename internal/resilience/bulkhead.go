@@ -0,0 +1,56 @@
+package resilience
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// defaultBulkheadAcquireTimeout bounds how long Bulkhead.Acquire waits
+// for a free slot before giving up, when NewBulkhead is given one <= 0.
+const defaultBulkheadAcquireTimeout = 200 * time.Millisecond
+
+// Bulkhead limits how many expensive operations may run concurrently,
+// so a flood of calls to one costly endpoint (e.g. a geo search) can't
+// exhaust a resource, like the DB connection pool, that cheaper
+// endpoints also depend on.
+type Bulkhead struct {
+	sem            *semaphore.Weighted
+	acquireTimeout time.Duration
+}
+
+// NewBulkhead constructs a Bulkhead admitting up to maxConcurrent calls
+// at once. maxConcurrent <= 0 means unlimited: Acquire always succeeds
+// immediately. acquireTimeout <= 0 falls back to
+// defaultBulkheadAcquireTimeout.
+func NewBulkhead(maxConcurrent int, acquireTimeout time.Duration) *Bulkhead {
+	if acquireTimeout <= 0 {
+		acquireTimeout = defaultBulkheadAcquireTimeout
+	}
+	if maxConcurrent <= 0 {
+		return &Bulkhead{acquireTimeout: acquireTimeout}
+	}
+	return &Bulkhead{sem: semaphore.NewWeighted(int64(maxConcurrent)), acquireTimeout: acquireTimeout}
+}
+
+// Acquire reserves a slot, waiting up to acquireTimeout for one to free
+// up. It reports false if the timeout elapses, or ctx is canceled,
+// before a slot becomes available. A successful Acquire must be paired
+// with a Release.
+func (b *Bulkhead) Acquire(ctx context.Context) bool {
+	if b.sem == nil {
+		return true
+	}
+	ctx, cancel := context.WithTimeout(ctx, b.acquireTimeout)
+	defer cancel()
+	return b.sem.Acquire(ctx, 1) == nil
+}
+
+// Release frees the slot reserved by a successful Acquire.
+func (b *Bulkhead) Release() {
+	if b.sem == nil {
+		return
+	}
+	b.sem.Release(1)
+}
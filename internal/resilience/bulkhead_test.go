@@ -0,0 +1,46 @@
+package resilience
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestBulkhead_AcquireTimesOutWhenFull(t *testing.T) {
+	b := NewBulkhead(1, 20*time.Millisecond)
+
+	if !b.Acquire(context.Background()) {
+		t.Fatalf("expected the first Acquire to succeed")
+	}
+	defer b.Release()
+
+	start := time.Now()
+	if b.Acquire(context.Background()) {
+		t.Fatalf("expected a second Acquire to fail while the slot is held")
+	}
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Fatalf("expected Acquire to time out quickly, took %v", elapsed)
+	}
+}
+
+func TestBulkhead_ReleaseFreesASlotForTheNextAcquire(t *testing.T) {
+	b := NewBulkhead(1, 20*time.Millisecond)
+
+	if !b.Acquire(context.Background()) {
+		t.Fatalf("expected the first Acquire to succeed")
+	}
+	b.Release()
+
+	if !b.Acquire(context.Background()) {
+		t.Fatalf("expected Acquire to succeed once the slot was released")
+	}
+}
+
+func TestBulkhead_UnlimitedWhenMaxConcurrentIsNonPositive(t *testing.T) {
+	b := NewBulkhead(0, 0)
+	for i := 0; i < 5; i++ {
+		if !b.Acquire(context.Background()) {
+			t.Fatalf("expected an unlimited bulkhead to always admit callers")
+		}
+	}
+}
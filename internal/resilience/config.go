@@ -0,0 +1,38 @@
+// Package resilience holds configuration shared by the fault-tolerance
+// wrappers around JollyRogerUserService's repositories (retries, circuit
+// breaking, slow-query detection).
+package resilience
+
+import "time"
+
+// defaultSlowQueryThreshold is how long a repository operation may run
+// before it is logged and counted as slow.
+const defaultSlowQueryThreshold = 500 * time.Millisecond
+
+// defaultMaxOperationTimeout caps how long a single repository operation
+// may run even when the caller's own context has no deadline, so a
+// single wedged query can't hang forever.
+const defaultMaxOperationTimeout = 5 * time.Second
+
+// Config controls the resilience wrappers applied to the repository
+// layer.
+type Config struct {
+	// SlowQueryThreshold is the duration above which a repository
+	// operation is logged as a warning and counted in
+	// jollyroger_db_slow_operations_total.
+	SlowQueryThreshold time.Duration
+
+	// MaxOperationTimeout bounds how long a repository operation may
+	// run, derived from the caller's context rather than replacing it,
+	// so client cancellation still propagates while a missing or
+	// distant deadline doesn't let a query run unbounded.
+	MaxOperationTimeout time.Duration
+}
+
+// DefaultConfig returns sane production defaults.
+func DefaultConfig() Config {
+	return Config{
+		SlowQueryThreshold:  defaultSlowQueryThreshold,
+		MaxOperationTimeout: defaultMaxOperationTimeout,
+	}
+}
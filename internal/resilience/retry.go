@@ -0,0 +1,47 @@
+package resilience
+
+import (
+	"context"
+	"time"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+)
+
+// WithRetry runs fn, retrying up to maxRetries additional times while it
+// keeps returning an error, waiting backoff between attempts (backoff <=
+// 0 retries immediately). Each retry (not the first attempt) increments
+// jollyroger_retry_attempts_total for operation, labeled with whether
+// that retry succeeded or failed. It gives up early if ctx is canceled
+// between attempts.
+func WithRetry(ctx context.Context, operation string, maxRetries int, backoff time.Duration, fn func() error) error {
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = fn()
+		if attempt > 0 {
+			outcome := "failure"
+			if err == nil {
+				outcome = "success"
+			}
+			metrics.RetryAttemptsTotal.WithLabelValues(operation, outcome).Inc()
+		}
+		if err == nil {
+			return nil
+		}
+		if attempt >= maxRetries {
+			return err
+		}
+		if backoff <= 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			default:
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+	}
+}
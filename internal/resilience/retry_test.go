@@ -0,0 +1,111 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+)
+
+func TestWithRetry_IncrementsCounterOncePerRetry(t *testing.T) {
+	beforeFailure := testutil.ToFloat64(metrics.RetryAttemptsTotal.WithLabelValues("TestOp", "failure"))
+	beforeSuccess := testutil.ToFloat64(metrics.RetryAttemptsTotal.WithLabelValues("TestOp", "success"))
+
+	attempts := 0
+	err := WithRetry(context.Background(), "TestOp", 2, 0, func() error {
+		attempts++
+		if attempts <= 2 {
+			return errors.New("transient failure")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected eventual success, got %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts (1 initial + 2 retries), got %d", attempts)
+	}
+
+	afterFailure := testutil.ToFloat64(metrics.RetryAttemptsTotal.WithLabelValues("TestOp", "failure"))
+	afterSuccess := testutil.ToFloat64(metrics.RetryAttemptsTotal.WithLabelValues("TestOp", "success"))
+	if afterFailure != beforeFailure+1 {
+		t.Fatalf("expected the failed retry to increment the failure outcome by 1, got delta %v", afterFailure-beforeFailure)
+	}
+	if afterSuccess != beforeSuccess+1 {
+		t.Fatalf("expected the succeeding retry to increment the success outcome by 1, got delta %v", afterSuccess-beforeSuccess)
+	}
+}
+
+func TestWithRetry_PersistentFailureIncrementsRetryCounterByMaxRetries(t *testing.T) {
+	before := testutil.ToFloat64(metrics.RetryAttemptsTotal.WithLabelValues("TestOpPersistent", "failure"))
+
+	attempts := 0
+	err := WithRetry(context.Background(), "TestOpPersistent", 3, 0, func() error {
+		attempts++
+		return errors.New("persistent failure")
+	})
+	if err == nil {
+		t.Fatalf("expected the persistently-failing operation to return an error")
+	}
+	if attempts != 4 {
+		t.Fatalf("expected 4 attempts (1 initial + 3 retries), got %d", attempts)
+	}
+
+	after := testutil.ToFloat64(metrics.RetryAttemptsTotal.WithLabelValues("TestOpPersistent", "failure"))
+	if after != before+3 {
+		t.Fatalf("expected the failure outcome to increment by 3 (once per retry), got delta %v", after-before)
+	}
+}
+
+func TestWithRetry_GivesUpAfterMaxRetries(t *testing.T) {
+	wantErr := errors.New("still failing")
+	attempts := 0
+	err := WithRetry(context.Background(), "TestOpExhausted", 2, 0, func() error {
+		attempts++
+		return wantErr
+	})
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("expected the final error to be returned, got %v", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected exactly 3 attempts, got %d", attempts)
+	}
+}
+
+// connectAttempt simulates a startup connector (Postgres/Redis) that
+// only succeeds once it has been tried a fixed number of times, standing
+// in for a database that comes up a little late.
+type connectAttempt struct {
+	succeedsOn int
+	tries      int
+}
+
+func (c *connectAttempt) connect() error {
+	c.tries++
+	if c.tries < c.succeedsOn {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestWithRetry_ConnectorSucceedsOnThirdAttempt(t *testing.T) {
+	conn := &connectAttempt{succeedsOn: 3}
+
+	start := time.Now()
+	err := WithRetry(context.Background(), "connect_postgres", 4, time.Millisecond, conn.connect)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("expected the connector to eventually succeed, got %v", err)
+	}
+	if conn.tries != 3 {
+		t.Fatalf("expected exactly 3 attempts, got %d", conn.tries)
+	}
+	if elapsed < 2*time.Millisecond {
+		t.Fatalf("expected the two backoff waits between attempts to be observed, elapsed %v", elapsed)
+	}
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// apiVersionHeader is the metadata key a client sets to declare which
+// API version it was built against.
+const apiVersionHeader = "x-api-version"
+
+// APIVersionInterceptor returns a unary interceptor that reads
+// apiVersionHeader from the incoming request and rejects it with
+// codes.FailedPrecondition if the declared version falls outside
+// [minSupportedVersion, currentVersion]. A request that omits the
+// header is treated as currentVersion, so clients that predate this
+// check keep working unmodified; a header that isn't a valid integer is
+// treated as unsupported, since the server has no way to place it in
+// the supported range.
+func APIVersionInterceptor(currentVersion, minSupportedVersion int) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		version := currentVersion
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(apiVersionHeader); len(values) > 0 {
+				parsed, err := strconv.Atoi(values[0])
+				if err != nil {
+					return nil, status.Errorf(codes.FailedPrecondition, "malformed %s header %q", apiVersionHeader, values[0])
+				}
+				version = parsed
+			}
+		}
+
+		if version < minSupportedVersion || version > currentVersion {
+			return nil, status.Errorf(codes.FailedPrecondition, "unsupported API version %d: server supports %d-%d", version, minSupportedVersion, currentVersion)
+		}
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestAPIVersionInterceptor_AllowsASupportedVersion(t *testing.T) {
+	interceptor := APIVersionInterceptor(3, 1)
+
+	var handlerCalled bool
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerCalled = true
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(apiVersionHeader, "2"))
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	if !handlerCalled {
+		t.Fatalf("expected the handler to run for a version within the supported range")
+	}
+}
+
+func TestAPIVersionInterceptor_RejectsAVersionBelowTheSupportedRange(t *testing.T) {
+	interceptor := APIVersionInterceptor(3, 2)
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		t.Fatalf("expected the handler not to run for an unsupported version")
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(apiVersionHeader, "1"))
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for a version below the supported range, got %v", err)
+	}
+}
+
+func TestAPIVersionInterceptor_MissingHeaderDefaultsToTheCurrentVersion(t *testing.T) {
+	interceptor := APIVersionInterceptor(3, 2)
+
+	var handlerCalled bool
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handlerCalled = true
+		return nil, nil
+	}
+
+	if _, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	if !handlerCalled {
+		t.Fatalf("expected a missing header to default to the current version and be allowed")
+	}
+}
@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// DefaultDeadlineInterceptor returns a unary interceptor that injects a
+// deadline into any incoming request that doesn't already carry one, so a
+// client that forgets to set one can't hang a handler indefinitely.
+// defaults maps a full gRPC method name (e.g.
+// "/userpb.UserService/GetUser") to the deadline that method should get;
+// a method missing from defaults falls back to fallback. A fallback <= 0
+// with no matching entry in defaults leaves the request without a
+// deadline, same as today.
+func DefaultDeadlineInterceptor(defaults map[string]time.Duration, fallback time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if _, ok := ctx.Deadline(); ok {
+			return handler(ctx, req)
+		}
+
+		timeout, ok := defaults[info.FullMethod]
+		if !ok {
+			timeout = fallback
+		}
+		if timeout <= 0 {
+			return handler(ctx, req)
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func TestDefaultDeadlineInterceptor_InjectsAMissingDeadline(t *testing.T) {
+	interceptor := DefaultDeadlineInterceptor(map[string]time.Duration{
+		"/userpb.UserService/GetUser": time.Minute,
+	}, 5*time.Second)
+
+	var sawDeadline bool
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		_, sawDeadline = ctx.Deadline()
+		return nil, nil
+	}
+
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/userpb.UserService/GetUser"}, handler)
+	if err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	if !sawDeadline {
+		t.Fatalf("expected a deadline to be injected for a method lacking one")
+	}
+}
+
+func TestDefaultDeadlineInterceptor_FallsBackForAnUnlistedMethod(t *testing.T) {
+	interceptor := DefaultDeadlineInterceptor(nil, 5*time.Second)
+
+	var deadline time.Time
+	var ok bool
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		deadline, ok = ctx.Deadline()
+		return nil, nil
+	}
+
+	before := time.Now()
+	_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/userpb.UserService/GetUserPreferences"}, handler)
+	if err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	if !ok {
+		t.Fatalf("expected the global fallback deadline to be applied")
+	}
+	if deadline.Before(before.Add(4 * time.Second)) {
+		t.Fatalf("expected the fallback deadline to be roughly 5s out, got %v", deadline)
+	}
+}
+
+func TestDefaultDeadlineInterceptor_LeavesAnExistingDeadlineUntouched(t *testing.T) {
+	interceptor := DefaultDeadlineInterceptor(map[string]time.Duration{
+		"/userpb.UserService/GetUser": time.Minute,
+	}, 5*time.Second)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	want, _ := ctx.Deadline()
+
+	var got time.Time
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got, _ = ctx.Deadline()
+		return nil, nil
+	}
+
+	if _, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/userpb.UserService/GetUser"}, handler); err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	if !got.Equal(want) {
+		t.Fatalf("expected the caller's own deadline to pass through untouched, got %v want %v", got, want)
+	}
+}
@@ -0,0 +1,85 @@
+// Package server holds gRPC-server-level concerns that sit above the
+// business logic in internal/service, such as request-lifecycle
+// interceptors and graceful shutdown.
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+)
+
+// InFlightTracker counts active RPCs (unary and streaming) so a
+// graceful shutdown can wait for them to finish before forcing the
+// server closed.
+type InFlightTracker struct {
+	wg sync.WaitGroup
+}
+
+// UnaryInterceptor tracks a unary RPC for the duration of its handler.
+func (t *InFlightTracker) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	t.wg.Add(1)
+	metrics.InFlightRequests.Inc()
+	defer func() {
+		metrics.InFlightRequests.Dec()
+		t.wg.Done()
+	}()
+	return handler(ctx, req)
+}
+
+// StreamInterceptor is the streaming equivalent of UnaryInterceptor, so
+// a long-running RPC (e.g. a future StreamNearbyUsers) is also counted
+// for the duration it's open. It also tracks jollyroger_active_streams
+// labeled by method, which - unlike InFlightRequests - is scoped to
+// streams specifically, so a client that never disconnects shows up as
+// a gauge that only grows instead of blending into short-lived unary
+// traffic.
+func (t *InFlightTracker) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	t.wg.Add(1)
+	metrics.InFlightRequests.Inc()
+	metrics.ActiveStreams.WithLabelValues(info.FullMethod).Inc()
+	defer func() {
+		metrics.ActiveStreams.WithLabelValues(info.FullMethod).Dec()
+		metrics.InFlightRequests.Dec()
+		t.wg.Done()
+	}()
+	return handler(srv, ss)
+}
+
+// Wait blocks until every tracked RPC has finished, or ctx is done,
+// whichever comes first. It returns true if every RPC finished cleanly.
+func (t *InFlightTracker) Wait(ctx context.Context) bool {
+	done := make(chan struct{})
+	go func() {
+		t.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Drain stops srv from accepting new RPCs and waits up to timeout for
+// tracker's in-flight RPCs to finish. If they don't finish in time, it
+// forces the server closed with Stop rather than waiting indefinitely.
+func Drain(srv *grpc.Server, tracker *InFlightTracker, timeout time.Duration) {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if !tracker.Wait(ctx) {
+		srv.Stop()
+	}
+	<-stopped
+}
@@ -0,0 +1,100 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+)
+
+func TestInFlightTracker_WaitBlocksUntilTheHandlerFinishes(t *testing.T) {
+	tracker := &InFlightTracker{}
+	release := make(chan struct{})
+	handlerDone := make(chan struct{})
+
+	go func() {
+		tracker.UnaryInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			<-release
+			return nil, nil
+		})
+		close(handlerDone)
+	}()
+	time.Sleep(10 * time.Millisecond) // let the interceptor register before we wait on it
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	waitDone := make(chan bool, 1)
+	go func() { waitDone <- tracker.Wait(ctx) }()
+
+	select {
+	case <-waitDone:
+		t.Fatalf("expected Wait to block while the request is still in flight")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-handlerDone
+
+	if !<-waitDone {
+		t.Fatalf("expected Wait to return true once the in-flight request finished")
+	}
+}
+
+func TestInFlightTracker_ActiveStreamsGaugeTracksAnOpenStream(t *testing.T) {
+	tracker := &InFlightTracker{}
+	method := "/jollyroger.user.v1.UserService/TestStream"
+	release := make(chan struct{})
+	handlerDone := make(chan struct{})
+
+	before := testutil.ToFloat64(metrics.ActiveStreams.WithLabelValues(method))
+
+	go func() {
+		tracker.StreamInterceptor(nil, nil, &grpc.StreamServerInfo{FullMethod: method}, func(srv interface{}, ss grpc.ServerStream) error {
+			<-release
+			return nil
+		})
+		close(handlerDone)
+	}()
+	time.Sleep(10 * time.Millisecond) // let the interceptor register before we check the gauge
+
+	if during := testutil.ToFloat64(metrics.ActiveStreams.WithLabelValues(method)); during != before+1 {
+		t.Fatalf("expected the gauge to go up by 1 while the stream is open, got delta %v", during-before)
+	}
+
+	close(release)
+	<-handlerDone
+
+	if after := testutil.ToFloat64(metrics.ActiveStreams.WithLabelValues(method)); after != before {
+		t.Fatalf("expected the gauge to return to its starting value once the stream finished, got delta %v", after-before)
+	}
+}
+
+func TestDrain_ForcesStopAfterTimeoutWhenARequestNeverFinishes(t *testing.T) {
+	tracker := &InFlightTracker{}
+	srv := grpc.NewServer()
+
+	// Simulate an RPC (e.g. a stuck streaming call) that never returns
+	// on its own.
+	block := make(chan struct{})
+	go tracker.UnaryInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		<-block
+		return nil, nil
+	})
+	defer close(block)
+	time.Sleep(10 * time.Millisecond)
+
+	start := time.Now()
+	Drain(srv, tracker, 50*time.Millisecond)
+	elapsed := time.Since(start)
+
+	if elapsed < 50*time.Millisecond {
+		t.Fatalf("expected Drain to wait out the configured timeout, only waited %v", elapsed)
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Fatalf("expected Drain to force Stop soon after the timeout elapsed, took %v", elapsed)
+	}
+}
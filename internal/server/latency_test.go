@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+)
+
+func TestLatencyUnaryInterceptor_RecordsRequestDuration(t *testing.T) {
+	const method = "/userpb.UserService/GetUser"
+	histogram := metrics.GRPCRequestDuration.WithLabelValues(method).(prometheus.Histogram)
+
+	var before dto.Metric
+	if err := histogram.Write(&before); err != nil {
+		t.Fatalf("write metric: %v", err)
+	}
+
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		time.Sleep(time.Millisecond)
+		return nil, nil
+	}
+
+	if _, err := LatencyUnaryInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, handler); err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+
+	var after dto.Metric
+	if err := histogram.Write(&after); err != nil {
+		t.Fatalf("write metric: %v", err)
+	}
+
+	gotDelta := after.GetHistogram().GetSampleCount() - before.GetHistogram().GetSampleCount()
+	if gotDelta != 1 {
+		t.Fatalf("expected one new observation, got delta %d", gotDelta)
+	}
+}
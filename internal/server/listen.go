@@ -0,0 +1,20 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+)
+
+// BuildListenAddr joins host and port into a listen address suitable for
+// net.Listen, e.g. ("", 50051) -> ":50051" (all interfaces) or
+// ("127.0.0.1", 50051) -> "127.0.0.1:50051". It resolves the result to
+// catch a malformed host at startup rather than have net.Listen fail
+// with a less specific error later.
+func BuildListenAddr(host string, port int) (string, error) {
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	if _, err := net.ResolveTCPAddr("tcp", addr); err != nil {
+		return "", fmt.Errorf("server: invalid listen address %q: %w", addr, err)
+	}
+	return addr, nil
+}
@@ -0,0 +1,29 @@
+package server
+
+import "testing"
+
+func TestBuildListenAddr_EmptyHostBindsAllInterfaces(t *testing.T) {
+	addr, err := BuildListenAddr("", 50051)
+	if err != nil {
+		t.Fatalf("BuildListenAddr: %v", err)
+	}
+	if addr != ":50051" {
+		t.Fatalf("expected %q, got %q", ":50051", addr)
+	}
+}
+
+func TestBuildListenAddr_JoinsAnExplicitHostAndPort(t *testing.T) {
+	addr, err := BuildListenAddr("127.0.0.1", 50051)
+	if err != nil {
+		t.Fatalf("BuildListenAddr: %v", err)
+	}
+	if addr != "127.0.0.1:50051" {
+		t.Fatalf("expected %q, got %q", "127.0.0.1:50051", addr)
+	}
+}
+
+func TestBuildListenAddr_RejectsAnUnresolvableHost(t *testing.T) {
+	if _, err := BuildListenAddr("[not-a-valid-host", 50051); err == nil {
+		t.Fatalf("expected an error for a malformed host")
+	}
+}
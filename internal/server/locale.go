@@ -0,0 +1,31 @@
+package server
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/i18n"
+)
+
+// acceptLanguageMetadataKey is the incoming gRPC metadata key clients
+// set to request a localized error message, mirroring the HTTP
+// Accept-Language header clients are already used to sending.
+const acceptLanguageMetadataKey = "accept-language"
+
+// LocaleUnaryInterceptor reads the accept-language metadata header (if
+// any) off the incoming request and attaches the parsed i18n.Locale to
+// the context, so handlers can localize error messages via
+// i18n.LocaleFromContext without threading the header through every
+// call. Requests with no header, or an unparseable one, get
+// i18n.English.
+func LocaleUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	locale := i18n.English
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(acceptLanguageMetadataKey); len(values) > 0 {
+			locale = i18n.ParseAcceptLanguage(values[0])
+		}
+	}
+	return handler(i18n.ContextWithLocale(ctx, locale), req)
+}
@@ -0,0 +1,42 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/i18n"
+)
+
+func TestLocaleUnaryInterceptor_DefaultsToEnglishWithoutAHeader(t *testing.T) {
+	var seen i18n.Locale
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen = i18n.LocaleFromContext(ctx)
+		return nil, nil
+	}
+
+	if _, err := LocaleUnaryInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatalf("LocaleUnaryInterceptor: %v", err)
+	}
+	if seen != i18n.English {
+		t.Fatalf("expected the default locale to be English, got %q", seen)
+	}
+}
+
+func TestLocaleUnaryInterceptor_ReadsAcceptLanguageHeader(t *testing.T) {
+	var seen i18n.Locale
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen = i18n.LocaleFromContext(ctx)
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", "es-MX,es;q=0.9"))
+	if _, err := LocaleUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatalf("LocaleUnaryInterceptor: %v", err)
+	}
+	if seen != i18n.Locale("es") {
+		t.Fatalf("expected the locale parsed from the header, got %q", seen)
+	}
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+// MessageSizeOptions bounds the largest message the server will accept
+// or send, in bytes.
+type MessageSizeOptions struct {
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+}
+
+// MessageSizeServerOptions returns the grpc.ServerOptions enforcing
+// opts' message size limits.
+func MessageSizeServerOptions(opts MessageSizeOptions) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(opts.MaxRecvMsgSize),
+		grpc.MaxSendMsgSize(opts.MaxSendMsgSize),
+	}
+}
+
+// KeepaliveOptions configures how often the server pings an idle
+// connection and how long it waits for the response.
+type KeepaliveOptions struct {
+	Time    time.Duration
+	Timeout time.Duration
+}
+
+// KeepaliveServerOption returns the grpc.ServerOption applying opts as
+// the server's keepalive ping parameters.
+func KeepaliveServerOption(opts KeepaliveOptions) grpc.ServerOption {
+	return grpc.KeepaliveParams(keepalive.ServerParameters{
+		Time:    opts.Time,
+		Timeout: opts.Timeout,
+	})
+}
@@ -0,0 +1,84 @@
+package server
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestMessageSizeServerOptions_RejectsRequestsOverTheConfiguredLimit(t *testing.T) {
+	const bufSize = 1024 * 1024
+
+	lis := bufconn.Listen(bufSize)
+	grpcServer := grpc.NewServer(MessageSizeServerOptions(MessageSizeOptions{
+		MaxRecvMsgSize: 1024,
+		MaxSendMsgSize: bufSize,
+	})...)
+	grpc_health_v1.RegisterHealthServer(grpcServer, &noopHealthServer{})
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+	defer conn.Close()
+
+	client := grpc_health_v1.NewHealthClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A tiny request comfortably under the limit is accepted.
+	if _, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: "ok"}); err != nil {
+		t.Fatalf("expected a small request under the limit to succeed, got %v", err)
+	}
+
+	// A request padded past MaxRecvMsgSize is rejected by the server,
+	// proving the configured limit was actually applied.
+	oversized := &grpc_health_v1.HealthCheckRequest{Service: strings.Repeat("x", 2048)}
+	_, err = client.Check(ctx, oversized)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted for an oversized request, got %v", err)
+	}
+}
+
+func TestKeepaliveServerOption_AppliesWithoutBreakingNormalCalls(t *testing.T) {
+	const bufSize = 1024 * 1024
+
+	lis := bufconn.Listen(bufSize)
+	grpcServer := grpc.NewServer(KeepaliveServerOption(KeepaliveOptions{
+		Time:    30 * time.Second,
+		Timeout: 10 * time.Second,
+	}))
+	grpc_health_v1.RegisterHealthServer(grpcServer, &noopHealthServer{})
+	go grpcServer.Serve(lis)
+	defer grpcServer.Stop()
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+	defer conn.Close()
+
+	client := grpc_health_v1.NewHealthClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{}); err != nil {
+		t.Fatalf("expected keepalive params to not interfere with a normal call, got %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+)
+
+// namedShutdownFunc pairs a shutdown step with the name it's reported
+// under, so GracefulShutdown can label logs and metrics per step.
+type namedShutdownFunc struct {
+	name string
+	fn   func() error
+}
+
+// GracefulShutdown runs a sequence of named shutdown steps in
+// registration order, recording metrics.ShutdownDurationSeconds for the
+// whole sequence and incrementing metrics.ShutdownErrorsTotal, labeled
+// by step name, for each step that returns an error.
+type GracefulShutdown struct {
+	log   *zap.Logger
+	funcs []namedShutdownFunc
+}
+
+// NewGracefulShutdown returns a GracefulShutdown that logs failed steps
+// to log.
+func NewGracefulShutdown(log *zap.Logger) *GracefulShutdown {
+	return &GracefulShutdown{log: log}
+}
+
+// AddShutdownFunc registers fn to run when Shutdown is called, labeled
+// name for logging and the shutdownErrorsTotal metric.
+func (g *GracefulShutdown) AddShutdownFunc(name string, fn func() error) {
+	g.funcs = append(g.funcs, namedShutdownFunc{name: name, fn: fn})
+}
+
+// Shutdown runs every registered func in the order it was added. A
+// failing step is logged and counted in shutdownErrorsTotal but doesn't
+// stop the remaining steps from running, since most shutdown steps
+// (closing a connection, flushing a buffer) are independent of each
+// other.
+func (g *GracefulShutdown) Shutdown() {
+	start := time.Now()
+	defer func() {
+		metrics.ShutdownDurationSeconds.Set(time.Since(start).Seconds())
+	}()
+	for _, f := range g.funcs {
+		if err := f.fn(); err != nil {
+			metrics.ShutdownErrorsTotal.WithLabelValues(f.name).Inc()
+			if g.log != nil {
+				g.log.Warn("shutdown func failed", zap.String("name", f.name), zap.Error(err))
+			}
+		}
+	}
+}
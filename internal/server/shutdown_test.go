@@ -0,0 +1,35 @@
+package server
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+)
+
+func TestGracefulShutdown_RecordsErrorAndDuration(t *testing.T) {
+	before := testutil.ToFloat64(metrics.ShutdownErrorsTotal.WithLabelValues("flush-cache"))
+
+	g := NewGracefulShutdown(zap.NewNop())
+	ranOK := false
+	g.AddShutdownFunc("flush-cache", func() error { return errors.New("redis unreachable") })
+	g.AddShutdownFunc("close-db", func() error {
+		ranOK = true
+		return nil
+	})
+	g.Shutdown()
+
+	after := testutil.ToFloat64(metrics.ShutdownErrorsTotal.WithLabelValues("flush-cache"))
+	if after != before+1 {
+		t.Fatalf("expected shutdownErrorsTotal[flush-cache] to increment by 1, got delta %v", after-before)
+	}
+	if !ranOK {
+		t.Fatalf("expected the second shutdown func to still run after the first one errored")
+	}
+	if testutil.ToFloat64(metrics.ShutdownDurationSeconds) < 0 {
+		t.Fatalf("expected shutdownDurationSeconds to be recorded")
+	}
+}
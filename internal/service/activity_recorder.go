@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+)
+
+// defaultActivityQueueSize and defaultActivityWorkers are the
+// ActivityRecorder settings used when NewActivityRecorder is given
+// values <= 0.
+const (
+	defaultActivityQueueSize = 1024
+	defaultActivityWorkers   = 4
+)
+
+// ActivityRecorder persists UpdateLastActive calls off the request path.
+// Record enqueues a user id and returns immediately; a small pool of
+// background workers drains the queue against Postgres, so a caller
+// like UpdateUserLocation never blocks on this write. A full queue drops
+// the update rather than blocking the caller, on the assumption that
+// the same user will ping again soon and "last active" doesn't need to
+// be exact.
+type ActivityRecorder struct {
+	users repository.UserRepository
+	log   *zap.Logger
+
+	queue chan uint
+	wg    sync.WaitGroup
+}
+
+// NewActivityRecorder constructs an ActivityRecorder with a queue of
+// queueSize pending updates, drained by workers background goroutines.
+// queueSize/workers <= 0 fall back to defaultActivityQueueSize/
+// defaultActivityWorkers. The workers start draining the queue right
+// away; callers must call Close to flush it and stop them.
+func NewActivityRecorder(users repository.UserRepository, log *zap.Logger, queueSize, workers int) *ActivityRecorder {
+	if queueSize <= 0 {
+		queueSize = defaultActivityQueueSize
+	}
+	if workers <= 0 {
+		workers = defaultActivityWorkers
+	}
+	r := &ActivityRecorder{
+		users: users,
+		log:   log,
+		queue: make(chan uint, queueSize),
+	}
+	r.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go r.worker()
+	}
+	return r
+}
+
+func (r *ActivityRecorder) worker() {
+	defer r.wg.Done()
+	for id := range r.queue {
+		if err := r.users.UpdateLastActive(context.Background(), id); err != nil {
+			r.log.Warn("failed to record user activity", zap.Uint("user_id", id), zap.Error(err))
+		}
+	}
+}
+
+// Record enqueues a last-active update for userID. It never blocks: if
+// every worker is busy and the queue is full, the update is dropped and
+// logged rather than slowing down the caller.
+func (r *ActivityRecorder) Record(userID uint) {
+	select {
+	case r.queue <- userID:
+	default:
+		r.log.Warn("activity queue full, dropping last-active update", zap.Uint("user_id", userID))
+	}
+}
+
+// Close stops accepting new work and blocks until every already-queued
+// update has been applied, so a graceful shutdown never silently drops
+// activity that was already accepted.
+func (r *ActivityRecorder) Close() {
+	close(r.queue)
+	r.wg.Wait()
+}
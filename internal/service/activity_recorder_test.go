@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+// slowActivityRepository stands in for Postgres with an artificial delay
+// on UpdateLastActive, so tests can tell whether a caller waited for the
+// write or not.
+type slowActivityRepository struct {
+	countingUserRepository
+	delay time.Duration
+	calls uint32
+}
+
+func (r *slowActivityRepository) UpdateLastActive(ctx context.Context, id uint) error {
+	time.Sleep(r.delay)
+	atomic.AddUint32(&r.calls, 1)
+	return r.countingUserRepository.UpdateLastActive(ctx, id)
+}
+
+func TestActivityRecorder_RecordReturnsBeforeTheWriteCompletes(t *testing.T) {
+	repo := &slowActivityRepository{delay: 50 * time.Millisecond}
+	recorder := NewActivityRecorder(repo, zap.NewNop(), 0, 1)
+	t.Cleanup(recorder.Close)
+
+	start := time.Now()
+	recorder.Record(1)
+	if elapsed := time.Since(start); elapsed >= repo.delay {
+		t.Fatalf("expected Record to return immediately, took %v", elapsed)
+	}
+
+	deadline := time.After(time.Second)
+	for atomic.LoadUint32(&repo.calls) == 0 {
+		select {
+		case <-deadline:
+			t.Fatalf("expected the queued update to eventually land")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestActivityRecorder_CloseWaitsForQueuedWorkToFinish(t *testing.T) {
+	repo := &slowActivityRepository{delay: 10 * time.Millisecond}
+	recorder := NewActivityRecorder(repo, zap.NewNop(), 0, 2)
+
+	for i := uint(0); i < 5; i++ {
+		recorder.Record(i)
+	}
+	recorder.Close()
+
+	if got := atomic.LoadUint32(&repo.calls); got != 5 {
+		t.Fatalf("expected all 5 queued updates to land before Close returns, got %d", got)
+	}
+}
+
+func TestUpdateUserLocation_RecordsActivityAsynchronously(t *testing.T) {
+	svc, _ := newLocationTestService(t)
+	repo := &slowActivityRepository{delay: 50 * time.Millisecond}
+	svc.users = repo
+	svc.activity = NewActivityRecorder(repo, zap.NewNop(), 0, 1)
+	t.Cleanup(svc.activity.Close)
+
+	start := time.Now()
+	if _, err := svc.UpdateUserLocation(context.Background(), &userpb.UpdateUserLocationRequest{
+		UserId: 1, Latitude: 55.75, Longitude: 37.62, Country: "Russia",
+	}); err != nil {
+		t.Fatalf("UpdateUserLocation: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed >= repo.delay {
+		t.Fatalf("expected UpdateUserLocation to return before the last-active write completes, took %v", elapsed)
+	}
+}
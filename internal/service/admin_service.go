@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/adminpb"
+)
+
+// reconcileAllBatchSize is the default page size ReconcileAll walks the
+// users table with.
+const reconcileAllBatchSize = 500
+
+// AdminService implements adminpb.AdminServiceServer: operator-only
+// maintenance operations that should not be reachable from end-user
+// clients.
+type AdminService struct {
+	adminpb.UnimplementedAdminServiceServer
+
+	users       repository.UserRepository
+	prefs       repository.PreferenceRepository
+	locations   repository.LocationRepository
+	cache       *repository.ResilientCacheRepository
+	log         *zap.Logger
+	maintenance *MaintenanceMode
+}
+
+// NewAdminService wires the admin RPC handler to its repositories.
+// maintenance is the flag SetMaintenanceMode toggles; it should be the
+// same instance given to NewUserService so the toggle actually gates
+// end-user writes. A nil maintenance behaves as if always disabled,
+// aside from SetMaintenanceMode itself becoming a no-op.
+func NewAdminService(users repository.UserRepository, prefs repository.PreferenceRepository, locations repository.LocationRepository, cache *repository.ResilientCacheRepository, log *zap.Logger, maintenance *MaintenanceMode) *AdminService {
+	if maintenance == nil {
+		maintenance = NewMaintenanceMode()
+	}
+	return &AdminService{users: users, prefs: prefs, locations: locations, cache: cache, log: log, maintenance: maintenance}
+}
+
+// SetMaintenanceMode toggles read-only maintenance mode for the shared
+// MaintenanceMode this service and its UserService were constructed
+// with.
+func (s *AdminService) SetMaintenanceMode(_ context.Context, req *adminpb.SetMaintenanceModeRequest) (*adminpb.SetMaintenanceModeResponse, error) {
+	s.maintenance.SetEnabled(req.Enabled)
+	return &adminpb.SetMaintenanceModeResponse{Enabled: req.Enabled}, nil
+}
+
+// PurgeUserData permanently deletes a user's account, preferences,
+// saved locations, and rating history, and evicts any cached copies,
+// for GDPR Art. 17 erasure requests. It is irreversible.
+func (s *AdminService) PurgeUserData(ctx context.Context, req *adminpb.PurgeUserDataRequest) (*adminpb.PurgeUserDataResponse, error) {
+	userID := uint(req.UserId)
+	prefs, err := s.prefs.ListPreferences(ctx, userID)
+	if err != nil {
+		return nil, mapServiceError(err, "list preferences")
+	}
+	if err := s.prefs.DeleteAllForUser(ctx, userID); err != nil {
+		return nil, mapServiceError(err, "purge preferences")
+	}
+	for _, p := range prefs {
+		if err := s.cache.IncrementTagPopularity(ctx, p.Tag, -1); err != nil {
+			s.log.Warn("failed to update tag popularity", zap.Error(err))
+		}
+	}
+	if err := s.locations.DeleteAllForUser(ctx, userID); err != nil {
+		return nil, mapServiceError(err, "purge locations")
+	}
+	if err := s.users.DeleteRatingHistory(ctx, userID); err != nil {
+		return nil, mapServiceError(err, "purge rating history")
+	}
+	if err := s.users.DeleteUser(ctx, userID); err != nil {
+		return nil, mapServiceError(err, "purge user")
+	}
+	if err := s.cache.ClearUserCache(ctx, userID); err != nil {
+		s.log.Warn("failed to evict user cache after purge", zap.Error(err))
+	}
+	return &adminpb.PurgeUserDataResponse{Purged: true}, nil
+}
+
+// ReconcileUser re-reads userID from Postgres and overwrites the cache
+// entry with it, discarding any cache/DB drift.
+func (s *AdminService) ReconcileUser(ctx context.Context, req *adminpb.ReconcileUserRequest) (*adminpb.ReconcileUserResponse, error) {
+	if err := s.reconcileUser(ctx, uint(req.UserId)); err != nil {
+		return nil, mapServiceError(err, "reconcile user")
+	}
+	return &adminpb.ReconcileUserResponse{Reconciled: true}, nil
+}
+
+// SetUserBanned bans or unbans a user and evicts any cached copy so the
+// change is visible immediately.
+func (s *AdminService) SetUserBanned(ctx context.Context, req *adminpb.SetUserBannedRequest) (*adminpb.SetUserBannedResponse, error) {
+	if err := s.users.SetBanned(ctx, uint(req.UserId), req.Banned); err != nil {
+		return nil, mapServiceError(err, "set banned")
+	}
+	if err := s.cache.ClearUserCache(ctx, uint(req.UserId)); err != nil {
+		s.log.Warn("failed to evict user cache after ban change", zap.Error(err))
+	}
+	if !req.Banned {
+		if err := s.cache.ClearUserNotFound(ctx, uint(req.UserId)); err != nil {
+			s.log.Warn("failed to clear user-not-found tombstone after unban", zap.Error(err))
+		}
+	}
+	return &adminpb.SetUserBannedResponse{Banned: req.Banned}, nil
+}
+
+// RecomputeUserRating rebuilds userID's Rating/RatingSum/RatingCount from
+// its source-of-truth rating events, repairing any drift left behind by a
+// buggy or partially-failed rating write.
+func (s *AdminService) RecomputeUserRating(ctx context.Context, req *adminpb.RecomputeUserRatingRequest) (*adminpb.RecomputeUserRatingResponse, error) {
+	userID := uint(req.UserId)
+	if err := s.users.RecomputeUserRating(ctx, userID); err != nil {
+		return nil, mapServiceError(err, "recompute user rating")
+	}
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, mapServiceError(err, "get user")
+	}
+	if err := s.cache.SetUser(ctx, user); err != nil {
+		s.log.Warn("failed to refresh user cache after rating recompute", zap.Error(err))
+	}
+	return &adminpb.RecomputeUserRatingResponse{Rating: user.Rating, RatingCount: user.RatingCount}, nil
+}
+
+// DeletePreferencesByTag removes every preference row for req.Tag, e.g.
+// when a tag is retired upstream and its preferences become orphaned,
+// and bumps each affected user's preferences cache generation so a
+// stale copy isn't served afterward. Bumping rather than deleting each
+// user's cache key keeps this cheap even when the tag is widely held.
+func (s *AdminService) DeletePreferencesByTag(ctx context.Context, req *adminpb.DeletePreferencesByTagRequest) (*adminpb.DeletePreferencesByTagResponse, error) {
+	userIDs, err := s.prefs.ListUserIDsForTag(ctx, req.Tag)
+	if err != nil {
+		return nil, mapServiceError(err, "list users for tag")
+	}
+	deleted, err := s.prefs.DeletePreferencesByTag(ctx, req.Tag)
+	if err != nil {
+		return nil, mapServiceError(err, "delete preferences by tag")
+	}
+	for _, userID := range userIDs {
+		if err := s.cache.BumpPreferencesGeneration(ctx, userID); err != nil {
+			s.log.Warn("failed to bump preferences generation after tag deletion", zap.Uint("user_id", userID), zap.Error(err))
+		}
+	}
+	return &adminpb.DeletePreferencesByTagResponse{DeletedCount: deleted}, nil
+}
+
+// ChangeTelegramID moves a user onto a new Telegram account id, e.g. when a
+// user's Telegram account id changes upstream and their existing account
+// needs to follow it. It fails with AlreadyExists if newTelegramID already
+// belongs to a different user. There is no telegram-indexed cache in this
+// service to invalidate, so it evicts the affected user's cached record
+// instead, since that entry embeds the stale telegram id.
+func (s *AdminService) ChangeTelegramID(ctx context.Context, req *adminpb.ChangeTelegramIDRequest) (*adminpb.ChangeTelegramIDResponse, error) {
+	userID := uint(req.UserId)
+	if err := s.users.ChangeTelegramID(ctx, userID, req.NewTelegramId); err != nil {
+		if errors.Is(err, repository.ErrTelegramIDTaken) {
+			return nil, status.Errorf(codes.AlreadyExists, "telegram id %d is already in use", req.NewTelegramId)
+		}
+		return nil, mapServiceError(err, "change telegram id")
+	}
+	if err := s.cache.ClearUserCache(ctx, userID); err != nil {
+		s.log.Warn("failed to evict user cache after telegram id change", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	return &adminpb.ChangeTelegramIDResponse{Changed: true}, nil
+}
+
+// GetServiceStats returns service-wide summary figures. UserCount is
+// served from the cache StatsRefresher keeps warm in the background, so
+// a burst of calls to this RPC never triggers a full table count of its
+// own. If the cache hasn't been populated yet (e.g. StatsRefresher
+// hasn't ticked once since startup), it falls back to counting directly
+// and seeding the cache with the result.
+func (s *AdminService) GetServiceStats(ctx context.Context, _ *adminpb.GetServiceStatsRequest) (*adminpb.GetServiceStatsResponse, error) {
+	count, found, err := s.cache.GetServiceUserCount(ctx)
+	if err != nil {
+		s.log.Warn("failed to read cached user count", zap.Error(err))
+	}
+	if !found {
+		count, err = s.users.CountUsers(ctx)
+		if err != nil {
+			return nil, mapServiceError(err, "count users")
+		}
+		if err := s.cache.SetServiceUserCount(ctx, count); err != nil {
+			s.log.Warn("failed to seed cached user count", zap.Error(err))
+		}
+	}
+	return &adminpb.GetServiceStatsResponse{UserCount: count}, nil
+}
+
+func (s *AdminService) reconcileUser(ctx context.Context, userID uint) error {
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return s.cache.SetUser(ctx, user)
+}
+
+// ReconcileAll walks every user in Postgres in pages of batchSize,
+// overwriting each one's cache entry authoritatively. It is meant to be
+// run after an outage or manual DB surgery has left the cache stale.
+func (s *AdminService) ReconcileAll(ctx context.Context, batchSize int) error {
+	if batchSize <= 0 {
+		batchSize = reconcileAllBatchSize
+	}
+	var afterID uint
+	for {
+		users, err := s.users.ListUsers(ctx, afterID, batchSize, false)
+		if err != nil {
+			return err
+		}
+		if len(users) == 0 {
+			return nil
+		}
+		for i := range users {
+			if err := s.cache.SetUser(ctx, &users[i]); err != nil {
+				s.log.Warn("reconcile: failed to refresh cache entry", zap.Uint("user_id", users[i].ID), zap.Error(err))
+			}
+			afterID = users[i].ID
+		}
+	}
+}
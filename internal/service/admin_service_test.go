@@ -0,0 +1,605 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/adminpb"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+// staticUserRepository serves ListUsers/GetUserByID from an in-memory
+// slice, standing in for Postgres in tests.
+type staticUserRepository struct {
+	users        []models.User
+	ratingEvents map[uint][]models.UserRatingEvent
+}
+
+func (r *staticUserRepository) CreateUser(context.Context, *models.User) error { return nil }
+
+func (r *staticUserRepository) CreateUserWithOnboarding(context.Context, *models.User, []string, *models.UserLocation) error {
+	return nil
+}
+
+func (r *staticUserRepository) GetUserByID(_ context.Context, id uint) (*models.User, error) {
+	for i := range r.users {
+		if r.users[i].ID == id {
+			return &r.users[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *staticUserRepository) GetActiveUserByID(ctx context.Context, id uint) (*models.User, error) {
+	return r.GetUserByID(ctx, id)
+}
+
+func (r *staticUserRepository) GetUsersByTelegramIDs(_ context.Context, telegramIDs []int64) (map[int64]*models.User, error) {
+	wanted := make(map[int64]bool, len(telegramIDs))
+	for _, id := range telegramIDs {
+		wanted[id] = true
+	}
+	byTelegramID := make(map[int64]*models.User, len(telegramIDs))
+	for i := range r.users {
+		if wanted[r.users[i].TelegramID] {
+			byTelegramID[r.users[i].TelegramID] = &r.users[i]
+		}
+	}
+	return byTelegramID, nil
+}
+
+func (r *staticUserRepository) SetBanned(_ context.Context, id uint, banned bool) error {
+	for i := range r.users {
+		if r.users[i].ID == id {
+			r.users[i].Banned = banned
+		}
+	}
+	return nil
+}
+
+func (r *staticUserRepository) ChangeTelegramID(_ context.Context, id uint, newTelegramID int64) error {
+	for i := range r.users {
+		if r.users[i].ID != id && r.users[i].TelegramID == newTelegramID {
+			return repository.ErrTelegramIDTaken
+		}
+	}
+	for i := range r.users {
+		if r.users[i].ID == id {
+			r.users[i].TelegramID = newTelegramID
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (r *staticUserRepository) UpdateUsername(_ context.Context, id uint, username string) error {
+	for i := range r.users {
+		if r.users[i].ID == id {
+			r.users[i].Username = username
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (r *staticUserRepository) UpdateLastActive(_ context.Context, id uint) error {
+	return nil
+}
+
+func (r *staticUserRepository) CountUsers(context.Context) (int64, error) {
+	return int64(len(r.users)), nil
+}
+
+func (r *staticUserRepository) ListUsers(_ context.Context, afterID uint, limit int, excludeBots bool) ([]models.User, error) {
+	var out []models.User
+	for _, u := range r.users {
+		if u.ID > afterID && !(excludeBots && u.IsBot) {
+			out = append(out, u)
+			if len(out) == limit {
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func TestListUsers_ExcludeBotsFiltersBotAccounts(t *testing.T) {
+	users := &staticUserRepository{users: []models.User{
+		{ID: 1, Username: "captain"},
+		{ID: 2, Username: "auto-reply", IsBot: true},
+		{ID: 3, Username: "quartermaster"},
+	}}
+
+	got, err := users.ListUsers(context.Background(), 0, 10, true)
+	if err != nil {
+		t.Fatalf("ListUsers: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected bots excluded, got %d users: %+v", len(got), got)
+	}
+	for _, u := range got {
+		if u.IsBot {
+			t.Fatalf("expected no bot accounts in result, got %+v", u)
+		}
+	}
+}
+
+func TestReconcileUser_RestoresStaleCacheFromDB(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	users := &staticUserRepository{users: []models.User{{ID: 1, Username: "true-name"}}}
+	prefs := &fakePreferenceRepository{byUser: map[uint][]string{}}
+	admin := NewAdminService(users, prefs, &fakeLocationRepository{}, cache, zap.NewNop(), nil)
+
+	ctx := context.Background()
+	if err := cache.SetUser(ctx, &models.User{ID: 1, Username: "stale-name"}); err != nil {
+		t.Fatalf("seed stale cache: %v", err)
+	}
+
+	if _, err := admin.ReconcileUser(ctx, &adminpb.ReconcileUserRequest{UserId: 1}); err != nil {
+		t.Fatalf("ReconcileUser: %v", err)
+	}
+
+	cached, err := cache.GetUser(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if cached.Username != "true-name" {
+		t.Fatalf("expected cache to be restored to %q, got %q", "true-name", cached.Username)
+	}
+}
+
+func (r *staticUserRepository) DeleteUser(_ context.Context, id uint) error {
+	for i := range r.users {
+		if r.users[i].ID == id {
+			r.users = append(r.users[:i], r.users[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (r *staticUserRepository) UpdateUserRating(_ context.Context, id uint, score float64, raterID uint) error {
+	if r.ratingEvents == nil {
+		r.ratingEvents = map[uint][]models.UserRatingEvent{}
+	}
+	r.ratingEvents[id] = append(r.ratingEvents[id], models.UserRatingEvent{
+		ID: uint(len(r.ratingEvents[id])) + 1, UserID: id, RaterID: raterID, Score: score,
+	})
+	for i := range r.users {
+		if r.users[i].ID == id {
+			r.users[i].RatingSum += score
+			r.users[i].RatingCount++
+			r.users[i].Rating = r.users[i].RatingSum / float64(r.users[i].RatingCount)
+		}
+	}
+	return nil
+}
+
+// RecomputeUserRating rebuilds the aggregate from ratingEvents, standing
+// in for a real recomputation against the user_rating_events table.
+func (r *staticUserRepository) RecomputeUserRating(_ context.Context, id uint) error {
+	var sum float64
+	events := r.ratingEvents[id]
+	for _, e := range events {
+		sum += e.Score
+	}
+	for i := range r.users {
+		if r.users[i].ID == id {
+			r.users[i].RatingSum = sum
+			r.users[i].RatingCount = int64(len(events))
+			if len(events) > 0 {
+				r.users[i].Rating = sum / float64(len(events))
+			} else {
+				r.users[i].Rating = 0
+			}
+		}
+	}
+	return nil
+}
+
+// GetRatingHistory returns id's rating events newest-first (insertion
+// order reversed, since ratingEvents doesn't track timestamps).
+func (r *staticUserRepository) GetRatingHistory(_ context.Context, id uint, limit int) ([]models.UserRatingEvent, error) {
+	events := r.ratingEvents[id]
+	out := make([]models.UserRatingEvent, len(events))
+	for i, e := range events {
+		out[len(events)-1-i] = e
+	}
+	if limit > 0 && limit < len(out) {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (r *staticUserRepository) DeleteRatingHistory(_ context.Context, id uint) error {
+	delete(r.ratingEvents, id)
+	return nil
+}
+
+func (r *staticUserRepository) UserExists(_ context.Context, id uint) (bool, error) {
+	for _, u := range r.users {
+		if u.ID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *staticUserRepository) UserExistsByTelegramID(_ context.Context, telegramID int64) (bool, error) {
+	for _, u := range r.users {
+		if u.TelegramID == telegramID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *staticUserRepository) GetUserFeatures(_ context.Context, id uint) (models.FeatureFlags, error) {
+	return models.FeatureFlags{}, nil
+}
+
+func (r *staticUserRepository) SetUserFeature(_ context.Context, id uint, key string, value bool) error {
+	return nil
+}
+
+func TestPurgeUserData_RemovesUserAndPreferences(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	users := &staticUserRepository{
+		users:        []models.User{{ID: 1, Username: "captain"}},
+		ratingEvents: map[uint][]models.UserRatingEvent{1: {{ID: 1, UserID: 1, RaterID: 2, Score: 5}}},
+	}
+	prefs := &fakePreferenceRepository{byUser: map[uint][]string{1: {"rum"}}}
+	locations := &fakeLocationRepository{byUser: map[uint]models.UserLocation{1: {UserID: 1, Label: models.CurrentLocationLabel}}}
+	admin := NewAdminService(users, prefs, locations, cache, zap.NewNop(), nil)
+	ctx := context.Background()
+
+	if _, err := admin.PurgeUserData(ctx, &adminpb.PurgeUserDataRequest{UserId: 1}); err != nil {
+		t.Fatalf("PurgeUserData: %v", err)
+	}
+	if _, err := users.GetUserByID(ctx, 1); err == nil {
+		t.Fatalf("expected user to be deleted")
+	}
+	if tags := prefs.byUser[1]; tags != nil {
+		t.Fatalf("expected preferences to be deleted, got %v", tags)
+	}
+	if locs, err := locations.GetUserLocations(ctx, 1); err != nil || len(locs) != 0 {
+		t.Fatalf("expected locations to be deleted, got %v (err %v)", locs, err)
+	}
+	if events, err := users.GetRatingHistory(ctx, 1, 0); err != nil || len(events) != 0 {
+		t.Fatalf("expected rating history to be deleted, got %v (err %v)", events, err)
+	}
+}
+
+func TestPurgeUserData_DecrementsTagPopularity(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	users := &staticUserRepository{users: []models.User{
+		{ID: 1, Username: "captain"},
+		{ID: 2, Username: "quartermaster"},
+	}}
+	prefs := &fakePreferenceRepository{byUser: map[uint][]string{1: {"rum"}, 2: {"rum"}}}
+	admin := NewAdminService(users, prefs, &fakeLocationRepository{}, cache, zap.NewNop(), nil)
+	ctx := context.Background()
+
+	if err := cache.IncrementTagPopularity(ctx, "rum", 2); err != nil {
+		t.Fatalf("seed tag popularity: %v", err)
+	}
+
+	if _, err := admin.PurgeUserData(ctx, &adminpb.PurgeUserDataRequest{UserId: 1}); err != nil {
+		t.Fatalf("PurgeUserData: %v", err)
+	}
+
+	counts, err := cache.GetPopularTags(ctx, 10)
+	if err != nil {
+		t.Fatalf("GetPopularTags: %v", err)
+	}
+	if len(counts) != 1 || counts[0].Tag != "rum" || counts[0].Count != 1 {
+		t.Fatalf("expected rum's count to drop to 1 after purging one of its two users, got %+v", counts)
+	}
+}
+
+func TestDeletePreferencesByTag_DeletesOnlyTheGivenTag(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	users := &staticUserRepository{users: []models.User{
+		{ID: 1, Username: "captain"},
+		{ID: 2, Username: "quartermaster"},
+	}}
+	prefs := &fakePreferenceRepository{byUser: map[uint][]string{
+		1: {"rum", "parrots"},
+		2: {"rum"},
+	}}
+	admin := NewAdminService(users, prefs, &fakeLocationRepository{}, cache, zap.NewNop(), nil)
+	ctx := context.Background()
+
+	resp, err := admin.DeletePreferencesByTag(ctx, &adminpb.DeletePreferencesByTagRequest{Tag: "rum"})
+	if err != nil {
+		t.Fatalf("DeletePreferencesByTag: %v", err)
+	}
+	if resp.DeletedCount != 2 {
+		t.Fatalf("expected 2 rows deleted, got %d", resp.DeletedCount)
+	}
+	if prefs.hasPreference(1, "rum") || prefs.hasPreference(2, "rum") {
+		t.Fatalf("expected rum removed from both users, got %+v", prefs.byUser)
+	}
+	if !prefs.hasPreference(1, "parrots") {
+		t.Fatalf("expected an unrelated tag to survive the deletion, got %+v", prefs.byUser)
+	}
+}
+
+func TestDeletePreferencesByTag_BumpsAffectedUsersPreferencesGeneration(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	users := &staticUserRepository{users: []models.User{{ID: 1, Username: "captain"}}}
+	prefs := &fakePreferenceRepository{byUser: map[uint][]string{1: {"rum"}}}
+	admin := NewAdminService(users, prefs, &fakeLocationRepository{}, cache, zap.NewNop(), nil)
+	ctx := context.Background()
+
+	if err := cache.SetPreferenceTags(ctx, 1, []string{"rum"}); err != nil {
+		t.Fatalf("seed preferences cache: %v", err)
+	}
+
+	if _, err := admin.DeletePreferencesByTag(ctx, &adminpb.DeletePreferencesByTagRequest{Tag: "rum"}); err != nil {
+		t.Fatalf("DeletePreferencesByTag: %v", err)
+	}
+
+	if _, found, err := cache.GetPreferenceTags(ctx, 1); err != nil {
+		t.Fatalf("GetPreferenceTags: %v", err)
+	} else if found {
+		t.Fatalf("expected user 1's cached preferences to be unreachable after the tag was deleted")
+	}
+}
+
+func TestChangeTelegramID_MovesUserOntoTheNewID(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	users := &staticUserRepository{users: []models.User{{ID: 1, Username: "captain", TelegramID: 100}}}
+	prefs := &fakePreferenceRepository{}
+	admin := NewAdminService(users, prefs, &fakeLocationRepository{}, cache, zap.NewNop(), nil)
+	ctx := context.Background()
+
+	resp, err := admin.ChangeTelegramID(ctx, &adminpb.ChangeTelegramIDRequest{UserId: 1, NewTelegramId: 200})
+	if err != nil {
+		t.Fatalf("ChangeTelegramID: %v", err)
+	}
+	if !resp.Changed {
+		t.Fatalf("expected Changed to be true")
+	}
+	user, err := users.GetUserByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if user.TelegramID != 200 {
+		t.Fatalf("expected telegram id 200, got %d", user.TelegramID)
+	}
+}
+
+func TestChangeTelegramID_RejectsAnAlreadyTakenID(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	users := &staticUserRepository{users: []models.User{
+		{ID: 1, Username: "captain", TelegramID: 100},
+		{ID: 2, Username: "quartermaster", TelegramID: 200},
+	}}
+	prefs := &fakePreferenceRepository{}
+	admin := NewAdminService(users, prefs, &fakeLocationRepository{}, cache, zap.NewNop(), nil)
+	ctx := context.Background()
+
+	_, err = admin.ChangeTelegramID(ctx, &adminpb.ChangeTelegramIDRequest{UserId: 1, NewTelegramId: 200})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists, got %v", err)
+	}
+	user, err := users.GetUserByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if user.TelegramID != 100 {
+		t.Fatalf("expected telegram id to be left unchanged after a rejected collision, got %d", user.TelegramID)
+	}
+}
+
+func TestChangeTelegramID_EvictsTheStaleCachedUser(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	users := &staticUserRepository{users: []models.User{{ID: 1, Username: "captain", TelegramID: 100}}}
+	prefs := &fakePreferenceRepository{}
+	admin := NewAdminService(users, prefs, &fakeLocationRepository{}, cache, zap.NewNop(), nil)
+	ctx := context.Background()
+
+	if err := cache.SetUser(ctx, &models.User{ID: 1, Username: "captain", TelegramID: 100}); err != nil {
+		t.Fatalf("seed user cache: %v", err)
+	}
+
+	if _, err := admin.ChangeTelegramID(ctx, &adminpb.ChangeTelegramIDRequest{UserId: 1, NewTelegramId: 200}); err != nil {
+		t.Fatalf("ChangeTelegramID: %v", err)
+	}
+
+	if user, err := cache.GetUser(ctx, 1); err != nil {
+		t.Fatalf("GetUser: %v", err)
+	} else if user != nil {
+		t.Fatalf("expected the stale cached user to be evicted, got %+v", user)
+	}
+}
+
+func TestRecomputeUserRating_FixesCorruptedAggregate(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	users := &staticUserRepository{users: []models.User{{ID: 1, Username: "captain"}}}
+	prefs := &fakePreferenceRepository{byUser: map[uint][]string{}}
+	admin := NewAdminService(users, prefs, &fakeLocationRepository{}, cache, zap.NewNop(), nil)
+	ctx := context.Background()
+
+	if err := users.UpdateUserRating(ctx, 1, 3, 7); err != nil {
+		t.Fatalf("UpdateUserRating: %v", err)
+	}
+	if err := users.UpdateUserRating(ctx, 1, 5, 8); err != nil {
+		t.Fatalf("UpdateUserRating: %v", err)
+	}
+
+	// Simulate a bug or partial failure desyncing the denormalized
+	// aggregate from the events that are supposed to back it.
+	users.users[0].RatingSum = 999
+	users.users[0].RatingCount = 40
+	users.users[0].Rating = 24.975
+
+	resp, err := admin.RecomputeUserRating(ctx, &adminpb.RecomputeUserRatingRequest{UserId: 1})
+	if err != nil {
+		t.Fatalf("RecomputeUserRating: %v", err)
+	}
+	if resp.RatingCount != 2 || resp.Rating != 4 {
+		t.Fatalf("expected recompute to restore rating=4 count=2 from events, got %+v", resp)
+	}
+
+	user, err := users.GetUserByID(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+	if user.RatingSum != 8 || user.RatingCount != 2 || user.Rating != 4 {
+		t.Fatalf("expected persisted aggregate to match events, got %+v", user)
+	}
+}
+
+func TestSetMaintenanceMode_GatesUserServiceWrites(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	maintenance := NewMaintenanceMode()
+	admin := NewAdminService(&staticUserRepository{}, &fakePreferenceRepository{byUser: map[uint][]string{}}, &fakeLocationRepository{}, cache, zap.NewNop(), maintenance)
+	users := &countingUserRepository{banned: map[uint]bool{}}
+	prefs := &fakePreferenceRepository{byUser: map[uint][]string{}}
+	userSvc := NewUserService(users, prefs, cache, zap.NewNop(), 0, GeoLimits{}, maintenance, nil, LocationDebounceConfig{}, FeatureFlagConfig{}, 0, false)
+
+	ctx := context.Background()
+	if _, err := admin.SetMaintenanceMode(ctx, &adminpb.SetMaintenanceModeRequest{Enabled: true}); err != nil {
+		t.Fatalf("SetMaintenanceMode: %v", err)
+	}
+	if _, err := userSvc.CreateUser(ctx, &userpb.CreateUserRequest{Username: "late-arrival"}); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected CreateUser to be rejected once admin enabled maintenance mode, got %v", err)
+	}
+
+	if _, err := admin.SetMaintenanceMode(ctx, &adminpb.SetMaintenanceModeRequest{Enabled: false}); err != nil {
+		t.Fatalf("SetMaintenanceMode: %v", err)
+	}
+	if _, err := userSvc.CreateUser(ctx, &userpb.CreateUserRequest{Username: "late-arrival", TelegramId: 99}); err != nil {
+		t.Fatalf("expected CreateUser to succeed once maintenance mode is disabled, got %v", err)
+	}
+}
+
+func TestGetServiceStats_ReadsTheWarmCacheWithoutCountingUsers(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	if err := cache.SetServiceUserCount(context.Background(), 42); err != nil {
+		t.Fatalf("seed cached user count: %v", err)
+	}
+
+	users := &countingUserRepository{banned: map[uint]bool{}, userCount: 999}
+	admin := NewAdminService(users, &fakePreferenceRepository{byUser: map[uint][]string{}}, &fakeLocationRepository{}, cache, zap.NewNop(), nil)
+
+	resp, err := admin.GetServiceStats(context.Background(), &adminpb.GetServiceStatsRequest{})
+	if err != nil {
+		t.Fatalf("GetServiceStats: %v", err)
+	}
+	if resp.UserCount != 42 {
+		t.Fatalf("expected the warm cached count of 42, got %d", resp.UserCount)
+	}
+	if calls := users.countCalls; calls != 0 {
+		t.Fatalf("expected a warm cache hit to never call CountUsers, got %d calls", calls)
+	}
+}
+
+func TestGetServiceStats_FallsBackToCountingUsersOnACacheMiss(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	users := &countingUserRepository{banned: map[uint]bool{}, userCount: 7}
+	admin := NewAdminService(users, &fakePreferenceRepository{byUser: map[uint][]string{}}, &fakeLocationRepository{}, cache, zap.NewNop(), nil)
+
+	resp, err := admin.GetServiceStats(context.Background(), &adminpb.GetServiceStatsRequest{})
+	if err != nil {
+		t.Fatalf("GetServiceStats: %v", err)
+	}
+	if resp.UserCount != 7 {
+		t.Fatalf("expected the freshly-counted value of 7 before the cache was ever warmed, got %d", resp.UserCount)
+	}
+}
@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/authpb"
+)
+
+// AuthService implements authpb.AuthServiceServer. It does not issue
+// tokens itself; it introspects session tokens that the gateway wrote to
+// the shared Redis instance, so downstream services can validate a
+// caller's identity without each holding its own copy of the session
+// store's layout.
+type AuthService struct {
+	authpb.UnimplementedAuthServiceServer
+
+	cache *repository.CacheRepository
+	log   *zap.Logger
+}
+
+// NewAuthService wires the token-introspection RPC to the shared cache.
+func NewAuthService(cache *repository.CacheRepository, log *zap.Logger) *AuthService {
+	return &AuthService{cache: cache, log: log}
+}
+
+// ValidateToken reports whether token is a live session and, if so, the
+// user it belongs to. An unknown or expired token is not an error: it is
+// reported as Valid=false so callers can distinguish "not logged in"
+// from a genuine RPC failure.
+func (s *AuthService) ValidateToken(ctx context.Context, req *authpb.ValidateTokenRequest) (*authpb.ValidateTokenResponse, error) {
+	userID, ttl, found, err := s.cache.GetSessionUserID(ctx, req.Token)
+	if err != nil {
+		s.log.Warn("token introspection failed", zap.Error(err))
+		return &authpb.ValidateTokenResponse{Valid: false}, nil
+	}
+	if !found {
+		return &authpb.ValidateTokenResponse{Valid: false}, nil
+	}
+	return &authpb.ValidateTokenResponse{
+		Valid:         true,
+		UserId:        userID,
+		ExpiresAtUnix: time.Now().Add(ttl).Unix(),
+	}, nil
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/authpb"
+)
+
+func TestValidateToken(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewCacheRepository(client, "")
+	auth := NewAuthService(cache, zap.NewNop())
+	ctx := context.Background()
+
+	if err := client.Set(ctx, "session:tok-1", uint64(9), time.Minute).Err(); err != nil {
+		t.Fatalf("seed session: %v", err)
+	}
+
+	resp, err := auth.ValidateToken(ctx, &authpb.ValidateTokenRequest{Token: "tok-1"})
+	if err != nil || !resp.Valid || resp.UserId != 9 {
+		t.Fatalf("expected valid token for user 9, got resp=%+v err=%v", resp, err)
+	}
+
+	resp, err = auth.ValidateToken(ctx, &authpb.ValidateTokenRequest{Token: "unknown"})
+	if err != nil || resp.Valid {
+		t.Fatalf("expected unknown token to be invalid, got resp=%+v err=%v", resp, err)
+	}
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+func TestGetUser_BannedUserIsNotFound(t *testing.T) {
+	svc, repo := newTestService(t)
+	ctx := context.Background()
+
+	created, err := svc.CreateUser(ctx, &userpb.CreateUserRequest{TelegramId: 1, Username: "long-john"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	if err := repo.SetBanned(ctx, uint(created.Id), true); err != nil {
+		t.Fatalf("SetBanned: %v", err)
+	}
+
+	_, err = svc.GetUser(ctx, &userpb.GetUserRequest{UserId: created.Id})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for a banned user, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+)
+
+// defaultCacheSweepInterval and defaultCacheSweepInactivityThreshold are
+// the CacheSweeperConfig used when NewCacheSweeper is given a
+// zero-valued CacheSweeperConfig.
+const (
+	defaultCacheSweepInterval            = 10 * time.Minute
+	defaultCacheSweepInactivityThreshold = 30 * 24 * time.Hour
+	cacheSweepPageSize                   = 500
+)
+
+// CacheSweeperConfig controls how often, and how aggressively, the
+// CacheSweeper evicts cached entries for users who have gone inactive.
+type CacheSweeperConfig struct {
+	Interval            time.Duration
+	InactivityThreshold time.Duration
+}
+
+// withDefaults fills in defaultCacheSweepInterval/
+// defaultCacheSweepInactivityThreshold for any field left at its zero
+// value.
+func (c CacheSweeperConfig) withDefaults() CacheSweeperConfig {
+	if c.Interval <= 0 {
+		c.Interval = defaultCacheSweepInterval
+	}
+	if c.InactivityThreshold <= 0 {
+		c.InactivityThreshold = defaultCacheSweepInactivityThreshold
+	}
+	return c
+}
+
+// CacheSweeper periodically scans users for ones that have gone
+// inactive and evicts their cache entries, so Redis memory isn't held
+// forever by accounts nobody looks up anymore.
+type CacheSweeper struct {
+	users repository.UserRepository
+	cache *repository.ResilientCacheRepository
+	log   *zap.Logger
+	cfg   CacheSweeperConfig
+	now   func() time.Time
+}
+
+// NewCacheSweeper constructs a CacheSweeper. A zero-valued cfg falls
+// back to CacheSweeperConfig's own defaults.
+func NewCacheSweeper(users repository.UserRepository, cache *repository.ResilientCacheRepository, log *zap.Logger, cfg CacheSweeperConfig) *CacheSweeper {
+	return &CacheSweeper{users: users, cache: cache, log: log, cfg: cfg.withDefaults(), now: time.Now}
+}
+
+// Run sweeps every cfg.Interval until ctx is canceled, so it can be
+// started as a shutdown-aware background goroutine alongside the gRPC
+// server: canceling ctx (e.g. during graceful shutdown) stops it
+// cleanly instead of leaking the goroutine.
+func (s *CacheSweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.sweepOnce(ctx)
+		}
+	}
+}
+
+// sweepOnce pages through every user once, evicting the cache entry of
+// any user whose LastActiveAt is older than cfg.InactivityThreshold.
+func (s *CacheSweeper) sweepOnce(ctx context.Context) {
+	var afterID uint
+	evicted := 0
+	for {
+		users, err := s.users.ListUsers(ctx, afterID, cacheSweepPageSize, false)
+		if err != nil {
+			s.log.Warn("cache sweep: failed to list users", zap.Error(err))
+			return
+		}
+		if len(users) == 0 {
+			break
+		}
+		for _, u := range users {
+			if s.now().Sub(u.LastActiveAt) > s.cfg.InactivityThreshold {
+				if err := s.cache.ClearUserCache(ctx, u.ID); err != nil {
+					s.log.Warn("cache sweep: failed to evict inactive user", zap.Uint("user_id", u.ID), zap.Error(err))
+					continue
+				}
+				evicted++
+			}
+			afterID = u.ID
+		}
+		if len(users) < cacheSweepPageSize {
+			break
+		}
+	}
+	if evicted > 0 {
+		s.log.Info("cache sweep evicted inactive users", zap.Int("count", evicted))
+	}
+}
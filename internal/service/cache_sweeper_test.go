@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+)
+
+// sweepUserRepository serves a fixed page of users, standing in for
+// Postgres in CacheSweeper tests.
+type sweepUserRepository struct {
+	users []models.User
+}
+
+func (r *sweepUserRepository) CreateUser(context.Context, *models.User) error { return nil }
+func (r *sweepUserRepository) CreateUserWithOnboarding(context.Context, *models.User, []string, *models.UserLocation) error {
+	return nil
+}
+func (r *sweepUserRepository) GetUserByID(context.Context, uint) (*models.User, error) {
+	return nil, nil
+}
+func (r *sweepUserRepository) GetActiveUserByID(context.Context, uint) (*models.User, error) {
+	return nil, nil
+}
+func (r *sweepUserRepository) GetUsersByTelegramIDs(context.Context, []int64) (map[int64]*models.User, error) {
+	return nil, nil
+}
+func (r *sweepUserRepository) SetBanned(context.Context, uint, bool) error   { return nil }
+func (r *sweepUserRepository) ChangeTelegramID(context.Context, uint, int64) error { return nil }
+func (r *sweepUserRepository) UpdateUsername(context.Context, uint, string) error  { return nil }
+func (r *sweepUserRepository) CountUsers(context.Context) (int64, error)           { return int64(len(r.users)), nil }
+func (r *sweepUserRepository) UpdateLastActive(context.Context, uint) error { return nil }
+func (r *sweepUserRepository) DeleteUser(context.Context, uint) error       { return nil }
+func (r *sweepUserRepository) UpdateUserRating(context.Context, uint, float64, uint) error { return nil }
+func (r *sweepUserRepository) RecomputeUserRating(context.Context, uint) error             { return nil }
+func (r *sweepUserRepository) GetRatingHistory(context.Context, uint, int) ([]models.UserRatingEvent, error) {
+	return nil, nil
+}
+func (r *sweepUserRepository) DeleteRatingHistory(context.Context, uint) error { return nil }
+func (r *sweepUserRepository) UserExists(context.Context, uint) (bool, error) { return false, nil }
+func (r *sweepUserRepository) UserExistsByTelegramID(context.Context, int64) (bool, error) {
+	return false, nil
+}
+
+func (r *sweepUserRepository) GetUserFeatures(context.Context, uint) (models.FeatureFlags, error) {
+	return nil, nil
+}
+func (r *sweepUserRepository) SetUserFeature(context.Context, uint, string, bool) error { return nil }
+
+func (r *sweepUserRepository) ListUsers(_ context.Context, afterID uint, limit int, excludeBots bool) ([]models.User, error) {
+	var page []models.User
+	for _, u := range r.users {
+		if u.ID > afterID {
+			page = append(page, u)
+		}
+	}
+	return page, nil
+}
+
+func TestCacheSweeper_EvictsOnlyInactiveUsers(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+
+	fakeNow := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	users := &sweepUserRepository{users: []models.User{
+		{ID: 1, Username: "active-pirate", LastActiveAt: fakeNow.Add(-time.Hour)},
+		{ID: 2, Username: "ghost-ship", LastActiveAt: fakeNow.Add(-60 * 24 * time.Hour)},
+	}}
+	for _, u := range users.users {
+		u := u
+		if err := cache.SetUser(context.Background(), &u); err != nil {
+			t.Fatalf("seed cache for user %d: %v", u.ID, err)
+		}
+	}
+
+	sweeper := NewCacheSweeper(users, cache, zap.NewNop(), CacheSweeperConfig{InactivityThreshold: 30 * 24 * time.Hour})
+	sweeper.now = func() time.Time { return fakeNow }
+
+	sweeper.sweepOnce(context.Background())
+
+	active, err := cache.GetUser(context.Background(), 1)
+	if err != nil || active == nil {
+		t.Fatalf("expected the active user's cache entry to be retained, got %+v err=%v", active, err)
+	}
+
+	inactive, err := cache.GetUser(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if inactive != nil {
+		t.Fatalf("expected the inactive user's cache entry to be evicted, got %+v", inactive)
+	}
+}
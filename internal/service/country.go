@@ -0,0 +1,56 @@
+package service
+
+import "strings"
+
+// countryCodesByName is a small lookup table normalizing common country
+// names (and a few common aliases) to ISO 3166-1 alpha-2 codes. It is
+// not exhaustive; input it doesn't recognize is rejected rather than
+// guessed at.
+var countryCodesByName = map[string]string{
+	"russia":              "RU",
+	"russian federation":  "RU",
+	"united states":       "US",
+	"united states of america": "US",
+	"usa":                 "US",
+	"united kingdom":      "GB",
+	"great britain":       "GB",
+	"uk":                  "GB",
+	"germany":             "DE",
+	"france":              "FR",
+	"spain":               "ES",
+	"italy":               "IT",
+	"china":               "CN",
+	"japan":               "JP",
+	"brazil":              "BR",
+	"canada":              "CA",
+	"india":               "IN",
+	"turkey":              "TR",
+	"ukraine":             "UA",
+	"poland":              "PL",
+	"netherlands":         "NL",
+	"mexico":              "MX",
+}
+
+// knownCountryCodes is the set of ISO 3166-1 alpha-2 codes normalizeCountryCode
+// accepts directly, derived from countryCodesByName's values.
+var knownCountryCodes = func() map[string]bool {
+	set := make(map[string]bool, len(countryCodesByName))
+	for _, code := range countryCodesByName {
+		set[code] = true
+	}
+	return set
+}()
+
+// normalizeCountryCode resolves a free-form country name or code (e.g.
+// "Russia", "RU", "russia") to its ISO 3166-1 alpha-2 code. It returns
+// ok=false for input it doesn't recognize.
+func normalizeCountryCode(input string) (code string, ok bool) {
+	trimmed := strings.TrimSpace(input)
+	if upper := strings.ToUpper(trimmed); len(upper) == 2 && knownCountryCodes[upper] {
+		return upper, true
+	}
+	if code, found := countryCodesByName[strings.ToLower(trimmed)]; found {
+		return code, true
+	}
+	return "", false
+}
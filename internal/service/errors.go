@@ -0,0 +1,31 @@
+package service
+
+import (
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+)
+
+// mapServiceError converts a repository-layer error into the gRPC status
+// every handler should return for it, so a Postgres outage surfaces as
+// codes.Unavailable instead of codes.Internal and callers can tell a
+// transient outage from a genuine bug. A query killed by Postgres's
+// statement_timeout surfaces as codes.DeadlineExceeded, matching what a
+// client would have seen had its own context deadline fired first. msg
+// is used as the status message's prefix, mirroring the message each
+// handler used to build by hand (e.g. "create user", "purge user").
+func mapServiceError(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	if errors.Is(err, repository.ErrStatementTimeout) {
+		return status.Errorf(codes.DeadlineExceeded, "%s: %v", msg, err)
+	}
+	if errors.Is(err, repository.ErrUnavailable) {
+		return status.Errorf(codes.Unavailable, "%s: %v", msg, err)
+	}
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+)
+
+func TestMapServiceError_UnavailableForDependencyOutage(t *testing.T) {
+	err := mapServiceError(repository.ErrUnavailable, "get user")
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable for a dependency outage, got %v", err)
+	}
+}
+
+func TestMapServiceError_DeadlineExceededForStatementTimeout(t *testing.T) {
+	err := mapServiceError(repository.ErrStatementTimeout, "find nearby users")
+	if status.Code(err) != codes.DeadlineExceeded {
+		t.Fatalf("expected DeadlineExceeded for a statement timeout, got %v", err)
+	}
+}
+
+func TestMapServiceError_InternalForGenuineError(t *testing.T) {
+	err := mapServiceError(errors.New("constraint violation"), "create user")
+	if status.Code(err) != codes.Internal {
+		t.Fatalf("expected Internal for a non-outage error, got %v", err)
+	}
+}
+
+func TestMapServiceError_NilIsNil(t *testing.T) {
+	if err := mapServiceError(nil, "get user"); err != nil {
+		t.Fatalf("expected nil in, nil out, got %v", err)
+	}
+}
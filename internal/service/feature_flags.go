@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+// FeatureFlagConfig bounds which feature keys SetUserFeature accepts, so
+// a typo or a retired experiment name can't silently create a new column
+// value nobody reads.
+type FeatureFlagConfig struct {
+	AllowedKeys []string
+}
+
+// allowed reports whether key is in AllowedKeys. A zero-valued
+// FeatureFlagConfig (no keys configured) allows nothing.
+func (c FeatureFlagConfig) allowed(key string) bool {
+	for _, k := range c.AllowedKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// GetUserFeatures returns a user's feature flags, cache-backed the same
+// way GetUserPreferences is.
+func (s *UserService) GetUserFeatures(ctx context.Context, req *userpb.GetUserFeaturesRequest) (*userpb.GetUserFeaturesResponse, error) {
+	userID := uint(req.UserId)
+
+	if flags, found, err := s.cache.GetFeatureFlags(ctx, userID); err != nil {
+		s.log.Warn("feature flags cache lookup failed, falling back to db", zap.Error(err))
+	} else if found {
+		metrics.UserReadSourceTotal.WithLabelValues("GetUserFeatures", "cache").Inc()
+		return &userpb.GetUserFeaturesResponse{Flags: flags}, nil
+	}
+
+	flags, err := s.users.GetUserFeatures(ctx, userID)
+	if err != nil {
+		return nil, mapServiceError(err, "get user features")
+	}
+	metrics.UserReadSourceTotal.WithLabelValues("GetUserFeatures", "db").Inc()
+	if err := s.cache.SetFeatureFlags(ctx, userID, flags); err != nil {
+		s.log.Warn("failed to repopulate feature flags cache", zap.Error(err))
+	}
+	return &userpb.GetUserFeaturesResponse{Flags: flags}, nil
+}
+
+// SetUserFeature sets a single feature toggle for a user, rejecting keys
+// outside s.featureFlags.AllowedKeys so experiments can't be turned on
+// under a name nobody configured.
+func (s *UserService) SetUserFeature(ctx context.Context, req *userpb.SetUserFeatureRequest) (*userpb.SetUserFeatureResponse, error) {
+	if !s.featureFlags.allowed(req.Key) {
+		return nil, status.Errorf(codes.InvalidArgument, "feature key %q is not in the configured allowlist", req.Key)
+	}
+	userID := uint(req.UserId)
+
+	if err := s.users.SetUserFeature(ctx, userID, req.Key, req.Value); err != nil {
+		return nil, mapServiceError(err, "set user feature")
+	}
+
+	flags, err := s.users.GetUserFeatures(ctx, userID)
+	if err != nil {
+		return nil, mapServiceError(err, "get user features")
+	}
+	if err := s.cache.SetFeatureFlags(ctx, userID, flags); err != nil {
+		s.log.Warn("failed to refresh feature flags cache after set", zap.Error(err))
+	}
+	return &userpb.SetUserFeatureResponse{Flags: flags}, nil
+}
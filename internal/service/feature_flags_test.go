@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+func newFeatureFlagTestService(t *testing.T, allowedKeys []string) (*UserService, *countingUserRepository) {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := &countingUserRepository{existingIDs: map[uint]bool{7: true}}
+	prefs := &fakePreferenceRepository{byUser: map[uint][]string{}}
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	svc := NewUserService(repo, prefs, cache, zap.NewNop(), 0, GeoLimits{}, nil, nil, LocationDebounceConfig{}, FeatureFlagConfig{AllowedKeys: allowedKeys}, 0, false)
+	return svc, repo
+}
+
+func TestSetUserFeature_RoundTripsThroughGetUserFeatures(t *testing.T) {
+	svc, _ := newFeatureFlagTestService(t, []string{"dark_mode"})
+	ctx := context.Background()
+
+	if _, err := svc.SetUserFeature(ctx, &userpb.SetUserFeatureRequest{UserId: 7, Key: "dark_mode", Value: true}); err != nil {
+		t.Fatalf("SetUserFeature: %v", err)
+	}
+
+	resp, err := svc.GetUserFeatures(ctx, &userpb.GetUserFeaturesRequest{UserId: 7})
+	if err != nil {
+		t.Fatalf("GetUserFeatures: %v", err)
+	}
+	if !resp.Flags["dark_mode"] {
+		t.Fatalf("expected dark_mode to be true, got %+v", resp.Flags)
+	}
+}
+
+func TestSetUserFeature_RejectsAKeyOutsideTheAllowlist(t *testing.T) {
+	svc, _ := newFeatureFlagTestService(t, []string{"dark_mode"})
+	ctx := context.Background()
+
+	_, err := svc.SetUserFeature(ctx, &userpb.SetUserFeatureRequest{UserId: 7, Key: "unreleased_experiment", Value: true})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an unknown key, got %v", err)
+	}
+}
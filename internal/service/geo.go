@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+// defaultMaxRadiusKm and defaultMaxResultLimit are the GeoLimits used
+// when NewUserService is given a zero-valued GeoLimits.
+const (
+	defaultMaxRadiusKm    = 500
+	defaultMaxResultLimit = 100
+)
+
+// defaultMaxConcurrentSearches and defaultSearchAcquireTimeout are the
+// FindNearbyUsers bulkhead settings used when NewUserService is given a
+// zero-valued GeoLimits.
+const (
+	defaultMaxConcurrentSearches = 20
+	defaultSearchAcquireTimeout  = 200 * time.Millisecond
+)
+
+// sortByDistance and sortByRating are the values FindNearbyUsersRequest.
+// SortBy accepts; the empty string is treated as sortByDistance.
+const (
+	sortByDistance = "distance"
+	sortByRating   = "rating"
+)
+
+// validSortBy reports whether s is a FindNearbyUsers sort_by value this
+// service understands.
+func validSortBy(s string) bool {
+	return s == "" || s == sortByDistance || s == sortByRating
+}
+
+// GeoLimits bounds the parameters a FindNearbyUsers request may specify,
+// so a client-supplied radius or limit can't turn a single search into a
+// full geo-index scan.
+type GeoLimits struct {
+	MaxRadiusKm    float64
+	MaxResultLimit int
+
+	// CellPrecision controls how coarsely FindNearbyUsers buckets a
+	// query point before caching its result. See
+	// repository.defaultGeoCellPrecision for the tradeoff and the
+	// fallback used when this is <= 0.
+	CellPrecision int
+
+	// MaxConcurrentSearches bounds how many FindNearbyUsers calls may
+	// run at once, isolating this expensive query from the DB pool
+	// that cheaper endpoints also depend on. <= 0 falls back to
+	// defaultMaxConcurrentSearches.
+	MaxConcurrentSearches int
+
+	// SearchAcquireTimeout bounds how long a FindNearbyUsers call waits
+	// for a bulkhead slot before failing with ResourceExhausted. <= 0
+	// falls back to defaultSearchAcquireTimeout.
+	SearchAcquireTimeout time.Duration
+}
+
+// withDefaults fills in defaultMaxRadiusKm/defaultMaxResultLimit/
+// defaultMaxConcurrentSearches/defaultSearchAcquireTimeout for any field
+// left at its zero value.
+func (l GeoLimits) withDefaults() GeoLimits {
+	if l.MaxRadiusKm <= 0 {
+		l.MaxRadiusKm = defaultMaxRadiusKm
+	}
+	if l.MaxResultLimit <= 0 {
+		l.MaxResultLimit = defaultMaxResultLimit
+	}
+	if l.MaxConcurrentSearches <= 0 {
+		l.MaxConcurrentSearches = defaultMaxConcurrentSearches
+	}
+	if l.SearchAcquireTimeout <= 0 {
+		l.SearchAcquireTimeout = defaultSearchAcquireTimeout
+	}
+	return l
+}
+
+// FindNearbyUsers returns users within req.RadiusKm of the given point,
+// ordered by req.SortBy: closest first for sortByDistance (the
+// default), or rating descending with distance ascending as a
+// tiebreaker for sortByRating. RadiusKm and Limit are clamped to the
+// configured GeoLimits before the search runs (and before any cache key
+// derived from them is built), and a non-positive RadiusKm is rejected
+// outright rather than silently clamped, since it isn't a meaningful
+// search. When SharedWithUserId is set, results are further filtered
+// down to users who share at least one preference tag with that user.
+func (s *UserService) FindNearbyUsers(ctx context.Context, req *userpb.FindNearbyUsersRequest) (*userpb.FindNearbyUsersResponse, error) {
+	point := models.GeoPoint{Lat: req.Latitude, Lon: req.Longitude}
+	if !point.Valid() {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid coordinate (%v, %v)", req.Latitude, req.Longitude)
+	}
+	if req.RadiusKm <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "radius_km must be positive, got %v", req.RadiusKm)
+	}
+	if !validSortBy(req.SortBy) {
+		return nil, status.Errorf(codes.InvalidArgument, "sort_by must be %q or %q, got %q", sortByDistance, sortByRating, req.SortBy)
+	}
+
+	if !s.geoBulkhead.Acquire(ctx) {
+		return nil, status.Error(codes.ResourceExhausted, "too many concurrent nearby searches, try again shortly")
+	}
+	defer s.geoBulkhead.Release()
+
+	radiusKm := req.RadiusKm
+	if radiusKm > s.geoLimits.MaxRadiusKm {
+		radiusKm = s.geoLimits.MaxRadiusKm
+	}
+	limit := int(req.Limit)
+	if limit <= 0 || limit > s.geoLimits.MaxResultLimit {
+		limit = s.geoLimits.MaxResultLimit
+	}
+
+	ids, err := s.cache.FindNearbyUserIDsCachedAt(ctx, point, radiusKm, limit, req.SortBy, s.geoLimits.CellPrecision)
+	if err != nil {
+		return nil, mapServiceError(err, "find nearby users")
+	}
+
+	var sharedTags map[string]bool
+	if req.SharedWithUserId != 0 {
+		sharedTags, err = s.preferenceTagSet(ctx, uint(req.SharedWithUserId))
+		if err != nil {
+			return nil, mapServiceError(err, "find nearby users")
+		}
+	}
+
+	// candidates is built in the order ids came back from the geo
+	// search, i.e. distance ascending; that order is preserved as-is
+	// for the default sort and used as the stable tiebreaker when
+	// sorting by rating below.
+	candidates := make([]*models.User, 0, len(ids))
+	for _, id := range ids {
+		user, err := s.users.GetActiveUserByID(ctx, id)
+		if err != nil {
+			s.log.Warn("dropping stale geo entry: user lookup failed", zap.Error(err))
+			continue
+		}
+		if sharedTags != nil && !s.hasSharedTag(ctx, id, sharedTags) {
+			continue
+		}
+		candidates = append(candidates, user)
+	}
+
+	if req.SortBy == sortByRating {
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].Rating > candidates[j].Rating
+		})
+	}
+
+	users := make([]*userpb.UserResponse, 0, len(candidates))
+	for _, user := range candidates {
+		users = append(users, toUserResponse(user))
+	}
+	return &userpb.FindNearbyUsersResponse{Users: users}, nil
+}
+
+// CountNearbyUsers returns the number of users within req.RadiusKm of the
+// given point, capped at the service's configured MaxResultLimit, for a
+// UI count badge shown before a caller commits to the fuller
+// FindNearbyUsers fetch. When req.MinRating is set, the count is
+// narrowed to matching users; since the geo index carries no rating
+// data, this falls back to walking the same cached id list
+// FindNearbyUsers would, so it costs a row read per candidate rather
+// than being served from CountNearbyUsersAt's own short-lived cache.
+func (s *UserService) CountNearbyUsers(ctx context.Context, req *userpb.CountNearbyUsersRequest) (*userpb.CountNearbyUsersResponse, error) {
+	point := models.GeoPoint{Lat: req.Latitude, Lon: req.Longitude}
+	if !point.Valid() {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid coordinate (%v, %v)", req.Latitude, req.Longitude)
+	}
+	if req.RadiusKm <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "radius_km must be positive, got %v", req.RadiusKm)
+	}
+
+	if !s.geoBulkhead.Acquire(ctx) {
+		return nil, status.Error(codes.ResourceExhausted, "too many concurrent nearby searches, try again shortly")
+	}
+	defer s.geoBulkhead.Release()
+
+	radiusKm := req.RadiusKm
+	if radiusKm > s.geoLimits.MaxRadiusKm {
+		radiusKm = s.geoLimits.MaxRadiusKm
+	}
+	limit := s.geoLimits.MaxResultLimit
+
+	if req.MinRating <= 0 {
+		count, err := s.cache.CountNearbyUsersAt(ctx, point, radiusKm, limit, s.geoLimits.CellPrecision)
+		if err != nil {
+			return nil, mapServiceError(err, "count nearby users")
+		}
+		return &userpb.CountNearbyUsersResponse{Count: count}, nil
+	}
+
+	ids, err := s.cache.FindNearbyUserIDsCachedAt(ctx, point, radiusKm, limit, sortByDistance, s.geoLimits.CellPrecision)
+	if err != nil {
+		return nil, mapServiceError(err, "count nearby users")
+	}
+	var count int64
+	for _, id := range ids {
+		user, err := s.users.GetActiveUserByID(ctx, id)
+		if err != nil {
+			s.log.Warn("dropping stale geo entry: user lookup failed", zap.Error(err))
+			continue
+		}
+		if user.Rating >= req.MinRating {
+			count++
+		}
+	}
+	return &userpb.CountNearbyUsersResponse{Count: count}, nil
+}
+
+// preferenceTagSet returns userID's preference tags as a set, for
+// cheap shared-tag membership checks.
+func (s *UserService) preferenceTagSet(ctx context.Context, userID uint) (map[string]bool, error) {
+	prefs, err := s.prefs.ListPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	tags := make(map[string]bool, len(prefs))
+	for _, p := range prefs {
+		tags[p.Tag] = true
+	}
+	return tags, nil
+}
+
+// hasSharedTag reports whether userID has at least one preference tag
+// present in tags. A lookup failure is treated as no match, so a flaky
+// preferences read narrows results rather than failing the whole search.
+func (s *UserService) hasSharedTag(ctx context.Context, userID uint, tags map[string]bool) bool {
+	prefs, err := s.prefs.ListPreferences(ctx, userID)
+	if err != nil {
+		s.log.Warn("shared-preference lookup failed, excluding candidate", zap.Error(err))
+		return false
+	}
+	for _, p := range prefs {
+		if tags[p.Tag] {
+			return true
+		}
+	}
+	return false
+}
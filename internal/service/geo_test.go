@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+func newGeoTestService(t *testing.T, limits GeoLimits) (*UserService, *redis.Client) {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := &countingUserRepository{banned: map[uint]bool{}}
+	prefs := &fakePreferenceRepository{byUser: map[uint][]string{}}
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	svc := NewUserService(repo, prefs, cache, zap.NewNop(), 0, limits, nil, nil, LocationDebounceConfig{}, FeatureFlagConfig{}, 0, false)
+
+	// Seed one user near Lisbon so it can be found once a request's
+	// radius has been clamped down over it.
+	repo.CreateUser(context.Background(), &models.User{Username: "lisbon-crew"})
+	if err := client.GeoAdd(context.Background(), "geo:users", &redis.GeoLocation{
+		Name: "1", Longitude: -9.1393, Latitude: 38.7223,
+	}).Err(); err != nil {
+		t.Fatalf("seed geo entry: %v", err)
+	}
+	return svc, client
+}
+
+func TestFindNearbyUsers_RejectsNonPositiveRadius(t *testing.T) {
+	svc, _ := newGeoTestService(t, GeoLimits{})
+
+	_, err := svc.FindNearbyUsers(context.Background(), &userpb.FindNearbyUsersRequest{
+		Latitude: 38.7, Longitude: -9.1, RadiusKm: 0,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a zero radius, got %v", err)
+	}
+}
+
+func TestFindNearbyUsers_RejectsAnInvalidCoordinate(t *testing.T) {
+	svc, _ := newGeoTestService(t, GeoLimits{})
+
+	_, err := svc.FindNearbyUsers(context.Background(), &userpb.FindNearbyUsersRequest{
+		Latitude: 200, Longitude: -9.1, RadiusKm: 10,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an out-of-range latitude, got %v", err)
+	}
+}
+
+func TestFindNearbyUsers_ClampsOversizedRadius(t *testing.T) {
+	svc, _ := newGeoTestService(t, GeoLimits{MaxRadiusKm: 50, MaxResultLimit: 10})
+
+	resp, err := svc.FindNearbyUsers(context.Background(), &userpb.FindNearbyUsersRequest{
+		Latitude: 38.7223, Longitude: -9.1393, RadiusKm: 20000,
+	})
+	if err != nil {
+		t.Fatalf("FindNearbyUsers: %v", err)
+	}
+	if len(resp.Users) != 1 || resp.Users[0].Id != 1 {
+		t.Fatalf("expected the seeded user within the clamped radius, got %+v", resp.Users)
+	}
+}
+
+func TestFindNearbyUsers_SharedWithUserIdFiltersToUsersWithACommonTag(t *testing.T) {
+	svc, client := newGeoTestService(t, GeoLimits{})
+	prefs := svc.prefs.(*fakePreferenceRepository)
+
+	// User 1 (seeded by newGeoTestService) shares a tag with the
+	// requester; user 2 is just as close but shares nothing.
+	svc.users.(*countingUserRepository).CreateUser(context.Background(), &models.User{Username: "no-overlap"})
+	if err := client.GeoAdd(context.Background(), "geo:users", &redis.GeoLocation{
+		Name: "2", Longitude: -9.1393, Latitude: 38.7223,
+	}).Err(); err != nil {
+		t.Fatalf("seed geo entry: %v", err)
+	}
+	prefs.byUser[1] = []string{"night-owl"}
+	prefs.byUser[2] = []string{"early-bird"}
+	prefs.byUser[3] = []string{"night-owl", "long-voyages"}
+
+	resp, err := svc.FindNearbyUsers(context.Background(), &userpb.FindNearbyUsersRequest{
+		Latitude: 38.7223, Longitude: -9.1393, RadiusKm: 100, SharedWithUserId: 3,
+	})
+	if err != nil {
+		t.Fatalf("FindNearbyUsers: %v", err)
+	}
+	if len(resp.Users) != 1 || resp.Users[0].Id != 1 {
+		t.Fatalf("expected only the user sharing a tag, got %+v", resp.Users)
+	}
+}
+
+func TestFindNearbyUsers_RejectsUnknownSortBy(t *testing.T) {
+	svc, _ := newGeoTestService(t, GeoLimits{})
+
+	_, err := svc.FindNearbyUsers(context.Background(), &userpb.FindNearbyUsersRequest{
+		Latitude: 38.7223, Longitude: -9.1393, RadiusKm: 100, SortBy: "popularity",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an unknown sort_by, got %v", err)
+	}
+}
+
+// seedRankedFixture adds users 2 and 3 beyond the already-seeded user 1,
+// at increasing distance from the query point, and rates users 1-3 at
+// 1.0, 5.0 and 3.0, so a distance sort and a rating sort disagree on the
+// ordering.
+func seedRankedFixture(t *testing.T, svc *UserService, client *redis.Client) {
+	t.Helper()
+	repo := svc.users.(*countingUserRepository)
+	repo.ratings = map[uint]float64{1: 1.0, 2: 5.0, 3: 3.0}
+
+	repo.CreateUser(context.Background(), &models.User{Username: "second-closest-highest-rated"})
+	repo.CreateUser(context.Background(), &models.User{Username: "farthest-middle-rated"})
+	locations := []struct {
+		id       string
+		lon, lat float64
+	}{
+		{"2", -9.1400, 38.7230}, // 2nd closest, rating 5 (highest)
+		{"3", -9.1500, 38.7300}, // farthest, rating 3
+	}
+	for _, loc := range locations {
+		if err := client.GeoAdd(context.Background(), "geo:users", &redis.GeoLocation{
+			Name: loc.id, Longitude: loc.lon, Latitude: loc.lat,
+		}).Err(); err != nil {
+			t.Fatalf("seed geo entry: %v", err)
+		}
+	}
+}
+
+func TestFindNearbyUsers_SortByDistanceIsClosestFirst(t *testing.T) {
+	svc, client := newGeoTestService(t, GeoLimits{})
+	seedRankedFixture(t, svc, client)
+
+	resp, err := svc.FindNearbyUsers(context.Background(), &userpb.FindNearbyUsersRequest{
+		Latitude: 38.7223, Longitude: -9.1393, RadiusKm: 100,
+	})
+	if err != nil {
+		t.Fatalf("FindNearbyUsers: %v", err)
+	}
+	ids := make([]uint64, len(resp.Users))
+	for i, u := range resp.Users {
+		ids[i] = u.Id
+	}
+	want := []uint64{1, 2, 3}
+	if len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] || ids[2] != want[2] {
+		t.Fatalf("expected distance order %v, got %v", want, ids)
+	}
+}
+
+func TestFindNearbyUsers_SortByRatingOrdersDescendingByRating(t *testing.T) {
+	svc, client := newGeoTestService(t, GeoLimits{})
+	seedRankedFixture(t, svc, client)
+
+	resp, err := svc.FindNearbyUsers(context.Background(), &userpb.FindNearbyUsersRequest{
+		Latitude: 38.7223, Longitude: -9.1393, RadiusKm: 100, SortBy: "rating",
+	})
+	if err != nil {
+		t.Fatalf("FindNearbyUsers: %v", err)
+	}
+	ids := make([]uint64, len(resp.Users))
+	for i, u := range resp.Users {
+		ids[i] = u.Id
+	}
+	want := []uint64{2, 3, 1}
+	if len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] || ids[2] != want[2] {
+		t.Fatalf("expected rating order %v, got %v", want, ids)
+	}
+}
+
+func TestFindNearbyUsers_ClampsOversizedLimit(t *testing.T) {
+	svc, _ := newGeoTestService(t, GeoLimits{MaxRadiusKm: 500, MaxResultLimit: 1})
+
+	resp, err := svc.FindNearbyUsers(context.Background(), &userpb.FindNearbyUsersRequest{
+		Latitude: 38.7223, Longitude: -9.1393, RadiusKm: 100, Limit: 5000,
+	})
+	if err != nil {
+		t.Fatalf("FindNearbyUsers: %v", err)
+	}
+	if len(resp.Users) > 1 {
+		t.Fatalf("expected the result limit to be clamped to 1, got %d users", len(resp.Users))
+	}
+}
+
+func TestFindNearbyUsers_ResourceExhaustedWhenBulkheadIsFull(t *testing.T) {
+	svc, _ := newGeoTestService(t, GeoLimits{MaxConcurrentSearches: 1, SearchAcquireTimeout: 20 * time.Millisecond})
+
+	if !svc.geoBulkhead.Acquire(context.Background()) {
+		t.Fatalf("expected to acquire the only bulkhead slot")
+	}
+	defer svc.geoBulkhead.Release()
+
+	start := time.Now()
+	_, err := svc.FindNearbyUsers(context.Background(), &userpb.FindNearbyUsersRequest{
+		Latitude: 38.7223, Longitude: -9.1393, RadiusKm: 100,
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("expected ResourceExhausted while the bulkhead is full, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("expected the call to fail quickly instead of blocking, took %v", elapsed)
+	}
+}
+
+func TestCountNearbyUsers_CountsUsersWithinRadius(t *testing.T) {
+	svc, _ := newGeoTestService(t, GeoLimits{MaxRadiusKm: 500, MaxResultLimit: 10})
+
+	resp, err := svc.CountNearbyUsers(context.Background(), &userpb.CountNearbyUsersRequest{
+		Latitude: 38.7223, Longitude: -9.1393, RadiusKm: 100,
+	})
+	if err != nil {
+		t.Fatalf("CountNearbyUsers: %v", err)
+	}
+	if resp.Count != 1 {
+		t.Fatalf("expected the single seeded user to be counted, got %d", resp.Count)
+	}
+}
+
+func TestCountNearbyUsers_MinRatingExcludesLowerRatedUsers(t *testing.T) {
+	svc, client := newGeoTestService(t, GeoLimits{MaxRadiusKm: 500, MaxResultLimit: 10})
+	repo := svc.users.(*countingUserRepository)
+	repo.ratings = map[uint]float64{1: 3}
+
+	repo.CreateUser(context.Background(), &models.User{Username: "highly-rated"})
+	repo.ratings[2] = 4.8
+	if err := client.GeoAdd(context.Background(), "geo:users", &redis.GeoLocation{
+		Name: "2", Longitude: -9.1393, Latitude: 38.7223,
+	}).Err(); err != nil {
+		t.Fatalf("seed geo entry: %v", err)
+	}
+
+	resp, err := svc.CountNearbyUsers(context.Background(), &userpb.CountNearbyUsersRequest{
+		Latitude: 38.7223, Longitude: -9.1393, RadiusKm: 100, MinRating: 4,
+	})
+	if err != nil {
+		t.Fatalf("CountNearbyUsers: %v", err)
+	}
+	if resp.Count != 1 {
+		t.Fatalf("expected only the user rated >= 4 to be counted, got %d", resp.Count)
+	}
+}
+
+func TestCountNearbyUsers_RejectsNonPositiveRadius(t *testing.T) {
+	svc, _ := newGeoTestService(t, GeoLimits{})
+
+	_, err := svc.CountNearbyUsers(context.Background(), &userpb.CountNearbyUsersRequest{
+		Latitude: 38.7, Longitude: -9.1, RadiusKm: 0,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for a zero radius, got %v", err)
+	}
+}
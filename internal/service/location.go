@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/validation"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+// defaultLocationDebounceMinDistanceMeters and
+// defaultLocationDebounceMinInterval are the LocationDebounceConfig used
+// when NewUserService is given a zero-valued LocationDebounceConfig.
+const (
+	defaultLocationDebounceMinDistanceMeters = 200
+	defaultLocationDebounceMinInterval       = 5 * time.Minute
+)
+
+// earthRadiusMeters is used by haversineDistanceMeters.
+const earthRadiusMeters = 6371000.0
+
+// LocationDebounceConfig bounds how aggressively UpdateUserLocation
+// debounces writes to Postgres: every ping updates the Redis fast-path
+// cache immediately, but a ping is only flushed to Postgres once the
+// user has moved at least MinDistanceMeters from their last persisted
+// position, or MinInterval has elapsed since that write.
+type LocationDebounceConfig struct {
+	MinDistanceMeters float64
+	MinInterval       time.Duration
+}
+
+// withDefaults fills in defaultLocationDebounceMinDistanceMeters/
+// defaultLocationDebounceMinInterval for any field left at its zero
+// value.
+func (c LocationDebounceConfig) withDefaults() LocationDebounceConfig {
+	if c.MinDistanceMeters <= 0 {
+		c.MinDistanceMeters = defaultLocationDebounceMinDistanceMeters
+	}
+	if c.MinInterval <= 0 {
+		c.MinInterval = defaultLocationDebounceMinInterval
+	}
+	return c
+}
+
+// haversineDistanceMeters returns the great-circle distance between two
+// lat/lon points, in meters.
+func haversineDistanceMeters(lat1, lon1, lat2, lon2 float64) float64 {
+	lat1Rad := lat1 * math.Pi / 180
+	lat2Rad := lat2 * math.Pi / 180
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLon := (lon2 - lon1) * math.Pi / 180
+
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1Rad)*math.Cos(lat2Rad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return earthRadiusMeters * 2 * math.Atan2(math.Sqrt(a), math.Sqrt(1-a))
+}
+
+// UpdateUserLocation stores one of a user's saved positions, normalizing
+// country to its ISO 3166-1 alpha-2 code so downstream filtering never
+// has to reconcile "Russia"/"RU"/"russia" as distinct values. req.Label
+// defaults to models.CurrentLocationLabel when empty.
+//
+// Only the current label goes through the Redis debounce/fast-path
+// cache and feeds nearby-user search; any other label (e.g. "home",
+// "work") is written straight to Postgres, since it isn't a live
+// position that needs sub-second freshness. For the current label, the
+// Postgres write is debounced per s.locationDebounce: every call updates
+// the Redis fast-path cache immediately, but the DB is only touched once
+// the user has moved far enough, or long enough has elapsed, since the
+// last persisted ping. Debounced pings are tracked as "dirty" so
+// FlushAllPendingLocations can catch them up later. The last-active
+// stamp itself is recorded through s.activity, so this call never blocks
+// on that write either.
+func (s *UserService) UpdateUserLocation(ctx context.Context, req *userpb.UpdateUserLocationRequest) (*userpb.UpdateUserLocationResponse, error) {
+	point := models.GeoPoint{Lat: req.Latitude, Lon: req.Longitude}
+	if !point.Valid() {
+		return nil, validation.Errorf(codes.InvalidArgument,
+			[]validation.FieldViolation{{Field: "coordinates", Description: "invalid coordinate"}},
+			"invalid coordinate (%v, %v)", req.Latitude, req.Longitude)
+	}
+	code, ok := normalizeCountryCode(req.Country)
+	if !ok {
+		return nil, validation.Errorf(codes.InvalidArgument,
+			[]validation.FieldViolation{{Field: "country", Description: "unrecognized country"}},
+			"unrecognized country %q", req.Country)
+	}
+	userID := uint(req.UserId)
+	label := req.Label
+	if label == "" {
+		label = models.CurrentLocationLabel
+	}
+
+	s.activity.Record(userID)
+
+	if label != models.CurrentLocationLabel {
+		loc := &models.UserLocation{UserID: userID, Label: label, Latitude: req.Latitude, Longitude: req.Longitude, Country: code}
+		if err := s.locations.UpsertLocation(ctx, loc); err != nil {
+			return nil, mapServiceError(err, "update user location")
+		}
+		return &userpb.UpdateUserLocationResponse{Country: code}, nil
+	}
+
+	state, err := s.cache.GetLocationState(ctx, userID)
+	if err != nil {
+		s.log.Warn("location debounce state lookup failed, persisting immediately", zap.Error(err))
+		state = nil
+	}
+
+	now := time.Now()
+	persistedLat, persistedLon, persistedAt := req.Latitude, req.Longitude, now
+	shouldPersist := state == nil
+	if state != nil {
+		moved := haversineDistanceMeters(state.LastPersistedLatitude, state.LastPersistedLongitude, req.Latitude, req.Longitude)
+		elapsed := now.Sub(state.LastPersistedAt)
+		shouldPersist = moved >= s.locationDebounce.MinDistanceMeters || elapsed >= s.locationDebounce.MinInterval
+		if !shouldPersist {
+			persistedLat, persistedLon, persistedAt = state.LastPersistedLatitude, state.LastPersistedLongitude, state.LastPersistedAt
+		}
+	}
+
+	if err := s.cache.SetLocationState(ctx, userID, repository.CachedLocation{
+		Latitude:               req.Latitude,
+		Longitude:              req.Longitude,
+		Country:                code,
+		LastPersistedLatitude:  persistedLat,
+		LastPersistedLongitude: persistedLon,
+		LastPersistedAt:        persistedAt,
+	}); err != nil {
+		s.log.Warn("failed to update location fast-path cache", zap.Error(err))
+	}
+
+	if !shouldPersist {
+		if err := s.cache.MarkLocationDirty(ctx, userID); err != nil {
+			s.log.Warn("failed to mark location dirty for later flush", zap.Error(err))
+		}
+		return &userpb.UpdateUserLocationResponse{Country: code}, nil
+	}
+
+	if err := s.persistUserLocation(ctx, userID, req.Latitude, req.Longitude, code); err != nil {
+		return nil, err
+	}
+	return &userpb.UpdateUserLocationResponse{Country: code}, nil
+}
+
+// persistUserLocation is a thin wrapper around persistUserLocationAt for
+// callers that still have a separate lat/lon rather than a
+// models.GeoPoint.
+func (s *UserService) persistUserLocation(ctx context.Context, userID uint, lat, lon float64, country string) error {
+	return s.persistUserLocationAt(ctx, userID, models.GeoPoint{Lat: lat, Lon: lon}, country)
+}
+
+// persistUserLocationAt writes a user's location to Postgres and
+// updates the Redis-side bookkeeping (fast-path cache and dirty marker)
+// to reflect that it's now up to date.
+func (s *UserService) persistUserLocationAt(ctx context.Context, userID uint, point models.GeoPoint, country string) error {
+	loc := &models.UserLocation{UserID: userID, Label: models.CurrentLocationLabel, Latitude: point.Lat, Longitude: point.Lon, Country: country}
+	if err := s.locations.UpsertLocation(ctx, loc); err != nil {
+		return mapServiceError(err, "update user location")
+	}
+
+	now := time.Now()
+	if err := s.cache.SetLocationState(ctx, userID, repository.CachedLocation{
+		Latitude:               point.Lat,
+		Longitude:              point.Lon,
+		Country:                country,
+		LastPersistedLatitude:  point.Lat,
+		LastPersistedLongitude: point.Lon,
+		LastPersistedAt:        now,
+	}); err != nil {
+		s.log.Warn("failed to update location fast-path cache", zap.Error(err))
+	}
+	if err := s.cache.ClearLocationDirty(ctx, userID); err != nil {
+		s.log.Warn("failed to clear dirty location marker", zap.Error(err))
+	}
+	return nil
+}
+
+// FlushUserLocation forces userID's cached fast-path location to be
+// persisted to Postgres, bypassing the debounce thresholds. It's a
+// no-op if there's no cached state, i.e. nothing pending.
+func (s *UserService) FlushUserLocation(ctx context.Context, userID uint) error {
+	state, err := s.cache.GetLocationState(ctx, userID)
+	if err != nil || state == nil {
+		return err
+	}
+	return s.persistUserLocation(ctx, userID, state.Latitude, state.Longitude, state.Country)
+}
+
+// FlushAllPendingLocations persists every user's fast-path location that
+// hasn't yet made it to Postgres. Callers should invoke this during
+// graceful shutdown so a debounced ping is never silently lost.
+func (s *UserService) FlushAllPendingLocations(ctx context.Context) error {
+	ids, err := s.cache.ListDirtyLocationUserIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := s.FlushUserLocation(ctx, id); err != nil {
+			s.log.Warn("failed to flush pending location on shutdown", zap.Uint("user_id", id), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+// validCoordinate is a thin wrapper around models.GeoPoint.Valid for
+// callers that still have a separate lat/lon rather than a GeoPoint.
+func validCoordinate(lat, lon float64) bool {
+	return (models.GeoPoint{Lat: lat, Lon: lon}).Valid()
+}
+
+// BatchUpdateLocations upserts many users' locations in a single
+// transaction. An entry with an invalid coordinate or unrecognized
+// country is skipped rather than failing the whole batch; its outcome is
+// reported back to the caller in Results instead.
+func (s *UserService) BatchUpdateLocations(ctx context.Context, req *userpb.BatchUpdateLocationsRequest) (*userpb.BatchUpdateLocationsResponse, error) {
+	results := make([]*userpb.LocationUpdateStatus, len(req.Locations))
+	locs := make([]*models.UserLocation, 0, len(req.Locations))
+	locIndexes := make([]int, 0, len(req.Locations))
+
+	for i, entry := range req.Locations {
+		if !validCoordinate(entry.Latitude, entry.Longitude) {
+			results[i] = &userpb.LocationUpdateStatus{UserId: entry.UserId, Success: false, Error: "invalid coordinate"}
+			continue
+		}
+		code, ok := normalizeCountryCode(entry.Country)
+		if !ok {
+			results[i] = &userpb.LocationUpdateStatus{UserId: entry.UserId, Success: false, Error: fmt.Sprintf("unrecognized country %q", entry.Country)}
+			continue
+		}
+		locs = append(locs, &models.UserLocation{
+			UserID:    uint(entry.UserId),
+			Label:     models.CurrentLocationLabel,
+			Latitude:  entry.Latitude,
+			Longitude: entry.Longitude,
+			Country:   code,
+		})
+		locIndexes = append(locIndexes, i)
+	}
+
+	if len(locs) > 0 {
+		if err := s.locations.BatchUpsertLocations(ctx, locs); err != nil {
+			for _, i := range locIndexes {
+				results[i] = &userpb.LocationUpdateStatus{UserId: req.Locations[i].UserId, Success: false, Error: err.Error()}
+			}
+			return &userpb.BatchUpdateLocationsResponse{Results: results}, nil
+		}
+		now := time.Now()
+		for n, i := range locIndexes {
+			loc := locs[n]
+			if err := s.cache.SetLocationState(ctx, loc.UserID, repository.CachedLocation{
+				Latitude:               loc.Latitude,
+				Longitude:              loc.Longitude,
+				Country:                loc.Country,
+				LastPersistedLatitude:  loc.Latitude,
+				LastPersistedLongitude: loc.Longitude,
+				LastPersistedAt:        now,
+			}); err != nil {
+				s.log.Warn("failed to update location fast-path cache", zap.Error(err))
+			}
+			if err := s.cache.ClearLocationDirty(ctx, loc.UserID); err != nil {
+				s.log.Warn("failed to clear dirty location marker", zap.Error(err))
+			}
+			results[i] = &userpb.LocationUpdateStatus{UserId: req.Locations[i].UserId, Success: true}
+		}
+	}
+
+	return &userpb.BatchUpdateLocationsResponse{Results: results}, nil
+}
+
+// GetUserLocations returns every labeled position saved for a user
+// (e.g. "current", "home", "work").
+func (s *UserService) GetUserLocations(ctx context.Context, req *userpb.GetUserLocationsRequest) (*userpb.GetUserLocationsResponse, error) {
+	locs, err := s.locations.GetUserLocations(ctx, uint(req.UserId))
+	if err != nil {
+		return nil, mapServiceError(err, "get user locations")
+	}
+	entries := make([]*userpb.UserLocationEntry, len(locs))
+	for i, loc := range locs {
+		entries[i] = &userpb.UserLocationEntry{
+			Label:     loc.Label,
+			Latitude:  loc.Latitude,
+			Longitude: loc.Longitude,
+			Country:   loc.Country,
+			UpdatedAt: loc.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+	}
+	return &userpb.GetUserLocationsResponse{Locations: entries}, nil
+}
+
+// defaultBoundsResultLimit caps FindUsersInBounds when the caller passes
+// a limit <= 0.
+const defaultBoundsResultLimit = 100
+
+// FindUsersInBounds returns users whose current location falls within a
+// map viewport rectangle, for clients that already have a bounding box
+// (e.g. from a map's visible area) rather than a center point and
+// radius. min_lat/max_lat and min_lon/max_lon must each be valid
+// coordinates; a min_lon greater than max_lon is treated as a box
+// crossing the antimeridian.
+func (s *UserService) FindUsersInBounds(ctx context.Context, req *userpb.FindUsersInBoundsRequest) (*userpb.FindUsersInBoundsResponse, error) {
+	if req.MinLat > req.MaxLat {
+		return nil, status.Errorf(codes.InvalidArgument, "min_lat must be <= max_lat")
+	}
+	if !validCoordinate(req.MinLat, req.MinLon) || !validCoordinate(req.MaxLat, req.MaxLon) {
+		return nil, status.Errorf(codes.InvalidArgument, "bounds must be valid coordinates")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultBoundsResultLimit
+	}
+
+	locs, err := s.locations.FindUsersInBounds(ctx, req.MinLat, req.MinLon, req.MaxLat, req.MaxLon, limit)
+	if err != nil {
+		return nil, mapServiceError(err, "find users in bounds")
+	}
+
+	users := make([]*userpb.UserResponse, 0, len(locs))
+	for _, loc := range locs {
+		user, err := s.users.GetActiveUserByID(ctx, loc.UserID)
+		if err != nil {
+			s.log.Warn("dropping stale bounds entry: user lookup failed", zap.Error(err))
+			continue
+		}
+		users = append(users, toUserResponse(user))
+	}
+	return &userpb.FindUsersInBoundsResponse{Users: users}, nil
+}
@@ -0,0 +1,419 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+// fakeLocationRepository stores locations in memory, standing in for
+// PostgresLocationRepository in tests. byUser tracks only the current
+// label, matching what most existing tests assert on; byLabel tracks
+// every label so GetUserLocations can be exercised.
+type fakeLocationRepository struct {
+	byUser      map[uint]models.UserLocation
+	byLabel     map[uint]map[string]models.UserLocation
+	upsertCalls uint32
+}
+
+func (r *fakeLocationRepository) put(loc models.UserLocation) {
+	label := loc.Label
+	if label == "" {
+		label = models.CurrentLocationLabel
+	}
+	if r.byLabel == nil {
+		r.byLabel = map[uint]map[string]models.UserLocation{}
+	}
+	if r.byLabel[loc.UserID] == nil {
+		r.byLabel[loc.UserID] = map[string]models.UserLocation{}
+	}
+	r.byLabel[loc.UserID][label] = loc
+	if label == models.CurrentLocationLabel {
+		r.byUser[loc.UserID] = loc
+	}
+}
+
+func (r *fakeLocationRepository) UpsertLocation(_ context.Context, loc *models.UserLocation) error {
+	atomic.AddUint32(&r.upsertCalls, 1)
+	r.put(*loc)
+	return nil
+}
+
+func (r *fakeLocationRepository) BatchUpsertLocations(_ context.Context, locs []*models.UserLocation) error {
+	for _, loc := range locs {
+		r.put(*loc)
+	}
+	return nil
+}
+
+func (r *fakeLocationRepository) GetUserLocations(_ context.Context, userID uint) ([]models.UserLocation, error) {
+	var out []models.UserLocation
+	for _, loc := range r.byLabel[userID] {
+		out = append(out, loc)
+	}
+	return out, nil
+}
+
+func (r *fakeLocationRepository) DeleteAllForUser(_ context.Context, userID uint) error {
+	delete(r.byUser, userID)
+	delete(r.byLabel, userID)
+	return nil
+}
+
+func (r *fakeLocationRepository) FindUsersInBounds(_ context.Context, minLat, minLon, maxLat, maxLon float64, limit int) ([]models.UserLocation, error) {
+	var out []models.UserLocation
+	for _, loc := range r.byUser {
+		if loc.Latitude < minLat || loc.Latitude > maxLat {
+			continue
+		}
+		inBounds := minLon <= maxLon && loc.Longitude >= minLon && loc.Longitude <= maxLon
+		crossesAntimeridian := minLon > maxLon && (loc.Longitude >= minLon || loc.Longitude <= maxLon)
+		if !inBounds && !crossesAntimeridian {
+			continue
+		}
+		out = append(out, loc)
+		if limit > 0 && len(out) >= limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+func (r *fakeLocationRepository) FindUsersWithinRadius(_ context.Context, lat, lon, radiusKm float64, limit int) ([]models.UserLocation, error) {
+	var out []models.UserLocation
+	for _, loc := range r.byUser {
+		if haversineKm(lat, lon, loc.Latitude, loc.Longitude) <= radiusKm {
+			out = append(out, loc)
+		}
+	}
+	if limit > 0 && len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// haversineKm mirrors PostgresLocationRepository.FindUsersWithinRadius's
+// haversine formula in Go, since the fake has no SQL engine to evaluate
+// it against.
+func haversineKm(lat1, lon1, lat2, lon2 float64) float64 {
+	const earthRadiusKm = 6371.0
+	dLat := (lat2 - lat1) * math.Pi / 180
+	dLon := (lon2 - lon1) * math.Pi / 180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*math.Pi/180)*math.Cos(lat2*math.Pi/180)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return earthRadiusKm * 2 * math.Asin(math.Sqrt(a))
+}
+
+func newLocationTestService(t *testing.T) (*UserService, *fakeLocationRepository) {
+	t.Helper()
+	svc, _ := newTestService(t)
+	locations := &fakeLocationRepository{byUser: map[uint]models.UserLocation{}}
+	svc.locations = locations
+	return svc, locations
+}
+
+func newLocationTestServiceWithDebounce(t *testing.T, cfg LocationDebounceConfig) (*UserService, *fakeLocationRepository) {
+	t.Helper()
+	svc, locations := newLocationTestService(t)
+	svc.locationDebounce = cfg.withDefaults()
+	return svc, locations
+}
+
+func TestFindUsersInBounds_RejectsAnInvertedLatitudeRange(t *testing.T) {
+	svc, _ := newLocationTestService(t)
+
+	_, err := svc.FindUsersInBounds(context.Background(), &userpb.FindUsersInBoundsRequest{
+		MinLat: 20, MaxLat: 10, MinLon: 0, MaxLon: 10,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for min_lat > max_lat, got %v", err)
+	}
+}
+
+func TestFindUsersInBounds_ReturnsUsersWithinTheBox(t *testing.T) {
+	svc, locations := newLocationTestService(t)
+	users := svc.users.(*countingUserRepository)
+
+	users.CreateUser(context.Background(), &models.User{Username: "inside"})
+	users.CreateUser(context.Background(), &models.User{Username: "outside"})
+	locations.put(models.UserLocation{UserID: 1, Label: models.CurrentLocationLabel, Latitude: 15, Longitude: 15})
+	locations.put(models.UserLocation{UserID: 2, Label: models.CurrentLocationLabel, Latitude: 45, Longitude: 45})
+
+	resp, err := svc.FindUsersInBounds(context.Background(), &userpb.FindUsersInBoundsRequest{
+		MinLat: 10, MaxLat: 20, MinLon: 10, MaxLon: 20,
+	})
+	if err != nil {
+		t.Fatalf("FindUsersInBounds: %v", err)
+	}
+	if len(resp.Users) != 1 || resp.Users[0].Id != 1 {
+		t.Fatalf("expected only the user inside the box, got %+v", resp.Users)
+	}
+}
+
+func TestFindUsersInBounds_HandlesAnAntimeridianCrossingBox(t *testing.T) {
+	svc, locations := newLocationTestService(t)
+	users := svc.users.(*countingUserRepository)
+
+	users.CreateUser(context.Background(), &models.User{Username: "east-of-antimeridian"})
+	users.CreateUser(context.Background(), &models.User{Username: "middle-of-the-map"})
+	locations.put(models.UserLocation{UserID: 1, Label: models.CurrentLocationLabel, Latitude: 0, Longitude: 175})
+	locations.put(models.UserLocation{UserID: 2, Label: models.CurrentLocationLabel, Latitude: 0, Longitude: 0})
+
+	resp, err := svc.FindUsersInBounds(context.Background(), &userpb.FindUsersInBoundsRequest{
+		MinLat: -10, MaxLat: 10, MinLon: 170, MaxLon: -170,
+	})
+	if err != nil {
+		t.Fatalf("FindUsersInBounds: %v", err)
+	}
+	if len(resp.Users) != 1 || resp.Users[0].Id != 1 {
+		t.Fatalf("expected only the user east of the antimeridian, got %+v", resp.Users)
+	}
+}
+
+func TestUpdateUserLocation_NormalizesCountryName(t *testing.T) {
+	svc, locations := newLocationTestService(t)
+
+	resp, err := svc.UpdateUserLocation(context.Background(), &userpb.UpdateUserLocationRequest{
+		UserId: 1, Latitude: 55.75, Longitude: 37.62, Country: "Russia",
+	})
+	if err != nil {
+		t.Fatalf("UpdateUserLocation: %v", err)
+	}
+	if resp.Country != "RU" {
+		t.Fatalf(`expected "Russia" to normalize to "RU", got %q`, resp.Country)
+	}
+	if got := locations.byUser[1].Country; got != "RU" {
+		t.Fatalf("expected the stored country to be normalized, got %q", got)
+	}
+}
+
+func TestUpdateUserLocation_RejectsUnknownCountry(t *testing.T) {
+	svc, _ := newLocationTestService(t)
+
+	_, err := svc.UpdateUserLocation(context.Background(), &userpb.UpdateUserLocationRequest{
+		UserId: 1, Latitude: 0, Longitude: 0, Country: "Narnia",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an unrecognized country, got %v", err)
+	}
+}
+
+func TestUpdateUserLocation_RejectsAnInvalidCoordinate(t *testing.T) {
+	svc, _ := newLocationTestService(t)
+
+	_, err := svc.UpdateUserLocation(context.Background(), &userpb.UpdateUserLocationRequest{
+		UserId: 1, Latitude: 999, Longitude: 0, Country: "Russia",
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an out-of-range latitude, got %v", err)
+	}
+}
+
+func TestUpdateUserLocation_SubThresholdMoveIsDebouncedFromPostgres(t *testing.T) {
+	svc, locations := newLocationTestServiceWithDebounce(t, LocationDebounceConfig{
+		MinDistanceMeters: 1000,
+		MinInterval:       time.Hour,
+	})
+
+	first := &userpb.UpdateUserLocationRequest{UserId: 1, Latitude: 55.7500, Longitude: 37.6200, Country: "Russia"}
+	if _, err := svc.UpdateUserLocation(context.Background(), first); err != nil {
+		t.Fatalf("first UpdateUserLocation: %v", err)
+	}
+	if got := atomic.LoadUint32(&locations.upsertCalls); got != 1 {
+		t.Fatalf("expected the first ping to persist immediately, got %d writes", got)
+	}
+
+	// A tiny move (well under 1000m) should be debounced: the fast-path
+	// cache updates, but Postgres isn't touched again.
+	second := &userpb.UpdateUserLocationRequest{UserId: 1, Latitude: 55.7501, Longitude: 37.6201, Country: "Russia"}
+	if _, err := svc.UpdateUserLocation(context.Background(), second); err != nil {
+		t.Fatalf("second UpdateUserLocation: %v", err)
+	}
+	if got := atomic.LoadUint32(&locations.upsertCalls); got != 1 {
+		t.Fatalf("expected the sub-threshold move to be debounced, got %d writes", got)
+	}
+
+	dirty, err := svc.cache.ListDirtyLocationUserIDs(context.Background())
+	if err != nil {
+		t.Fatalf("ListDirtyLocationUserIDs: %v", err)
+	}
+	if len(dirty) != 1 || dirty[0] != 1 {
+		t.Fatalf("expected user 1 to be marked dirty pending a flush, got %v", dirty)
+	}
+}
+
+func TestUpdateUserLocation_LargeMoveIsPersistedImmediately(t *testing.T) {
+	svc, locations := newLocationTestServiceWithDebounce(t, LocationDebounceConfig{
+		MinDistanceMeters: 1000,
+		MinInterval:       time.Hour,
+	})
+
+	first := &userpb.UpdateUserLocationRequest{UserId: 1, Latitude: 55.75, Longitude: 37.62, Country: "Russia"}
+	if _, err := svc.UpdateUserLocation(context.Background(), first); err != nil {
+		t.Fatalf("first UpdateUserLocation: %v", err)
+	}
+
+	// Moscow to a point roughly 40km away comfortably clears the 1000m
+	// threshold, so this ping should be persisted right away.
+	second := &userpb.UpdateUserLocationRequest{UserId: 1, Latitude: 56.10, Longitude: 37.62, Country: "Russia"}
+	if _, err := svc.UpdateUserLocation(context.Background(), second); err != nil {
+		t.Fatalf("second UpdateUserLocation: %v", err)
+	}
+	if got := atomic.LoadUint32(&locations.upsertCalls); got != 2 {
+		t.Fatalf("expected the large move to be persisted immediately, got %d writes", got)
+	}
+
+	dirty, err := svc.cache.ListDirtyLocationUserIDs(context.Background())
+	if err != nil {
+		t.Fatalf("ListDirtyLocationUserIDs: %v", err)
+	}
+	if len(dirty) != 0 {
+		t.Fatalf("expected no pending dirty locations after an immediate persist, got %v", dirty)
+	}
+}
+
+func TestFlushAllPendingLocations_PersistsDebouncedPings(t *testing.T) {
+	svc, locations := newLocationTestServiceWithDebounce(t, LocationDebounceConfig{
+		MinDistanceMeters: 1000,
+		MinInterval:       time.Hour,
+	})
+
+	if _, err := svc.UpdateUserLocation(context.Background(), &userpb.UpdateUserLocationRequest{
+		UserId: 1, Latitude: 55.7500, Longitude: 37.6200, Country: "Russia",
+	}); err != nil {
+		t.Fatalf("first UpdateUserLocation: %v", err)
+	}
+	if _, err := svc.UpdateUserLocation(context.Background(), &userpb.UpdateUserLocationRequest{
+		UserId: 1, Latitude: 55.7501, Longitude: 37.6201, Country: "Russia",
+	}); err != nil {
+		t.Fatalf("second UpdateUserLocation: %v", err)
+	}
+	if got := atomic.LoadUint32(&locations.upsertCalls); got != 1 {
+		t.Fatalf("expected the debounced move to still be unpersisted, got %d writes", got)
+	}
+
+	if err := svc.FlushAllPendingLocations(context.Background()); err != nil {
+		t.Fatalf("FlushAllPendingLocations: %v", err)
+	}
+	if got := atomic.LoadUint32(&locations.upsertCalls); got != 2 {
+		t.Fatalf("expected the shutdown flush to persist the pending ping, got %d writes", got)
+	}
+	if got := locations.byUser[1].Latitude; got != 55.7501 {
+		t.Fatalf("expected the flush to persist the latest fast-path position, got %v", got)
+	}
+
+	dirty, err := svc.cache.ListDirtyLocationUserIDs(context.Background())
+	if err != nil {
+		t.Fatalf("ListDirtyLocationUserIDs: %v", err)
+	}
+	if len(dirty) != 0 {
+		t.Fatalf("expected no pending dirty locations after the flush, got %v", dirty)
+	}
+}
+
+func TestBatchUpdateLocations_SkipsInvalidEntriesButWritesTheRest(t *testing.T) {
+	svc, locations := newLocationTestService(t)
+
+	resp, err := svc.BatchUpdateLocations(context.Background(), &userpb.BatchUpdateLocationsRequest{
+		Locations: []*userpb.UpdateUserLocationRequest{
+			{UserId: 1, Latitude: 55.75, Longitude: 37.62, Country: "Russia"},
+			{UserId: 2, Latitude: 999, Longitude: 0, Country: "France"},
+			{UserId: 3, Latitude: 48.85, Longitude: 2.35, Country: "Narnia"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("BatchUpdateLocations: %v", err)
+	}
+	if len(resp.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(resp.Results))
+	}
+
+	if !resp.Results[0].Success {
+		t.Fatalf("expected user 1 to succeed, got %+v", resp.Results[0])
+	}
+	if resp.Results[1].Success || resp.Results[1].Error == "" {
+		t.Fatalf("expected user 2 to be reported as an invalid coordinate, got %+v", resp.Results[1])
+	}
+	if resp.Results[2].Success || resp.Results[2].Error == "" {
+		t.Fatalf("expected user 3 to be reported as an unrecognized country, got %+v", resp.Results[2])
+	}
+
+	if got := locations.byUser[1].Country; got != "RU" {
+		t.Fatalf("expected user 1's location to be stored, got %+v", locations.byUser[1])
+	}
+	if _, stored := locations.byUser[2]; stored {
+		t.Fatalf("expected user 2's invalid entry to be skipped")
+	}
+	if _, stored := locations.byUser[3]; stored {
+		t.Fatalf("expected user 3's invalid entry to be skipped")
+	}
+}
+
+func TestUpdateUserLocation_LabelsAreStoredIndependently(t *testing.T) {
+	svc, locations := newLocationTestService(t)
+
+	if _, err := svc.UpdateUserLocation(context.Background(), &userpb.UpdateUserLocationRequest{
+		UserId: 1, Latitude: 55.75, Longitude: 37.62, Country: "Russia",
+	}); err != nil {
+		t.Fatalf("update current: %v", err)
+	}
+	if _, err := svc.UpdateUserLocation(context.Background(), &userpb.UpdateUserLocationRequest{
+		UserId: 1, Latitude: 51.50, Longitude: -0.12, Country: "United Kingdom", Label: "home",
+	}); err != nil {
+		t.Fatalf("update home: %v", err)
+	}
+	if _, err := svc.UpdateUserLocation(context.Background(), &userpb.UpdateUserLocationRequest{
+		UserId: 1, Latitude: 48.85, Longitude: 2.35, Country: "France", Label: "work",
+	}); err != nil {
+		t.Fatalf("update work: %v", err)
+	}
+
+	if got := locations.byLabel[1]["current"].Country; got != "RU" {
+		t.Fatalf("expected current label to hold RU, got %q", got)
+	}
+	if got := locations.byLabel[1]["home"].Country; got != "GB" {
+		t.Fatalf("expected home label to hold GB, got %q", got)
+	}
+	if got := locations.byLabel[1]["work"].Country; got != "FR" {
+		t.Fatalf("expected work label to hold FR, got %q", got)
+	}
+}
+
+func TestGetUserLocations_ReturnsEveryLabel(t *testing.T) {
+	svc, _ := newLocationTestService(t)
+
+	for _, req := range []*userpb.UpdateUserLocationRequest{
+		{UserId: 1, Latitude: 55.75, Longitude: 37.62, Country: "Russia"},
+		{UserId: 1, Latitude: 51.50, Longitude: -0.12, Country: "United Kingdom", Label: "home"},
+	} {
+		if _, err := svc.UpdateUserLocation(context.Background(), req); err != nil {
+			t.Fatalf("UpdateUserLocation: %v", err)
+		}
+	}
+
+	resp, err := svc.GetUserLocations(context.Background(), &userpb.GetUserLocationsRequest{UserId: 1})
+	if err != nil {
+		t.Fatalf("GetUserLocations: %v", err)
+	}
+	if len(resp.Locations) != 2 {
+		t.Fatalf("expected 2 labeled locations, got %d", len(resp.Locations))
+	}
+	byLabel := map[string]*userpb.UserLocationEntry{}
+	for _, entry := range resp.Locations {
+		byLabel[entry.Label] = entry
+	}
+	if byLabel["current"] == nil || byLabel["current"].Country != "RU" {
+		t.Fatalf("expected a current entry with country RU, got %+v", byLabel["current"])
+	}
+	if byLabel["home"] == nil || byLabel["home"].Country != "GB" {
+		t.Fatalf("expected a home entry with country GB, got %+v", byLabel["home"])
+	}
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// errMaintenanceMode is returned by mutating handlers while the service
+// is in maintenance mode.
+var errMaintenanceMode = status.Error(codes.Unavailable, "service is in maintenance mode: writes are temporarily disabled")
+
+// MaintenanceMode is a process-wide, atomically toggled flag that puts
+// the service into read-only mode: mutating RPCs reject with
+// codes.Unavailable while reads continue to be served. It is meant to
+// be shared (via a single pointer) between every service that gates
+// writes on it and whatever exposes it for toggling (an admin RPC,
+// SIGUSR1, etc.).
+type MaintenanceMode struct {
+	enabled atomic.Bool
+}
+
+// NewMaintenanceMode constructs a MaintenanceMode that starts disabled.
+func NewMaintenanceMode() *MaintenanceMode {
+	return &MaintenanceMode{}
+}
+
+// Enabled reports whether the service is currently in maintenance mode.
+func (m *MaintenanceMode) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// SetEnabled toggles maintenance mode on or off.
+func (m *MaintenanceMode) SetEnabled(enabled bool) {
+	m.enabled.Store(enabled)
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+func newMaintenanceTestService(t *testing.T) (*UserService, *MaintenanceMode) {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	maintenance := NewMaintenanceMode()
+	repo := &countingUserRepository{banned: map[uint]bool{}}
+	prefs := &fakePreferenceRepository{byUser: map[uint][]string{}}
+	svc := NewUserService(repo, prefs, cache, zap.NewNop(), 0, GeoLimits{}, maintenance, nil, LocationDebounceConfig{}, FeatureFlagConfig{}, 0, false)
+	return svc, maintenance
+}
+
+func TestCreateUser_RejectedInMaintenanceMode(t *testing.T) {
+	svc, maintenance := newMaintenanceTestService(t)
+	maintenance.SetEnabled(true)
+
+	_, err := svc.CreateUser(context.Background(), &userpb.CreateUserRequest{Username: "stowaway"})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable while in maintenance mode, got %v", err)
+	}
+}
+
+func TestAddUserPreference_RejectedInMaintenanceMode(t *testing.T) {
+	svc, maintenance := newMaintenanceTestService(t)
+	maintenance.SetEnabled(true)
+
+	_, err := svc.AddUserPreference(context.Background(), &userpb.AddUserPreferenceRequest{UserId: 1, Tag: "rum"})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable while in maintenance mode, got %v", err)
+	}
+}
+
+func TestGetUser_StillServedInMaintenanceMode(t *testing.T) {
+	svc, maintenance := newMaintenanceTestService(t)
+	maintenance.SetEnabled(true)
+
+	resp, err := svc.GetUser(context.Background(), &userpb.GetUserRequest{UserId: 1})
+	if err != nil {
+		t.Fatalf("expected reads to keep working during maintenance mode, got %v", err)
+	}
+	if resp.Id != 1 {
+		t.Fatalf("unexpected user: %+v", resp)
+	}
+}
+
+func TestCreateUser_SucceedsOnceMaintenanceModeDisabled(t *testing.T) {
+	svc, maintenance := newMaintenanceTestService(t)
+	maintenance.SetEnabled(true)
+	maintenance.SetEnabled(false)
+
+	_, err := svc.CreateUser(context.Background(), &userpb.CreateUserRequest{Username: "returning-crew", TelegramId: 99})
+	if err != nil {
+		t.Fatalf("expected CreateUser to succeed once maintenance mode is disabled, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+)
+
+// defaultOutboxRelayInterval and defaultOutboxRelayBatchSize are the
+// OutboxRelayConfig used when NewOutboxRelay is given a zero-valued
+// OutboxRelayConfig.
+const (
+	defaultOutboxRelayInterval  = 5 * time.Second
+	defaultOutboxRelayBatchSize = 100
+)
+
+// OutboxRelayConfig controls how often, and how many rows at a time,
+// OutboxRelay publishes unsent outbox events.
+type OutboxRelayConfig struct {
+	Interval  time.Duration
+	BatchSize int
+}
+
+// withDefaults fills in defaultOutboxRelayInterval/
+// defaultOutboxRelayBatchSize for any field left at its zero value.
+func (c OutboxRelayConfig) withDefaults() OutboxRelayConfig {
+	if c.Interval <= 0 {
+		c.Interval = defaultOutboxRelayInterval
+	}
+	if c.BatchSize <= 0 {
+		c.BatchSize = defaultOutboxRelayBatchSize
+	}
+	return c
+}
+
+// OutboxRelay periodically publishes outbox rows that haven't been sent
+// yet to Redis, on a channel named after each row's Topic, and marks
+// each one published immediately after a successful publish. This gives
+// domain events at-least-once delivery: a crash between a row's commit
+// and its publish just leaves it unpublished for the next tick to pick
+// up, rather than losing it the way publishing directly after commit
+// would.
+type OutboxRelay struct {
+	outbox repository.OutboxRepository
+	client *redis.Client
+	log    *zap.Logger
+	cfg    OutboxRelayConfig
+}
+
+// NewOutboxRelay constructs an OutboxRelay. A zero-valued cfg falls back
+// to OutboxRelayConfig's own defaults.
+func NewOutboxRelay(outbox repository.OutboxRepository, client *redis.Client, log *zap.Logger, cfg OutboxRelayConfig) *OutboxRelay {
+	return &OutboxRelay{outbox: outbox, client: client, log: log, cfg: cfg.withDefaults()}
+}
+
+// Run relays every cfg.Interval until ctx is canceled, so it can be
+// started as a shutdown-aware background goroutine alongside the gRPC
+// server: canceling ctx (e.g. during graceful shutdown) stops it
+// cleanly instead of leaking the goroutine.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.RelayOnce(ctx)
+		}
+	}
+}
+
+// RelayOnce publishes up to cfg.BatchSize unpublished outbox rows to
+// Redis, oldest first, marking each one published right after its
+// publish succeeds. It stops at the first failure rather than skipping
+// ahead, so a Redis blip retries from the same row next tick instead of
+// publishing out of order. It returns the number of rows relayed.
+func (r *OutboxRelay) RelayOnce(ctx context.Context) int {
+	events, err := r.outbox.ListUnpublished(ctx, r.cfg.BatchSize)
+	if err != nil {
+		r.log.Warn("outbox relay: list unpublished failed", zap.Error(err))
+		return 0
+	}
+
+	relayed := 0
+	for _, event := range events {
+		if err := r.client.Publish(ctx, event.Topic, event.Payload).Err(); err != nil {
+			r.log.Warn("outbox relay: publish failed, will retry", zap.Uint("event_id", event.ID), zap.Error(err))
+			break
+		}
+		if err := r.outbox.MarkPublished(ctx, event.ID); err != nil {
+			r.log.Warn("outbox relay: mark published failed", zap.Uint("event_id", event.ID), zap.Error(err))
+			break
+		}
+		relayed++
+	}
+	return relayed
+}
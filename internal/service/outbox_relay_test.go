@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+)
+
+// fakeOutboxRepository is an in-memory stand-in for
+// repository.OutboxRepository, standing in for the same-transaction
+// write PostgresUserRepository.CreateUser does against a real Postgres
+// (not something this sandbox's sqlite-only test setup can exercise).
+// It captures which rows have been marked published so tests can assert
+// on the relay's behavior against them.
+type fakeOutboxRepository struct {
+	mu     sync.Mutex
+	events []models.OutboxEvent
+}
+
+func (r *fakeOutboxRepository) ListUnpublished(_ context.Context, limit int) ([]models.OutboxEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unpublished []models.OutboxEvent
+	for _, e := range r.events {
+		if e.PublishedAt == nil {
+			unpublished = append(unpublished, e)
+		}
+		if len(unpublished) == limit {
+			break
+		}
+	}
+	return unpublished, nil
+}
+
+func (r *fakeOutboxRepository) MarkPublished(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	for i := range r.events {
+		if r.events[i].ID == id {
+			r.events[i].PublishedAt = &now
+			return nil
+		}
+	}
+	return nil
+}
+
+func (r *fakeOutboxRepository) isPublished(id uint) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, e := range r.events {
+		if e.ID == id {
+			return e.PublishedAt != nil
+		}
+	}
+	return false
+}
+
+func TestOutboxRelay_RelayOncePublishesAndMarksAnUnsentEvent(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	sub := client.Subscribe(context.Background(), "user.created")
+	defer sub.Close()
+
+	// The same row a UserRepository.CreateUser transaction would have
+	// written alongside the user insert.
+	outbox := &fakeOutboxRepository{events: []models.OutboxEvent{
+		{ID: 1, Topic: "user.created", Payload: `{"id":1,"username":"blackbeard"}`},
+	}}
+	relay := NewOutboxRelay(outbox, client, zap.NewNop(), OutboxRelayConfig{})
+
+	relayed := relay.RelayOnce(context.Background())
+	if relayed != 1 {
+		t.Fatalf("expected 1 event relayed, got %d", relayed)
+	}
+	if !outbox.isPublished(1) {
+		t.Fatalf("expected the event to be marked published after a successful relay")
+	}
+
+	select {
+	case msg := <-sub.Channel():
+		if msg.Payload != `{"id":1,"username":"blackbeard"}` {
+			t.Fatalf("unexpected published payload: %q", msg.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("expected the relay to publish the event on its topic")
+	}
+}
+
+func TestOutboxRelay_RelayOnceSkipsAnAlreadyPublishedEvent(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	published := time.Now()
+	outbox := &fakeOutboxRepository{events: []models.OutboxEvent{
+		{ID: 1, Topic: "user.created", Payload: `{"id":1}`, PublishedAt: &published},
+	}}
+	relay := NewOutboxRelay(outbox, client, zap.NewNop(), OutboxRelayConfig{})
+
+	if relayed := relay.RelayOnce(context.Background()); relayed != 0 {
+		t.Fatalf("expected 0 events relayed when everything is already published, got %d", relayed)
+	}
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+// GetUserProfile aggregates a user's profile from several independent
+// sub-reads. The user section is mandatory: a failure there fails the
+// whole call. Locations, preference tags and rating history (this
+// service has no separate "user stats" concept, so rating history
+// stands in for it) are optional - a failed optional read is dropped
+// from the response and recorded in PartialErrors, rather than failing
+// a call that could otherwise still be useful to the caller.
+func (s *UserService) GetUserProfile(ctx context.Context, req *userpb.GetUserProfileRequest) (*userpb.GetUserProfileResponse, error) {
+	user, err := s.GetUser(ctx, &userpb.GetUserRequest{UserId: req.UserId})
+	if err != nil {
+		return nil, err
+	}
+	userID := uint(req.UserId)
+
+	var (
+		mu            sync.Mutex
+		partialErrors []string
+		prefTags      []string
+		locations     []*userpb.UserLocationEntry
+		ratingHistory []*userpb.RatingEvent
+	)
+	recordFailure := func(section string, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		partialErrors = append(partialErrors, fmt.Sprintf("%s: %v", section, err))
+	}
+
+	var g errgroup.Group
+	g.Go(func() error {
+		prefs, err := s.prefs.ListPreferences(ctx, userID)
+		if err != nil {
+			recordFailure("preferences", err)
+			return nil
+		}
+		tags := make([]string, len(prefs))
+		for i, p := range prefs {
+			tags[i] = p.Tag
+		}
+		mu.Lock()
+		prefTags = tags
+		mu.Unlock()
+		return nil
+	})
+	g.Go(func() error {
+		locs, err := s.locations.GetUserLocations(ctx, userID)
+		if err != nil {
+			recordFailure("location", err)
+			return nil
+		}
+		entries := make([]*userpb.UserLocationEntry, len(locs))
+		for i, loc := range locs {
+			entries[i] = &userpb.UserLocationEntry{
+				Label:     loc.Label,
+				Latitude:  loc.Latitude,
+				Longitude: loc.Longitude,
+				Country:   loc.Country,
+				UpdatedAt: loc.UpdatedAt.UTC().Format(time.RFC3339),
+			}
+		}
+		mu.Lock()
+		locations = entries
+		mu.Unlock()
+		return nil
+	})
+	g.Go(func() error {
+		history, err := s.users.GetRatingHistory(ctx, userID, defaultRatingHistoryLimit)
+		if err != nil {
+			recordFailure("stats", err)
+			return nil
+		}
+		events := make([]*userpb.RatingEvent, len(history))
+		for i, e := range history {
+			events[i] = &userpb.RatingEvent{
+				RaterId:   uint64(e.RaterID),
+				Score:     e.Score,
+				CreatedAt: e.CreatedAt.UTC().Format(time.RFC3339),
+			}
+		}
+		mu.Lock()
+		ratingHistory = events
+		mu.Unlock()
+		return nil
+	})
+	// Every goroutine above swallows its own error into partialErrors, so
+	// Wait never actually returns one - it's only here to block until
+	// all three sections have finished.
+	_ = g.Wait()
+
+	return &userpb.GetUserProfileResponse{
+		User:           user,
+		Locations:      locations,
+		PreferenceTags: prefTags,
+		RatingHistory:  ratingHistory,
+		PartialErrors:  partialErrors,
+	}, nil
+}
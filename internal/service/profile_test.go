@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+func TestGetUserProfile_AggregatesAllSections(t *testing.T) {
+	svc, users, prefs := newTestServiceWithPrefs(t)
+	locations := &fakeLocationRepository{byUser: map[uint]models.UserLocation{}}
+	svc.locations = locations
+
+	users.CreateUser(context.Background(), &models.User{Username: "blackbeard"})
+	prefs.byUser[1] = []string{"night-owl"}
+	locations.put(models.UserLocation{UserID: 1, Label: models.CurrentLocationLabel, Country: "Tortuga"})
+	users.ratingHistory = map[uint][]models.UserRatingEvent{1: {{RaterID: 2, Score: 5}}}
+
+	resp, err := svc.GetUserProfile(context.Background(), &userpb.GetUserProfileRequest{UserId: 1})
+	if err != nil {
+		t.Fatalf("GetUserProfile: %v", err)
+	}
+	if resp.User == nil || resp.User.Username != "blackbeard" {
+		t.Fatalf("expected the mandatory user section to be populated, got %+v", resp.User)
+	}
+	if len(resp.Locations) != 1 || len(resp.PreferenceTags) != 1 || len(resp.RatingHistory) != 1 {
+		t.Fatalf("expected all optional sections populated, got %+v", resp)
+	}
+	if len(resp.PartialErrors) != 0 {
+		t.Fatalf("expected no partial errors, got %v", resp.PartialErrors)
+	}
+}
+
+func TestGetUserProfile_ReturnsPartiallyWhenStatsSectionFails(t *testing.T) {
+	svc, users, prefs := newTestServiceWithPrefs(t)
+	locations := &fakeLocationRepository{byUser: map[uint]models.UserLocation{}}
+	svc.locations = locations
+
+	users.CreateUser(context.Background(), &models.User{Username: "anne-bonny"})
+	prefs.byUser[1] = []string{"rum"}
+	users.ratingHistoryErr = errors.New("rating history unavailable")
+
+	resp, err := svc.GetUserProfile(context.Background(), &userpb.GetUserProfileRequest{UserId: 1})
+	if err != nil {
+		t.Fatalf("expected the overall call to succeed despite the stats section failing, got %v", err)
+	}
+	if resp.User == nil || resp.User.Username != "anne-bonny" {
+		t.Fatalf("expected the mandatory user section to still be populated, got %+v", resp.User)
+	}
+	if resp.RatingHistory != nil {
+		t.Fatalf("expected the failed stats section to be dropped, got %v", resp.RatingHistory)
+	}
+	if len(resp.PreferenceTags) != 1 {
+		t.Fatalf("expected the unrelated preferences section to still be populated, got %v", resp.PreferenceTags)
+	}
+	if len(resp.PartialErrors) != 1 {
+		t.Fatalf("expected exactly one partial error, got %v", resp.PartialErrors)
+	}
+}
+
+func TestGetUserProfile_FailsEntirelyWhenTheUserSectionFails(t *testing.T) {
+	svc, users := newTestService(t)
+	users.CreateUser(context.Background(), &models.User{Username: "banned-user"})
+	users.SetBanned(context.Background(), 1, true)
+
+	_, err := svc.GetUserProfile(context.Background(), &userpb.GetUserProfileRequest{UserId: 1})
+	if err == nil {
+		t.Fatalf("expected the mandatory user section failing to fail the whole call")
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+)
+
+// defaultStatsRefreshInterval is the StatsRefresherConfig used when
+// NewStatsRefresher is given a zero-valued config.
+const defaultStatsRefreshInterval = 1 * time.Minute
+
+// StatsRefresherConfig controls how often StatsRefresher recomputes the
+// service-wide user count.
+type StatsRefresherConfig struct {
+	Interval time.Duration
+}
+
+// withDefaults fills in defaultStatsRefreshInterval if Interval was left
+// at its zero value.
+func (c StatsRefresherConfig) withDefaults() StatsRefresherConfig {
+	if c.Interval <= 0 {
+		c.Interval = defaultStatsRefreshInterval
+	}
+	return c
+}
+
+// StatsRefresher periodically recomputes the service-wide user count
+// and stores it in the cache, so a read never has to fall through to a
+// full table count itself - it just gets whatever StatsRefresher last
+// wrote, even the instant a previous cached value expires.
+type StatsRefresher struct {
+	users repository.UserRepository
+	cache *repository.ResilientCacheRepository
+	log   *zap.Logger
+	cfg   StatsRefresherConfig
+}
+
+// NewStatsRefresher constructs a StatsRefresher. A zero-valued cfg falls
+// back to StatsRefresherConfig's own defaults.
+func NewStatsRefresher(users repository.UserRepository, cache *repository.ResilientCacheRepository, log *zap.Logger, cfg StatsRefresherConfig) *StatsRefresher {
+	return &StatsRefresher{users: users, cache: cache, log: log, cfg: cfg.withDefaults()}
+}
+
+// Run recomputes the cached user count every cfg.Interval until ctx is
+// canceled, so it can be started as a shutdown-aware background
+// goroutine alongside the gRPC server: canceling ctx (e.g. during
+// graceful shutdown) stops it cleanly instead of leaking the goroutine.
+func (r *StatsRefresher) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.refreshOnce(ctx)
+		}
+	}
+}
+
+// refreshOnce counts every user row and stores the result in the cache.
+func (r *StatsRefresher) refreshOnce(ctx context.Context) {
+	count, err := r.users.CountUsers(ctx)
+	if err != nil {
+		r.log.Warn("stats refresh: failed to count users", zap.Error(err))
+		return
+	}
+	if err := r.cache.SetServiceUserCount(ctx, count); err != nil {
+		r.log.Warn("stats refresh: failed to cache user count", zap.Error(err))
+	}
+}
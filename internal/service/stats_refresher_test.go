@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+)
+
+func TestStatsRefresher_UpdatesTheCachedCountOnEachTickWithoutAReadTriggeringIt(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	users := &countingUserRepository{banned: map[uint]bool{}, userCount: 3}
+
+	refresher := NewStatsRefresher(users, cache, zap.NewNop(), StatsRefresherConfig{Interval: 10 * time.Millisecond})
+
+	if _, found, err := cache.GetServiceUserCount(context.Background()); err != nil {
+		t.Fatalf("GetServiceUserCount: %v", err)
+	} else if found {
+		t.Fatalf("expected no cached count before the refresher has ticked")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		refresher.Run(ctx)
+		close(done)
+	}()
+
+	deadline := time.After(time.Second)
+	for {
+		count, found, err := cache.GetServiceUserCount(context.Background())
+		if err != nil {
+			t.Fatalf("GetServiceUserCount: %v", err)
+		}
+		if found {
+			if count != 3 {
+				t.Fatalf("expected the cached count to be 3, got %d", count)
+			}
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("timed out waiting for StatsRefresher to populate the cache")
+		case <-time.After(5 * time.Millisecond):
+		}
+	}
+
+	cancel()
+	<-done
+
+	users.userCount = 9
+	time.Sleep(30 * time.Millisecond)
+	if count, _, _ := cache.GetServiceUserCount(context.Background()); count != 3 {
+		t.Fatalf("expected the count to stay at 3 after Run stopped, got %d", count)
+	}
+}
@@ -0,0 +1,187 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+// downUserRepository simulates a database that is unreachable: every
+// call fails with repository.ErrUnavailable.
+type downUserRepository struct{}
+
+func (downUserRepository) CreateUser(context.Context, *models.User) error {
+	return repository.ErrUnavailable
+}
+func (downUserRepository) CreateUserWithOnboarding(context.Context, *models.User, []string, *models.UserLocation) error {
+	return repository.ErrUnavailable
+}
+func (downUserRepository) GetUserByID(context.Context, uint) (*models.User, error) {
+	return nil, repository.ErrUnavailable
+}
+func (downUserRepository) GetActiveUserByID(context.Context, uint) (*models.User, error) {
+	return nil, repository.ErrUnavailable
+}
+func (downUserRepository) GetUsersByTelegramIDs(context.Context, []int64) (map[int64]*models.User, error) {
+	return nil, repository.ErrUnavailable
+}
+func (downUserRepository) SetBanned(context.Context, uint, bool) error { return repository.ErrUnavailable }
+func (downUserRepository) ChangeTelegramID(context.Context, uint, int64) error {
+	return repository.ErrUnavailable
+}
+func (downUserRepository) UpdateUsername(context.Context, uint, string) error {
+	return repository.ErrUnavailable
+}
+func (downUserRepository) CountUsers(context.Context) (int64, error) {
+	return 0, repository.ErrUnavailable
+}
+func (downUserRepository) UpdateLastActive(context.Context, uint) error {
+	return repository.ErrUnavailable
+}
+func (downUserRepository) DeleteUser(context.Context, uint) error      { return repository.ErrUnavailable }
+func (downUserRepository) ListUsers(context.Context, uint, int, bool) ([]models.User, error) {
+	return nil, repository.ErrUnavailable
+}
+func (downUserRepository) UpdateUserRating(context.Context, uint, float64, uint) error {
+	return repository.ErrUnavailable
+}
+func (downUserRepository) RecomputeUserRating(context.Context, uint) error {
+	return repository.ErrUnavailable
+}
+func (downUserRepository) GetRatingHistory(context.Context, uint, int) ([]models.UserRatingEvent, error) {
+	return nil, repository.ErrUnavailable
+}
+func (downUserRepository) DeleteRatingHistory(context.Context, uint) error {
+	return repository.ErrUnavailable
+}
+func (downUserRepository) UserExists(context.Context, uint) (bool, error) {
+	return false, repository.ErrUnavailable
+}
+func (downUserRepository) UserExistsByTelegramID(context.Context, int64) (bool, error) {
+	return false, repository.ErrUnavailable
+}
+func (downUserRepository) GetUserFeatures(context.Context, uint) (models.FeatureFlags, error) {
+	return nil, repository.ErrUnavailable
+}
+func (downUserRepository) SetUserFeature(context.Context, uint, string, bool) error {
+	return repository.ErrUnavailable
+}
+
+func TestCreateUser_DBUnavailableMapsToUnavailableCode(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	svc := NewUserService(downUserRepository{}, &fakePreferenceRepository{byUser: map[uint][]string{}}, cache, zap.NewNop(), 0, GeoLimits{}, nil, nil, LocationDebounceConfig{}, FeatureFlagConfig{}, 0, false)
+
+	_, err = svc.CreateUser(context.Background(), &userpb.CreateUserRequest{TelegramId: 1, Username: "ghost-ship"})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable when the user store is down, got %v", err)
+	}
+}
+
+func TestGetUser_ServedFromCacheWhenDBUnavailable(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	svc := NewUserService(downUserRepository{}, &fakePreferenceRepository{byUser: map[uint][]string{}}, cache, zap.NewNop(), 0, GeoLimits{}, nil, nil, LocationDebounceConfig{}, FeatureFlagConfig{}, 0, false)
+
+	if err := cache.SetUser(context.Background(), &models.User{ID: 1, Username: "cached-captain"}); err != nil {
+		t.Fatalf("seed cache: %v", err)
+	}
+
+	resp, err := svc.GetUser(context.Background(), &userpb.GetUserRequest{UserId: 1})
+	if err != nil {
+		t.Fatalf("expected a cache hit to succeed despite the db being down, got %v", err)
+	}
+	if resp.Username != "cached-captain" {
+		t.Fatalf("unexpected user: %+v", resp)
+	}
+}
+
+func TestGetUser_DBUnavailableOnCacheMissMapsToUnavailableCode(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	svc := NewUserService(downUserRepository{}, &fakePreferenceRepository{byUser: map[uint][]string{}}, cache, zap.NewNop(), 0, GeoLimits{}, nil, nil, LocationDebounceConfig{}, FeatureFlagConfig{}, 0, false)
+
+	_, err = svc.GetUser(context.Background(), &userpb.GetUserRequest{UserId: 1})
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable on a cache miss against a down db, got %v", err)
+	}
+}
+
+func TestGetUser_ServedFromStaleCacheWhenDBUnavailableAndFreshCacheMisses(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	svc := NewUserService(downUserRepository{}, &fakePreferenceRepository{byUser: map[uint][]string{}}, cache, zap.NewNop(), 0, GeoLimits{}, nil, nil, LocationDebounceConfig{}, FeatureFlagConfig{}, 0, false)
+
+	if err := cache.SetStaleUser(context.Background(), &models.User{ID: 1, Username: "old-quartermaster"}); err != nil {
+		t.Fatalf("seed stale cache: %v", err)
+	}
+
+	resp, err := svc.GetUser(context.Background(), &userpb.GetUserRequest{UserId: 1})
+	if err != nil {
+		t.Fatalf("expected a stale cache hit to succeed despite the db being down, got %v", err)
+	}
+	if resp.Username != "old-quartermaster" {
+		t.Fatalf("unexpected user: %+v", resp)
+	}
+	if !resp.Stale {
+		t.Fatalf("expected Stale to be true when served from the stale fallback")
+	}
+}
+
+func TestCheckCacheHealth_ReportsRedisDownWhileDBReadsStillWork(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	cache := repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0)
+	svc := NewUserService(&countingUserRepository{}, &fakePreferenceRepository{byUser: map[uint][]string{}}, cache, zap.NewNop(), 0, GeoLimits{}, nil, nil, LocationDebounceConfig{}, FeatureFlagConfig{}, 0, false)
+
+	if err := svc.CheckCacheHealth(context.Background()); err != nil {
+		t.Fatalf("expected CheckCacheHealth to succeed while redis is up: %v", err)
+	}
+
+	mr.Close()
+
+	if err := svc.CheckCacheHealth(context.Background()); err == nil {
+		t.Fatalf("expected CheckCacheHealth to report an error once redis is down")
+	}
+
+	if _, err := svc.GetUserFresh(context.Background(), 1); err != nil {
+		t.Fatalf("expected DB reads to keep working while only redis is down: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+func TestGetUser_RecordsReadSourceOnColdThenWarmRead(t *testing.T) {
+	svc, repo := newTestService(t)
+	repo.nextID = 0
+	created, err := svc.CreateUser(context.Background(), &userpb.CreateUserRequest{TelegramId: 1, Username: "cold-then-warm"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	beforeDB := testutil.ToFloat64(metrics.UserReadSourceTotal.WithLabelValues("GetUser", "db"))
+	beforeCache := testutil.ToFloat64(metrics.UserReadSourceTotal.WithLabelValues("GetUser", "cache"))
+
+	if _, err := svc.GetUser(context.Background(), &userpb.GetUserRequest{UserId: created.Id}); err != nil {
+		t.Fatalf("cold GetUser: %v", err)
+	}
+	if after := testutil.ToFloat64(metrics.UserReadSourceTotal.WithLabelValues("GetUser", "db")); after != beforeDB+1 {
+		t.Fatalf("expected a cold read to increment the db counter by 1, got delta %v", after-beforeDB)
+	}
+
+	if _, err := svc.GetUser(context.Background(), &userpb.GetUserRequest{UserId: created.Id}); err != nil {
+		t.Fatalf("warm GetUser: %v", err)
+	}
+	if after := testutil.ToFloat64(metrics.UserReadSourceTotal.WithLabelValues("GetUser", "cache")); after != beforeCache+1 {
+		t.Fatalf("expected a warm read to increment the cache counter by 1, got delta %v", after-beforeCache)
+	}
+}
+
+func TestGetUserPreferences_RecordsReadSourceOnColdThenWarmRead(t *testing.T) {
+	svc, _, prefs := newTestServiceWithPrefs(t)
+	prefs.byUser[1] = []string{"rum"}
+
+	beforeDB := testutil.ToFloat64(metrics.UserReadSourceTotal.WithLabelValues("GetUserPreferences", "db"))
+	beforeCache := testutil.ToFloat64(metrics.UserReadSourceTotal.WithLabelValues("GetUserPreferences", "cache"))
+
+	if _, err := svc.GetUserPreferences(context.Background(), &userpb.GetUserPreferencesRequest{UserId: 1}); err != nil {
+		t.Fatalf("cold GetUserPreferences: %v", err)
+	}
+	if after := testutil.ToFloat64(metrics.UserReadSourceTotal.WithLabelValues("GetUserPreferences", "db")); after != beforeDB+1 {
+		t.Fatalf("expected a cold read to increment the db counter by 1, got delta %v", after-beforeDB)
+	}
+
+	if _, err := svc.GetUserPreferences(context.Background(), &userpb.GetUserPreferencesRequest{UserId: 1}); err != nil {
+		t.Fatalf("warm GetUserPreferences: %v", err)
+	}
+	if after := testutil.ToFloat64(metrics.UserReadSourceTotal.WithLabelValues("GetUserPreferences", "cache")); after != beforeCache+1 {
+		t.Fatalf("expected a warm read to increment the cache counter by 1, got delta %v", after-beforeCache)
+	}
+}
@@ -0,0 +1,727 @@
+// Package service implements the JollyRogerUserService gRPC business
+// logic on top of the repository layer.
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/i18n"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/metrics"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/resilience"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/validation"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+// idempotencyKeyMetadata is the request metadata key clients set to make
+// CreateUser safe to retry.
+const idempotencyKeyMetadata = "idempotency-key"
+
+// idempotencyReplayPollInterval is how often a request waiting on a
+// concurrent replay's lock re-checks for the cached result.
+const idempotencyReplayPollInterval = 50 * time.Millisecond
+
+// defaultMaxPreferencesPerUser is the fallback preference-count limit
+// when NewUserService is given one <= 0.
+const defaultMaxPreferencesPerUser = 50
+
+// defaultOnlineWindow is the fallback for onlineWindow when
+// NewUserService is given one <= 0.
+const defaultOnlineWindow = 5 * time.Minute
+
+// backgroundRefreshTimeout bounds how long a stale-while-revalidate
+// background refresh (see refreshUserCacheAsync) may take before it's
+// abandoned.
+const backgroundRefreshTimeout = 5 * time.Second
+
+// UserService implements userpb.UserServiceServer.
+type UserService struct {
+	userpb.UnimplementedUserServiceServer
+
+	users                 repository.UserRepository
+	prefs                 repository.PreferenceRepository
+	cache                 *repository.ResilientCacheRepository
+	log                   *zap.Logger
+	maxPreferencesPerUser int
+	geoLimits             GeoLimits
+	maintenance           *MaintenanceMode
+	locations             repository.LocationRepository
+	locationDebounce      LocationDebounceConfig
+	geoBulkhead           *resilience.Bulkhead
+	activity              *ActivityRecorder
+	featureFlags          FeatureFlagConfig
+	onlineWindow          time.Duration
+	allowEmptyUsername    bool
+
+	// refreshGroup deduplicates concurrent background cache refreshes
+	// (see refreshUserCacheAsync) so a soft-TTL expiry under load
+	// triggers at most one Postgres read per user id, however many
+	// requests observe the stale entry at once.
+	refreshGroup singleflight.Group
+}
+
+// NewUserService wires the gRPC handler to its repositories.
+// maxPreferencesPerUser <= 0 falls back to defaultMaxPreferencesPerUser;
+// a zero-valued geoLimits falls back to GeoLimits' own defaults. A nil
+// maintenance never blocks writes. A zero-valued locationDebounce falls
+// back to LocationDebounceConfig's own defaults. A zero-valued
+// featureFlags allows no feature keys through SetUserFeature.
+// onlineWindow <= 0 falls back to defaultOnlineWindow. allowEmptyUsername
+// controls whether UpdateUsername accepts an empty username.
+func NewUserService(users repository.UserRepository, prefs repository.PreferenceRepository, cache *repository.ResilientCacheRepository, log *zap.Logger, maxPreferencesPerUser int, geoLimits GeoLimits, maintenance *MaintenanceMode, locations repository.LocationRepository, locationDebounce LocationDebounceConfig, featureFlags FeatureFlagConfig, onlineWindow time.Duration, allowEmptyUsername bool) *UserService {
+	if maxPreferencesPerUser <= 0 {
+		maxPreferencesPerUser = defaultMaxPreferencesPerUser
+	}
+	if maintenance == nil {
+		maintenance = NewMaintenanceMode()
+	}
+	if onlineWindow <= 0 {
+		onlineWindow = defaultOnlineWindow
+	}
+	geoLimits = geoLimits.withDefaults()
+	geoBulkhead := resilience.NewBulkhead(geoLimits.MaxConcurrentSearches, geoLimits.SearchAcquireTimeout)
+	activity := NewActivityRecorder(users, log, 0, 0)
+	return &UserService{users: users, prefs: prefs, cache: cache, log: log, maxPreferencesPerUser: maxPreferencesPerUser, geoLimits: geoLimits, maintenance: maintenance, locations: locations, locationDebounce: locationDebounce.withDefaults(), geoBulkhead: geoBulkhead, activity: activity, featureFlags: featureFlags, onlineWindow: onlineWindow, allowEmptyUsername: allowEmptyUsername}
+}
+
+// StopActivityRecorder blocks until every last-active update already
+// accepted by the background ActivityRecorder has been applied.
+// Callers should invoke this during graceful shutdown so a queued
+// update is never silently dropped.
+func (s *UserService) StopActivityRecorder() {
+	s.activity.Close()
+}
+
+// GetUser returns a single user by id. It serves from cache when
+// possible, so a Postgres outage doesn't take down reads of users
+// already cached. A banned user is reported as NotFound rather than
+// surfaced with its data, so callers can't distinguish "banned" from
+// "never existed".
+func (s *UserService) GetUser(ctx context.Context, req *userpb.GetUserRequest) (*userpb.UserResponse, error) {
+	userID := uint(req.UserId)
+
+	if req.BypassCache {
+		return s.GetUserFresh(ctx, userID)
+	}
+
+	if cached, err := s.cache.GetUser(ctx, userID); err != nil {
+		s.log.Warn("user cache lookup failed, falling back to db", zap.Error(err))
+	} else if cached != nil {
+		metrics.UserReadSourceTotal.WithLabelValues("GetUser", "cache").Inc()
+		resp := toUserResponse(cached)
+		resp.IsOnline = s.isOnline(cached)
+		return resp, nil
+	}
+
+	// The normal cache entry missed or expired past its soft TTL. Before
+	// falling back to Postgres, try the longer-lived stale copy: if it's
+	// there, serve it immediately (marked stale) and refresh the normal
+	// entry from Postgres in the background rather than blocking this
+	// request on that refresh.
+	if stale, err := s.cache.GetStaleUser(ctx, userID); err != nil {
+		s.log.Warn("stale user cache lookup failed, falling back to db", zap.Error(err))
+	} else if stale != nil {
+		metrics.UserReadSourceTotal.WithLabelValues("GetUser", "stale-cache").Inc()
+		resp := toUserResponse(stale)
+		resp.Stale = true
+		resp.IsOnline = s.isOnline(stale)
+		s.refreshUserCacheAsync(userID)
+		return resp, nil
+	}
+
+	// The same tombstone UserExists writes on a nonexistent id also gates
+	// this read, so a burst of GetUser calls for an id that doesn't exist
+	// (e.g. a client scanning ids) doesn't hit Postgres on every call.
+	if tombstoned, err := s.cache.IsUserNotFound(ctx, userID); err != nil {
+		s.log.Warn("user-not-found tombstone lookup failed, falling back to db", zap.Error(err))
+	} else if tombstoned {
+		metrics.UserReadSourceTotal.WithLabelValues("GetUser", "tombstone").Inc()
+		return nil, status.Error(codes.NotFound, i18n.Message(i18n.LocaleFromContext(ctx), i18n.ReasonUserNotFound, req.UserId))
+	}
+
+	user, err := s.users.GetActiveUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUnavailable) {
+			return nil, mapServiceError(err, "get user")
+		}
+		if err := s.cache.SetUserNotFound(ctx, userID); err != nil {
+			s.log.Warn("failed to set user-not-found tombstone", zap.Error(err))
+		}
+		return nil, status.Error(codes.NotFound, i18n.Message(i18n.LocaleFromContext(ctx), i18n.ReasonUserNotFound, req.UserId))
+	}
+	metrics.UserReadSourceTotal.WithLabelValues("GetUser", "db").Inc()
+	if err := s.cache.SetUser(ctx, user); err != nil {
+		s.log.Warn("failed to populate user cache", zap.Error(err))
+	}
+	if err := s.cache.SetStaleUser(ctx, user); err != nil {
+		s.log.Warn("failed to populate stale user cache", zap.Error(err))
+	}
+	resp := toUserResponse(user)
+	resp.IsOnline = s.isOnline(user)
+	return resp, nil
+}
+
+// isOnline reports whether u was active recently enough - within
+// s.onlineWindow - to be considered online right now.
+func (s *UserService) isOnline(u *models.User) bool {
+	return time.Since(u.LastActiveAt) <= s.onlineWindow
+}
+
+// refreshUserCacheAsync refreshes the cached copy of userID from
+// Postgres in the background, on its own timeout independent of the
+// request that triggered it. Concurrent calls for the same userID are
+// collapsed into a single refresh via refreshGroup. It returns the
+// singleflight completion channel so tests can wait on the refresh
+// deterministically; callers on the read path are expected to ignore it.
+func (s *UserService) refreshUserCacheAsync(userID uint) <-chan singleflight.Result {
+	key := strconv.FormatUint(uint64(userID), 10)
+	return s.refreshGroup.DoChan(key, func() (interface{}, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), backgroundRefreshTimeout)
+		defer cancel()
+		user, err := s.users.GetActiveUserByID(ctx, userID)
+		if err != nil {
+			s.log.Warn("background user cache refresh failed", zap.Uint("user_id", userID), zap.Error(err))
+			return nil, err
+		}
+		if err := s.cache.SetUser(ctx, user); err != nil {
+			s.log.Warn("failed to refresh user cache in background", zap.Error(err))
+		}
+		if err := s.cache.SetStaleUser(ctx, user); err != nil {
+			s.log.Warn("failed to refresh stale user cache in background", zap.Error(err))
+		}
+		return nil, nil
+	})
+}
+
+// GetUserFresh reads a user directly from Postgres, skipping the cache
+// entirely, and refreshes the cache with the result. It's for admin
+// tooling doing one-off consistency checks that can't tolerate a stale
+// cache entry masking a recent write.
+func (s *UserService) GetUserFresh(ctx context.Context, id uint) (*userpb.UserResponse, error) {
+	user, err := s.users.GetActiveUserByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrUnavailable) {
+			return nil, mapServiceError(err, "get user")
+		}
+		return nil, status.Error(codes.NotFound, i18n.Message(i18n.LocaleFromContext(ctx), i18n.ReasonUserNotFound, id))
+	}
+	if err := s.cache.SetUser(ctx, user); err != nil {
+		s.log.Warn("failed to refresh user cache", zap.Error(err))
+	}
+	resp := toUserResponse(user)
+	resp.IsOnline = s.isOnline(user)
+	return resp, nil
+}
+
+// UpdateUsername sets a user's username. An empty username is rejected
+// with InvalidArgument unless allowEmptyUsername was set, since until
+// field masks land there's no way to distinguish "clear the username"
+// from a caller that simply forgot to set the field.
+func (s *UserService) UpdateUsername(ctx context.Context, req *userpb.UpdateUsernameRequest) (*userpb.UpdateUsernameResponse, error) {
+	if req.Username == "" && !s.allowEmptyUsername {
+		return nil, validation.Errorf(codes.InvalidArgument,
+			[]validation.FieldViolation{{Field: "username", Description: "must not be empty"}},
+			"%s", i18n.Message(i18n.LocaleFromContext(ctx), i18n.ReasonUsernameRequired))
+	}
+	userID := uint(req.UserId)
+	if err := s.users.UpdateUsername(ctx, userID, req.Username); err != nil {
+		return nil, mapServiceError(err, "update username")
+	}
+	if err := s.cache.ClearUserCache(ctx, userID); err != nil {
+		s.log.Warn("failed to evict user cache after username change", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	if err := s.cache.ClearUserNotFound(ctx, userID); err != nil {
+		s.log.Warn("failed to clear user-not-found tombstone after username change", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	return &userpb.UpdateUsernameResponse{Username: req.Username}, nil
+}
+
+// UpdateUser changes a user's username and, when req.IncludeStats is set,
+// loads their rating stats within the same operation so a caller that
+// immediately re-fetches stats after an update doesn't need a follow-up
+// round-trip. Stats are read through the same cache-first path as GetUser,
+// which UpdateUser calls internally to build the returned user.
+func (s *UserService) UpdateUser(ctx context.Context, req *userpb.UpdateUserRequest) (*userpb.UserWithStatsResponse, error) {
+	if req.Username == "" && !s.allowEmptyUsername {
+		return nil, validation.Errorf(codes.InvalidArgument,
+			[]validation.FieldViolation{{Field: "username", Description: "must not be empty"}},
+			"%s", i18n.Message(i18n.LocaleFromContext(ctx), i18n.ReasonUsernameRequired))
+	}
+	userID := uint(req.UserId)
+	if err := s.users.UpdateUsername(ctx, userID, req.Username); err != nil {
+		return nil, mapServiceError(err, "update username")
+	}
+	if err := s.cache.ClearUserCache(ctx, userID); err != nil {
+		s.log.Warn("failed to evict user cache after username change", zap.Uint("user_id", userID), zap.Error(err))
+	}
+	if err := s.cache.ClearUserNotFound(ctx, userID); err != nil {
+		s.log.Warn("failed to clear user-not-found tombstone after username change", zap.Uint("user_id", userID), zap.Error(err))
+	}
+
+	user, err := s.GetUser(ctx, &userpb.GetUserRequest{UserId: req.UserId})
+	if err != nil {
+		return nil, err
+	}
+	resp := &userpb.UserWithStatsResponse{User: user}
+	if !req.IncludeStats {
+		return resp, nil
+	}
+
+	// The cache entry GetUser just (re)populated carries the same
+	// denormalized rating aggregate as Postgres, so this second cache
+	// lookup is the "cache-first stats path": it hits the entry GetUser
+	// just warmed rather than issuing its own query.
+	if cached, err := s.cache.GetUser(ctx, userID); err != nil {
+		s.log.Warn("user cache lookup for stats failed, falling back to db", zap.Error(err))
+	} else if cached != nil {
+		resp.Stats = &userpb.UserStats{Rating: cached.Rating, RatingCount: cached.RatingCount}
+		return resp, nil
+	}
+	dbUser, err := s.users.GetActiveUserByID(ctx, userID)
+	if err != nil {
+		s.log.Warn("failed to load user stats after update", zap.Uint("user_id", userID), zap.Error(err))
+		return resp, nil
+	}
+	resp.Stats = &userpb.UserStats{Rating: dbUser.Rating, RatingCount: dbUser.RatingCount}
+	return resp, nil
+}
+
+// CheckCacheHealth reports whether Redis is reachable, so callers (e.g. a
+// diagnostics endpoint) can distinguish "cache is down" from "the service
+// as a whole is down" — reads still succeed against Postgres while the
+// cache is unavailable, just without its usual speedup.
+func (s *UserService) CheckCacheHealth(ctx context.Context) error {
+	return s.cache.Ping(ctx)
+}
+
+// GetUsersByTelegramIDs resolves many Telegram user ids in a single
+// query, for the bot backend's use case of resolving a whole group's
+// membership on join. A telegram_id with no matching account is simply
+// absent from the response.
+func (s *UserService) GetUsersByTelegramIDs(ctx context.Context, req *userpb.GetUsersByTelegramIDsRequest) (*userpb.GetUsersByTelegramIDsResponse, error) {
+	// Telegram ids are always positive, so a zero or negative id can
+	// never match a row; drop them before the query instead of asking
+	// Postgres to look up something that can't exist.
+	validIDs := make([]int64, 0, len(req.TelegramIds))
+	for _, id := range req.TelegramIds {
+		if id > 0 {
+			validIDs = append(validIDs, id)
+		}
+	}
+	usersByTelegramID := map[int64]*models.User{}
+	if len(validIDs) > 0 {
+		var err error
+		usersByTelegramID, err = s.users.GetUsersByTelegramIDs(ctx, validIDs)
+		if err != nil {
+			return nil, mapServiceError(err, "get users by telegram ids")
+		}
+	}
+	users := make([]*userpb.UserResponse, 0, len(req.TelegramIds))
+	for _, telegramID := range req.TelegramIds {
+		if user, ok := usersByTelegramID[telegramID]; ok {
+			users = append(users, toUserResponse(user))
+		}
+	}
+	return &userpb.GetUsersByTelegramIDsResponse{Users: users}, nil
+}
+
+// ExportUserData returns the full set of personal data JollyRoger holds
+// on a user, for GDPR Art. 15/20 data-portability requests.
+func (s *UserService) ExportUserData(ctx context.Context, req *userpb.ExportUserDataRequest) (*userpb.ExportUserDataResponse, error) {
+	user, err := s.users.GetUserByID(ctx, uint(req.UserId))
+	if err != nil {
+		if errors.Is(err, repository.ErrUnavailable) {
+			return nil, mapServiceError(err, "export user data")
+		}
+		return nil, status.Error(codes.NotFound, i18n.Message(i18n.LocaleFromContext(ctx), i18n.ReasonUserNotFound, req.UserId))
+	}
+	prefs, err := s.prefs.ListPreferences(ctx, uint(req.UserId))
+	if err != nil {
+		return nil, mapServiceError(err, "list preferences")
+	}
+	tags := make([]string, len(prefs))
+	for i, p := range prefs {
+		tags[i] = p.Tag
+	}
+	return &userpb.ExportUserDataResponse{
+		User:           toUserResponse(user),
+		PreferenceTags: tags,
+	}, nil
+}
+
+// GetUserPreferences returns a user's preference tags. It serves from
+// cache when possible; on a cache miss (or a cache error, so a flaky
+// Redis never turns into a hard failure) it falls back to Postgres and
+// repopulates the cache for next time.
+func (s *UserService) GetUserPreferences(ctx context.Context, req *userpb.GetUserPreferencesRequest) (*userpb.GetUserPreferencesResponse, error) {
+	userID := uint(req.UserId)
+
+	if tags, found, err := s.cache.GetPreferenceTags(ctx, userID); err != nil {
+		s.log.Warn("preferences cache lookup failed, falling back to db", zap.Error(err))
+	} else if found {
+		metrics.UserReadSourceTotal.WithLabelValues("GetUserPreferences", "cache").Inc()
+		return &userpb.GetUserPreferencesResponse{Tags: tags}, nil
+	}
+
+	prefs, err := s.prefs.ListPreferences(ctx, userID)
+	if err != nil {
+		return nil, mapServiceError(err, "list preferences")
+	}
+	metrics.UserReadSourceTotal.WithLabelValues("GetUserPreferences", "db").Inc()
+	tags := make([]string, len(prefs))
+	for i, p := range prefs {
+		tags[i] = p.Tag
+	}
+	if err := s.cache.SetPreferenceTags(ctx, userID, tags); err != nil {
+		s.log.Warn("failed to repopulate preferences cache", zap.Error(err))
+	}
+	return &userpb.GetUserPreferencesResponse{Tags: tags}, nil
+}
+
+// AddUserPreference appends a preference tag for a user, rejecting the
+// call once the user is already at maxPreferencesPerUser tags so
+// preferences can't grow without bound.
+func (s *UserService) AddUserPreference(ctx context.Context, req *userpb.AddUserPreferenceRequest) (*userpb.AddUserPreferenceResponse, error) {
+	if s.maintenance.Enabled() {
+		return nil, errMaintenanceMode
+	}
+	userID := uint(req.UserId)
+
+	before, err := s.prefs.ListPreferences(ctx, userID)
+	if err != nil {
+		return nil, mapServiceError(err, "list preferences")
+	}
+	alreadyHasTag := false
+	for _, p := range before {
+		if p.Tag == req.Tag {
+			alreadyHasTag = true
+			break
+		}
+	}
+
+	err = s.prefs.AddPreferenceWithLimit(ctx, userID, req.Tag, s.maxPreferencesPerUser)
+	if errors.Is(err, repository.ErrPreferenceLimitExceeded) {
+		return nil, status.Errorf(codes.FailedPrecondition, "user %d already has the maximum of %d preferences", req.UserId, s.maxPreferencesPerUser)
+	}
+	if err != nil {
+		return nil, mapServiceError(err, "add preference")
+	}
+
+	prefs, err := s.prefs.ListPreferences(ctx, userID)
+	if err != nil {
+		return nil, mapServiceError(err, "list preferences")
+	}
+	tags := make([]string, len(prefs))
+	for i, p := range prefs {
+		tags[i] = p.Tag
+	}
+	if err := s.cache.SetPreferenceTags(ctx, userID, tags); err != nil {
+		s.log.Warn("failed to refresh preferences cache after add", zap.Error(err))
+	}
+	// Re-adding a tag the user already has is idempotent at the
+	// repository layer, so only a genuinely new tag should move the
+	// popularity counter.
+	if !alreadyHasTag {
+		if err := s.cache.IncrementTagPopularity(ctx, req.Tag, 1); err != nil {
+			s.log.Warn("failed to update tag popularity", zap.Error(err))
+		}
+	}
+	return &userpb.AddUserPreferenceResponse{Tags: tags}, nil
+}
+
+// FindUsersByTag returns the users who have opted into req.Tag, ordered
+// by rating descending, for a matching service doing a reverse lookup
+// ("who likes X"). Limit and offset pass straight through to the
+// repository, which caps the limit.
+func (s *UserService) FindUsersByTag(ctx context.Context, req *userpb.FindUsersByTagRequest) (*userpb.FindUsersByTagResponse, error) {
+	matched, err := s.prefs.FindUsersByTag(ctx, req.Tag, int(req.Limit), int(req.Offset))
+	if err != nil {
+		return nil, mapServiceError(err, "find users by tag")
+	}
+	users := make([]*userpb.UserResponse, 0, len(matched))
+	for i := range matched {
+		users = append(users, toUserResponse(&matched[i]))
+	}
+	return &userpb.FindUsersByTagResponse{Users: users}, nil
+}
+
+// defaultPopularTagsLimit caps GetPopularTags when the caller passes a
+// limit <= 0.
+const defaultPopularTagsLimit = 20
+
+// GetPopularTags returns the tags currently opted into by the most
+// users, most popular first, for trend analytics ("which tags are
+// gaining popularity"). The count is maintained incrementally in Redis
+// as preferences are added and removed, so it can drift from the actual
+// preferences table if a write path fails between its DB change and its
+// cache update.
+func (s *UserService) GetPopularTags(ctx context.Context, req *userpb.GetPopularTagsRequest) (*userpb.GetPopularTagsResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultPopularTagsLimit
+	}
+	counts, err := s.cache.GetPopularTags(ctx, limit)
+	if err != nil {
+		return nil, mapServiceError(err, "get popular tags")
+	}
+	tags := make([]*userpb.PopularTag, len(counts))
+	for i, c := range counts {
+		tags[i] = &userpb.PopularTag{Tag: c.Tag, Count: c.Count}
+	}
+	return &userpb.GetPopularTagsResponse{Tags: tags}, nil
+}
+
+// defaultRatingHistoryLimit caps GetRatingHistory when the caller passes
+// a limit <= 0.
+const defaultRatingHistoryLimit = 50
+
+// GetRatingHistory returns a user's recent rating events, newest first,
+// for dispute resolution and profile "recent feedback" UIs.
+func (s *UserService) GetRatingHistory(ctx context.Context, req *userpb.GetRatingHistoryRequest) (*userpb.GetRatingHistoryResponse, error) {
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultRatingHistoryLimit
+	}
+	history, err := s.users.GetRatingHistory(ctx, uint(req.UserId), limit)
+	if err != nil {
+		return nil, mapServiceError(err, "get rating history")
+	}
+	events := make([]*userpb.RatingEvent, len(history))
+	for i, e := range history {
+		events[i] = &userpb.RatingEvent{
+			RaterId:   uint64(e.RaterID),
+			Score:     e.Score,
+			CreatedAt: e.CreatedAt.UTC().Format(time.RFC3339),
+		}
+	}
+	return &userpb.GetRatingHistoryResponse{Events: events}, nil
+}
+
+// UserExists reports whether a user exists, by id or by Telegram id,
+// without the caller having to fetch (and the service having to load)
+// the full row. A prior "not found" answer for a user id is tombstoned in
+// the cache for a short time, so a burst of lookups for an id that doesn't
+// exist (e.g. a bot probing ids) doesn't hit Postgres on every call.
+// Lookups by Telegram id always go to Postgres.
+func (s *UserService) UserExists(ctx context.Context, req *userpb.UserExistsRequest) (*userpb.UserExistsResponse, error) {
+	if req.UserId != 0 {
+		return s.userExistsByID(ctx, uint(req.UserId))
+	}
+	if req.TelegramId != 0 {
+		exists, err := s.users.UserExistsByTelegramID(ctx, req.TelegramId)
+		if err != nil {
+			return nil, mapServiceError(err, "user exists by telegram id")
+		}
+		return &userpb.UserExistsResponse{Exists: exists}, nil
+	}
+	return nil, status.Errorf(codes.InvalidArgument, "user_id or telegram_id is required")
+}
+
+func (s *UserService) userExistsByID(ctx context.Context, id uint) (*userpb.UserExistsResponse, error) {
+	if tombstoned, err := s.cache.IsUserNotFound(ctx, id); err != nil {
+		s.log.Warn("user-not-found tombstone lookup failed, falling back to db", zap.Error(err))
+	} else if tombstoned {
+		return &userpb.UserExistsResponse{Exists: false}, nil
+	}
+
+	exists, err := s.users.UserExists(ctx, id)
+	if err != nil {
+		return nil, mapServiceError(err, "user exists")
+	}
+	if !exists {
+		if err := s.cache.SetUserNotFound(ctx, id); err != nil {
+			s.log.Warn("failed to set user-not-found tombstone", zap.Error(err))
+		}
+	}
+	return &userpb.UserExistsResponse{Exists: exists}, nil
+}
+
+// CreateUser registers a new user. When the caller supplies an
+// "idempotency-key" metadata header, retries of the same key within the
+// configured TTL return the original response instead of creating a
+// duplicate account.
+func (s *UserService) CreateUser(ctx context.Context, req *userpb.CreateUserRequest) (*userpb.UserResponse, error) {
+	if s.maintenance.Enabled() {
+		return nil, errMaintenanceMode
+	}
+	key := idempotencyKeyFromContext(ctx)
+	if key == "" {
+		return s.createUser(ctx, req)
+	}
+
+	var cached userpb.UserResponse
+	if found, err := s.cache.GetIdempotentResult(ctx, key, &cached); err != nil {
+		s.log.Warn("idempotency lookup failed, proceeding without it", zap.Error(err))
+	} else if found {
+		return &cached, nil
+	}
+
+	acquired, err := s.cache.AcquireIdempotencyLock(ctx, key)
+	if err != nil {
+		s.log.Warn("idempotency lock failed, proceeding without it", zap.Error(err))
+		return s.createUser(ctx, req)
+	}
+	if !acquired {
+		return s.waitForIdempotentResult(ctx, key)
+	}
+	defer s.cache.ReleaseIdempotencyLock(ctx, key)
+
+	resp, err := s.createUser(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cache.SaveIdempotentResult(ctx, key, resp, 24*time.Hour); err != nil {
+		s.log.Warn("failed to persist idempotent result", zap.Error(err))
+	}
+	return resp, nil
+}
+
+// waitForIdempotentResult is invoked when another in-flight request
+// already holds the idempotency lock for this key; it polls the cache
+// for the result that request will publish.
+func (s *UserService) waitForIdempotentResult(ctx context.Context, key string) (*userpb.UserResponse, error) {
+	ticker := time.NewTicker(idempotencyReplayPollInterval)
+	defer ticker.Stop()
+
+	for {
+		var cached userpb.UserResponse
+		if found, err := s.cache.GetIdempotentResult(ctx, key, &cached); err == nil && found {
+			return &cached, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, status.Errorf(codes.DeadlineExceeded, "timed out waiting for concurrent idempotent request")
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *UserService) createUser(ctx context.Context, req *userpb.CreateUserRequest) (*userpb.UserResponse, error) {
+	if req.TelegramId <= 0 {
+		return nil, validation.Errorf(codes.InvalidArgument,
+			[]validation.FieldViolation{{Field: "telegram_id", Description: "must be positive"}},
+			"telegram_id must be positive, got %d", req.TelegramId)
+	}
+	if !validPhotoURL(req.PhotoUrl) {
+		return nil, validation.Errorf(codes.InvalidArgument,
+			[]validation.FieldViolation{{Field: "photo_url", Description: fmt.Sprintf("must be a well-formed http(s) URL of at most %d characters", maxPhotoURLLength)}},
+			"photo_url must be a well-formed http(s) URL of at most %d characters", maxPhotoURLLength)
+	}
+	if len(req.PreferenceTags) > s.maxPreferencesPerUser {
+		return nil, validation.Errorf(codes.InvalidArgument,
+			[]validation.FieldViolation{{Field: "preference_tags", Description: fmt.Sprintf("has %d entries, more than the maximum of %d", len(req.PreferenceTags), s.maxPreferencesPerUser)}},
+			"preference_tags has %d entries, more than the maximum of %d", len(req.PreferenceTags), s.maxPreferencesPerUser)
+	}
+	var location *models.UserLocation
+	if req.Location != nil {
+		point := models.GeoPoint{Lat: req.Location.Latitude, Lon: req.Location.Longitude}
+		if !point.Valid() {
+			return nil, validation.Errorf(codes.InvalidArgument,
+				[]validation.FieldViolation{{Field: "location", Description: "invalid coordinate"}},
+				"invalid coordinate (%v, %v)", req.Location.Latitude, req.Location.Longitude)
+		}
+		code, ok := normalizeCountryCode(req.Location.Country)
+		if !ok {
+			return nil, validation.Errorf(codes.InvalidArgument,
+				[]validation.FieldViolation{{Field: "location.country", Description: "unrecognized country"}},
+				"unrecognized country %q", req.Location.Country)
+		}
+		location = &models.UserLocation{Label: models.CurrentLocationLabel, Latitude: point.Lat, Longitude: point.Lon, Country: code}
+	}
+
+	user := &models.User{
+		TelegramID: req.TelegramId,
+		Username:   req.Username,
+		FirstName:  req.FirstName,
+		LastName:   req.LastName,
+		IsBot:      req.IsBot,
+		PhotoURL:   req.PhotoUrl,
+	}
+	if err := s.users.CreateUserWithOnboarding(ctx, user, req.PreferenceTags, location); err != nil {
+		return nil, mapServiceError(err, "create user")
+	}
+	if err := s.cache.ClearUserNotFound(ctx, user.ID); err != nil {
+		s.log.Warn("failed to clear user-not-found tombstone after create", zap.Uint("user_id", user.ID), zap.Error(err))
+	}
+	if len(req.PreferenceTags) > 0 {
+		if err := s.cache.SetPreferenceTags(ctx, user.ID, req.PreferenceTags); err != nil {
+			s.log.Warn("failed to seed preferences cache after create", zap.Uint("user_id", user.ID), zap.Error(err))
+		}
+		for _, tag := range req.PreferenceTags {
+			if err := s.cache.IncrementTagPopularity(ctx, tag, 1); err != nil {
+				s.log.Warn("failed to update tag popularity after create", zap.Error(err))
+			}
+		}
+	}
+	if location != nil {
+		if err := s.cache.SetLocationState(ctx, user.ID, repository.CachedLocation{
+			Latitude:               location.Latitude,
+			Longitude:              location.Longitude,
+			Country:                location.Country,
+			LastPersistedLatitude:  location.Latitude,
+			LastPersistedLongitude: location.Longitude,
+			LastPersistedAt:        location.UpdatedAt,
+		}); err != nil {
+			s.log.Warn("failed to seed location fast-path cache after create", zap.Uint("user_id", user.ID), zap.Error(err))
+		}
+	}
+	return toUserResponse(user), nil
+}
+
+// maxPhotoURLLength bounds models.User.PhotoURL, so a client can't wedge
+// an unbounded string into every cached user payload.
+const maxPhotoURLLength = 2048
+
+// validPhotoURL reports whether s is unset (no photo) or a well-formed
+// http(s) URL no longer than maxPhotoURLLength.
+func validPhotoURL(s string) bool {
+	if s == "" {
+		return true
+	}
+	if len(s) > maxPhotoURLLength {
+		return false
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func toUserResponse(u *models.User) *userpb.UserResponse {
+	return &userpb.UserResponse{
+		Id:         uint64(u.ID),
+		TelegramId: u.TelegramID,
+		Username:   u.Username,
+		FirstName:  u.FirstName,
+		LastName:   u.LastName,
+		IsBot:      u.IsBot,
+		CreatedAt:  u.CreatedAt.UTC().Format(time.RFC3339),
+		UpdatedAt:  u.UpdatedAt.UTC().Format(time.RFC3339),
+		PhotoUrl:   u.PhotoURL,
+	}
+}
+
+func idempotencyKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(idempotencyKeyMetadata)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,999 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/i18n"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/validation"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+// countingUserRepository counts CreateUser calls so tests can assert on
+// how many inserts actually happened.
+type countingUserRepository struct {
+	inserts          uint32
+	nextID           uint32
+	users            map[uint]models.User
+	banned           map[uint]bool
+	ratings          map[uint]float64
+	activeReads      uint32
+	lastActiveWrites uint32
+	lookups          uint32
+	ratingHistory    map[uint][]models.UserRatingEvent
+	existingIDs      map[uint]bool
+	features         map[uint]models.FeatureFlags
+	lastActive       map[uint]time.Time
+	ratingHistoryErr error
+	// failOnboardingTag, if set, makes CreateUserWithOnboarding fail
+	// (without creating the user) whenever it appears in the tags
+	// given, standing in for a preference insert violating a
+	// constraint (e.g. a duplicate tag) inside the real transaction.
+	failOnboardingTag  string
+	onboardedTags      map[uint][]string
+	onboardedLocations map[uint]*models.UserLocation
+	userCount          int64
+	countCalls         uint32
+}
+
+func (r *countingUserRepository) CreateUser(_ context.Context, user *models.User) error {
+	atomic.AddUint32(&r.inserts, 1)
+	user.ID = uint(atomic.AddUint32(&r.nextID, 1))
+	if r.users == nil {
+		r.users = map[uint]models.User{}
+	}
+	r.users[user.ID] = *user
+	return nil
+}
+
+func (r *countingUserRepository) CreateUserWithOnboarding(ctx context.Context, user *models.User, tags []string, location *models.UserLocation) error {
+	if r.failOnboardingTag != "" {
+		for _, tag := range tags {
+			if tag == r.failOnboardingTag {
+				return errors.New("simulated preference insert failure")
+			}
+		}
+	}
+	if err := r.CreateUser(ctx, user); err != nil {
+		return err
+	}
+	if len(tags) > 0 {
+		if r.onboardedTags == nil {
+			r.onboardedTags = map[uint][]string{}
+		}
+		r.onboardedTags[user.ID] = tags
+	}
+	if location != nil {
+		location.UserID = user.ID
+		if r.onboardedLocations == nil {
+			r.onboardedLocations = map[uint]*models.UserLocation{}
+		}
+		r.onboardedLocations[user.ID] = location
+	}
+	return nil
+}
+
+func TestCreateUser_PersistsIsBotFlag(t *testing.T) {
+	svc, _ := newTestService(t)
+	req := &userpb.CreateUserRequest{TelegramId: 99, Username: "harbor-bot", IsBot: true}
+
+	resp, err := svc.CreateUser(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if !resp.IsBot {
+		t.Fatalf("expected response to report is_bot=true")
+	}
+}
+
+func TestCreateUser_OnboardsPreferencesAndLocationAtomically(t *testing.T) {
+	svc, repo := newTestService(t)
+	req := &userpb.CreateUserRequest{
+		TelegramId:     99,
+		Username:       "one-legged-pete",
+		PreferenceTags: []string{"diving", "fishing"},
+		Location:       &userpb.CreateUserLocationInput{Latitude: 10, Longitude: 20, Country: "RU"},
+	}
+
+	resp, err := svc.CreateUser(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	userID := uint(resp.Id)
+
+	gotTags := repo.onboardedTags[userID]
+	if len(gotTags) != 2 || gotTags[0] != "diving" || gotTags[1] != "fishing" {
+		t.Fatalf("expected onboarding to seed both tags in one transaction, got %v", gotTags)
+	}
+	loc := repo.onboardedLocations[userID]
+	if loc == nil || loc.Latitude != 10 || loc.Longitude != 20 || loc.Country != "RU" {
+		t.Fatalf("expected onboarding to seed the initial location, got %+v", loc)
+	}
+
+	prefsResp, err := svc.GetUserPreferences(context.Background(), &userpb.GetUserPreferencesRequest{UserId: resp.Id})
+	if err != nil {
+		t.Fatalf("GetUserPreferences: %v", err)
+	}
+	if len(prefsResp.Tags) != 2 {
+		t.Fatalf("expected the preferences cache to be seeded too, got %v", prefsResp.Tags)
+	}
+}
+
+func TestCreateUser_RejectsTooManyPreferenceTags(t *testing.T) {
+	svc, repo := newTestService(t)
+	tags := make([]string, 51)
+	for i := range tags {
+		tags[i] = "tag"
+	}
+	req := &userpb.CreateUserRequest{TelegramId: 99, Username: "greedy-gus", PreferenceTags: tags}
+
+	if _, err := svc.CreateUser(context.Background(), req); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for too many preference tags, got %v", err)
+	}
+	if atomic.LoadUint32(&repo.inserts) != 0 {
+		t.Fatalf("expected no user to be created when onboarding is rejected up front")
+	}
+}
+
+func TestCreateUser_InvalidArgumentCarriesTheOffendingFieldName(t *testing.T) {
+	svc, _ := newTestService(t)
+	req := &userpb.CreateUserRequest{TelegramId: -1, Username: "landlubber"}
+
+	_, err := svc.CreateUser(context.Background(), req)
+	violations := validation.FromError(err)
+	if len(violations) != 1 {
+		t.Fatalf("expected exactly one field violation, got %+v", violations)
+	}
+	if violations[0].Field != "telegram_id" {
+		t.Fatalf("expected the offending field to be %q, got %q", "telegram_id", violations[0].Field)
+	}
+}
+
+func TestCreateUser_RollsBackTheWholeUserOnAPreferenceInsertFailure(t *testing.T) {
+	svc, repo := newTestService(t)
+	repo.failOnboardingTag = "cursed"
+	req := &userpb.CreateUserRequest{
+		TelegramId:     99,
+		Username:       "jonah",
+		PreferenceTags: []string{"diving", "cursed"},
+	}
+
+	if _, err := svc.CreateUser(context.Background(), req); err == nil {
+		t.Fatalf("expected CreateUser to fail when a preference insert fails")
+	}
+	if got := atomic.LoadUint32(&repo.inserts); got != 0 {
+		t.Fatalf("expected the user row to be rolled back along with the failed preference insert, got %d inserts", got)
+	}
+}
+
+func TestCreateUser_RoundTripsPhotoURL(t *testing.T) {
+	svc, _ := newTestService(t)
+	req := &userpb.CreateUserRequest{TelegramId: 100, Username: "long-john", PhotoUrl: "https://cdn.example.com/avatars/100.png"}
+
+	resp, err := svc.CreateUser(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if resp.PhotoUrl != req.PhotoUrl {
+		t.Fatalf("expected photo_url to round-trip, got %q", resp.PhotoUrl)
+	}
+}
+
+func TestCreateUser_RejectsMalformedPhotoURL(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	for _, photoURL := range []string{
+		"not-a-url",
+		"ftp://example.com/avatar.png",
+		"javascript:alert(1)",
+	} {
+		req := &userpb.CreateUserRequest{TelegramId: 101, Username: "bad-photo", PhotoUrl: photoURL}
+		_, err := svc.CreateUser(context.Background(), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument for photo_url %q, got %v", photoURL, err)
+		}
+	}
+}
+
+func TestCreateUser_RejectsNonPositiveTelegramID(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	for _, telegramID := range []int64{0, -1} {
+		req := &userpb.CreateUserRequest{TelegramId: telegramID, Username: "impostor"}
+		_, err := svc.CreateUser(context.Background(), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("expected InvalidArgument for telegram_id %d, got %v", telegramID, err)
+		}
+	}
+}
+
+func TestGetUsersByTelegramIDs_DropsNonPositiveIDsWithoutDBHit(t *testing.T) {
+	svc, users := newTestService(t)
+
+	resp, err := svc.GetUsersByTelegramIDs(context.Background(), &userpb.GetUsersByTelegramIDsRequest{TelegramIds: []int64{0, -1}})
+	if err != nil {
+		t.Fatalf("GetUsersByTelegramIDs: %v", err)
+	}
+	if len(resp.Users) != 0 {
+		t.Fatalf("expected no users for non-positive telegram ids, got %+v", resp.Users)
+	}
+	if atomic.LoadUint32(&users.lookups) != 0 {
+		t.Fatalf("expected non-positive telegram ids to be filtered before hitting the repository")
+	}
+}
+
+func TestGetRatingHistory_ReturnsNewestFirstAndRespectsLimit(t *testing.T) {
+	svc, users := newTestService(t)
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	users.ratingHistory = map[uint][]models.UserRatingEvent{
+		1: {
+			{RaterID: 10, Score: 3, CreatedAt: base.Add(2 * time.Hour)},
+			{RaterID: 11, Score: 5, CreatedAt: base.Add(time.Hour)},
+			{RaterID: 12, Score: 4, CreatedAt: base},
+		},
+	}
+
+	resp, err := svc.GetRatingHistory(context.Background(), &userpb.GetRatingHistoryRequest{UserId: 1, Limit: 2})
+	if err != nil {
+		t.Fatalf("GetRatingHistory: %v", err)
+	}
+	if len(resp.Events) != 2 {
+		t.Fatalf("expected limit=2 to return 2 events, got %d", len(resp.Events))
+	}
+	if resp.Events[0].RaterId != 10 || resp.Events[1].RaterId != 11 {
+		t.Fatalf("expected events to preserve the repository's newest-first ordering, got %+v", resp.Events)
+	}
+}
+
+func TestUserExists_ReturnsTrueForAnExistingID(t *testing.T) {
+	svc, users := newTestService(t)
+	users.existingIDs = map[uint]bool{1: true}
+
+	resp, err := svc.UserExists(context.Background(), &userpb.UserExistsRequest{UserId: 1})
+	if err != nil {
+		t.Fatalf("UserExists: %v", err)
+	}
+	if !resp.Exists {
+		t.Fatalf("expected id 1 to exist")
+	}
+}
+
+func TestUserExists_ReturnsFalseAndTombstonesANonexistentID(t *testing.T) {
+	svc, users := newTestService(t)
+	users.existingIDs = map[uint]bool{}
+
+	resp, err := svc.UserExists(context.Background(), &userpb.UserExistsRequest{UserId: 404})
+	if err != nil {
+		t.Fatalf("UserExists: %v", err)
+	}
+	if resp.Exists {
+		t.Fatalf("expected id 404 not to exist")
+	}
+	tombstoned, err := svc.cache.IsUserNotFound(context.Background(), 404)
+	if err != nil {
+		t.Fatalf("IsUserNotFound: %v", err)
+	}
+	if !tombstoned {
+		t.Fatalf("expected a nonexistent id to be tombstoned after the lookup")
+	}
+}
+
+func TestUserExists_ServesATombstonedIDWithoutHittingTheRepository(t *testing.T) {
+	svc, users := newTestService(t)
+	users.existingIDs = map[uint]bool{}
+	if err := svc.cache.SetUserNotFound(context.Background(), 404); err != nil {
+		t.Fatalf("SetUserNotFound: %v", err)
+	}
+	users.existingIDs = nil // would report "exists" for any id if consulted
+
+	resp, err := svc.UserExists(context.Background(), &userpb.UserExistsRequest{UserId: 404})
+	if err != nil {
+		t.Fatalf("UserExists: %v", err)
+	}
+	if resp.Exists {
+		t.Fatalf("expected a tombstoned id to be reported as not existing, without consulting the repository")
+	}
+}
+
+func (r *countingUserRepository) GetUserByID(_ context.Context, id uint) (*models.User, error) {
+	user := r.users[id]
+	user.ID = id
+	user.Banned = r.banned[id]
+	return &user, nil
+}
+
+func (r *countingUserRepository) ListUsers(_ context.Context, afterID uint, limit int, excludeBots bool) ([]models.User, error) {
+	return nil, nil
+}
+
+func (r *countingUserRepository) GetActiveUserByID(_ context.Context, id uint) (*models.User, error) {
+	atomic.AddUint32(&r.activeReads, 1)
+	if r.banned[id] {
+		return nil, gorm.ErrRecordNotFound
+	}
+	user := r.users[id]
+	user.ID = id
+	user.Rating = r.ratings[id]
+	user.LastActiveAt = r.lastActive[id]
+	return &user, nil
+}
+
+func (r *countingUserRepository) GetUsersByTelegramIDs(_ context.Context, telegramIDs []int64) (map[int64]*models.User, error) {
+	atomic.AddUint32(&r.lookups, 1)
+	return nil, nil
+}
+
+func (r *countingUserRepository) SetBanned(_ context.Context, id uint, banned bool) error {
+	if r.banned == nil {
+		r.banned = map[uint]bool{}
+	}
+	r.banned[id] = banned
+	return nil
+}
+
+func (r *countingUserRepository) ChangeTelegramID(context.Context, uint, int64) error { return nil }
+func (r *countingUserRepository) UpdateUsername(context.Context, uint, string) error  { return nil }
+
+func (r *countingUserRepository) CountUsers(context.Context) (int64, error) {
+	atomic.AddUint32(&r.countCalls, 1)
+	return r.userCount, nil
+}
+
+func (r *countingUserRepository) UpdateLastActive(_ context.Context, id uint) error {
+	atomic.AddUint32(&r.lastActiveWrites, 1)
+	return nil
+}
+
+// fakePreferenceRepository serves preferences from an in-memory map,
+// standing in for Postgres in tests.
+type fakePreferenceRepository struct {
+	byUser  map[uint][]string
+	ratings map[uint]float64
+	reads   uint32
+}
+
+func (r *fakePreferenceRepository) ListPreferences(_ context.Context, userID uint) ([]models.Preference, error) {
+	atomic.AddUint32(&r.reads, 1)
+	tags := r.byUser[userID]
+	prefs := make([]models.Preference, len(tags))
+	for i, tag := range tags {
+		prefs[i] = models.Preference{UserID: userID, Tag: tag}
+	}
+	return prefs, nil
+}
+
+func (r *fakePreferenceRepository) hasPreference(userID uint, tag string) bool {
+	for _, t := range r.byUser[userID] {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *fakePreferenceRepository) AddPreference(_ context.Context, userID uint, tag string) error {
+	if r.hasPreference(userID, tag) {
+		return nil
+	}
+	r.byUser[userID] = append(r.byUser[userID], tag)
+	return nil
+}
+
+func (r *fakePreferenceRepository) AddPreferenceWithLimit(_ context.Context, userID uint, tag string, maxPreferences int) error {
+	if r.hasPreference(userID, tag) {
+		return nil
+	}
+	if len(r.byUser[userID]) >= maxPreferences {
+		return repository.ErrPreferenceLimitExceeded
+	}
+	r.byUser[userID] = append(r.byUser[userID], tag)
+	return nil
+}
+
+func (r *fakePreferenceRepository) ListUserIDsForTag(_ context.Context, tag string) ([]uint, error) {
+	var ids []uint
+	for userID, tags := range r.byUser {
+		for _, t := range tags {
+			if t == tag {
+				ids = append(ids, userID)
+				break
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (r *fakePreferenceRepository) DeletePreferencesByTag(_ context.Context, tag string) (int64, error) {
+	var deleted int64
+	for userID, tags := range r.byUser {
+		kept := tags[:0]
+		for _, t := range tags {
+			if t == tag {
+				deleted++
+				continue
+			}
+			kept = append(kept, t)
+		}
+		r.byUser[userID] = kept
+	}
+	return deleted, nil
+}
+
+func (r *fakePreferenceRepository) FindUsersByTag(_ context.Context, tag string, limit, offset int) ([]models.User, error) {
+	var matched []models.User
+	for userID, tags := range r.byUser {
+		for _, t := range tags {
+			if t == tag {
+				matched = append(matched, models.User{ID: userID, Rating: r.ratings[userID]})
+				break
+			}
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Rating > matched[j].Rating })
+
+	if offset >= len(matched) {
+		return nil, nil
+	}
+	matched = matched[offset:]
+	if limit > 0 && limit < len(matched) {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
+
+func newTestService(t *testing.T) (*UserService, *countingUserRepository) {
+	svc, repo, _ := newTestServiceWithPrefs(t)
+	return svc, repo
+}
+
+func newTestServiceWithPrefs(t *testing.T) (*UserService, *countingUserRepository, *fakePreferenceRepository) {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := &countingUserRepository{}
+	prefs := &fakePreferenceRepository{byUser: map[uint][]string{}}
+	svc := NewUserService(repo, prefs, repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0), zap.NewNop(), 0, GeoLimits{}, nil, nil, LocationDebounceConfig{}, FeatureFlagConfig{}, 0, false)
+	return svc, repo, prefs
+}
+
+func TestUpdateUsername_RejectsEmptyUsernameByDefault(t *testing.T) {
+	svc, _ := newTestService(t)
+
+	_, err := svc.UpdateUsername(context.Background(), &userpb.UpdateUsernameRequest{UserId: 1, Username: ""})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an empty username, got %v", err)
+	}
+}
+
+func TestUpdateUsername_AllowsEmptyUsernameWhenConfigured(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := &countingUserRepository{}
+	prefs := &fakePreferenceRepository{byUser: map[uint][]string{}}
+	svc := NewUserService(repo, prefs, repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0), zap.NewNop(), 0, GeoLimits{}, nil, nil, LocationDebounceConfig{}, FeatureFlagConfig{}, 0, true)
+
+	resp, err := svc.UpdateUsername(context.Background(), &userpb.UpdateUsernameRequest{UserId: 1, Username: ""})
+	if err != nil {
+		t.Fatalf("UpdateUsername: %v", err)
+	}
+	if resp.Username != "" {
+		t.Fatalf("expected the empty username to be accepted, got %+v", resp)
+	}
+}
+
+func TestUpdateUser_IncludeStatsReturnsRatingAlongsideTheUser(t *testing.T) {
+	svc, repo := newTestService(t)
+	repo.ratings = map[uint]float64{1: 4.5}
+
+	resp, err := svc.UpdateUser(context.Background(), &userpb.UpdateUserRequest{UserId: 1, Username: "blackbeard", IncludeStats: true})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if resp.User == nil {
+		t.Fatalf("expected the updated user to be returned")
+	}
+	if resp.Stats == nil || resp.Stats.Rating != 4.5 {
+		t.Fatalf("expected stats with the user's rating, got %+v", resp.Stats)
+	}
+}
+
+func TestUpdateUser_WithoutIncludeStatsReturnsThePlainUser(t *testing.T) {
+	svc, repo := newTestService(t)
+	repo.ratings = map[uint]float64{1: 4.5}
+
+	resp, err := svc.UpdateUser(context.Background(), &userpb.UpdateUserRequest{UserId: 1, Username: "blackbeard"})
+	if err != nil {
+		t.Fatalf("UpdateUser: %v", err)
+	}
+	if resp.User == nil {
+		t.Fatalf("expected the updated user to be returned")
+	}
+	if resp.Stats != nil {
+		t.Fatalf("expected no stats when include_stats is unset, got %+v", resp.Stats)
+	}
+}
+
+func withIdempotencyKey(key string) context.Context {
+	md := metadata.Pairs(idempotencyKeyMetadata, key)
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestCreateUser_IdempotentReplayReturnsSameUser(t *testing.T) {
+	svc, repo := newTestService(t)
+	ctx := withIdempotencyKey("retry-123")
+	req := &userpb.CreateUserRequest{TelegramId: 42, Username: "blackbeard"}
+
+	first, err := svc.CreateUser(ctx, req)
+	if err != nil {
+		t.Fatalf("first CreateUser: %v", err)
+	}
+	second, err := svc.CreateUser(ctx, req)
+	if err != nil {
+		t.Fatalf("second CreateUser: %v", err)
+	}
+
+	if first.Id != second.Id {
+		t.Fatalf("expected identical user, got ids %d and %d", first.Id, second.Id)
+	}
+	if got := atomic.LoadUint32(&repo.inserts); got != 1 {
+		t.Fatalf("expected exactly one DB insert, got %d", got)
+	}
+}
+
+func TestCreateUser_ConcurrentReplaysInsertOnce(t *testing.T) {
+	svc, repo := newTestService(t)
+	req := &userpb.CreateUserRequest{TelegramId: 7, Username: "anne-bonny"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := withIdempotencyKey("concurrent-key")
+			if _, err := svc.CreateUser(ctx, req); err != nil {
+				t.Errorf("CreateUser: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadUint32(&repo.inserts); got != 1 {
+		t.Fatalf("expected exactly one DB insert across concurrent replays, got %d", got)
+	}
+}
+
+func TestGetUserPreferences_FallsBackToDBOnCacheMiss(t *testing.T) {
+	svc, _, prefs := newTestServiceWithPrefs(t)
+	prefs.byUser[3] = []string{"night-owl", "long-voyages"}
+
+	resp, err := svc.GetUserPreferences(context.Background(), &userpb.GetUserPreferencesRequest{UserId: 3})
+	if err != nil {
+		t.Fatalf("GetUserPreferences: %v", err)
+	}
+	if len(resp.Tags) != 2 {
+		t.Fatalf("expected 2 tags from the DB fallback, got %v", resp.Tags)
+	}
+	if got := atomic.LoadUint32(&prefs.reads); got != 1 {
+		t.Fatalf("expected exactly one DB read on cache miss, got %d", got)
+	}
+
+	// A second call should now be served from cache without touching Postgres.
+	if _, err := svc.GetUserPreferences(context.Background(), &userpb.GetUserPreferencesRequest{UserId: 3}); err != nil {
+		t.Fatalf("GetUserPreferences (cached): %v", err)
+	}
+	if got := atomic.LoadUint32(&prefs.reads); got != 1 {
+		t.Fatalf("expected the cached read to skip Postgres, got %d db reads", got)
+	}
+}
+
+func TestGetUserPreferences_OrderIsStableAcrossCacheAndDBReads(t *testing.T) {
+	svc, _, prefs := newTestServiceWithPrefs(t)
+	want := []string{"night-owl", "long-voyages", "treasure-hunting"}
+	prefs.byUser[3] = want
+
+	resp, err := svc.GetUserPreferences(context.Background(), &userpb.GetUserPreferencesRequest{UserId: 3})
+	if err != nil {
+		t.Fatalf("GetUserPreferences (db): %v", err)
+	}
+	assertTagOrder(t, "db read", resp.Tags, want)
+
+	// The cached read must return the same order, not a re-sorted one.
+	resp, err = svc.GetUserPreferences(context.Background(), &userpb.GetUserPreferencesRequest{UserId: 3})
+	if err != nil {
+		t.Fatalf("GetUserPreferences (cache): %v", err)
+	}
+	assertTagOrder(t, "cached read", resp.Tags, want)
+}
+
+func assertTagOrder(t *testing.T, label string, got, want []string) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: expected %v, got %v", label, want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("%s: expected order %v, got %v", label, want, got)
+		}
+	}
+}
+
+func TestGetUser_NotFoundMessageDefaultsToEnglish(t *testing.T) {
+	svc, users := newTestService(t)
+	users.CreateUser(context.Background(), &models.User{Username: "one-legged-pete"})
+	users.SetBanned(context.Background(), 1, true)
+
+	_, err := svc.GetUser(context.Background(), &userpb.GetUserRequest{UserId: 1, BypassCache: true})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+	if want := "user 1 not found"; status.Convert(err).Message() != want {
+		t.Fatalf("expected message %q, got %q", want, status.Convert(err).Message())
+	}
+}
+
+func TestGetUser_NotFoundMessageIsLocalizedFromContext(t *testing.T) {
+	svc, users := newTestService(t)
+	users.CreateUser(context.Background(), &models.User{Username: "one-legged-pete"})
+	users.SetBanned(context.Background(), 1, true)
+
+	ctx := i18n.ContextWithLocale(context.Background(), "es")
+	_, err := svc.GetUser(ctx, &userpb.GetUserRequest{UserId: 1, BypassCache: true})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+	if want := "usuario 1 no encontrado"; status.Convert(err).Message() != want {
+		t.Fatalf("expected the localized message %q, got %q", want, status.Convert(err).Message())
+	}
+}
+
+func TestGetUser_TombstonesANotFoundIDToAvoidRepeatedDBMisses(t *testing.T) {
+	svc, users := newTestService(t)
+	users.CreateUser(context.Background(), &models.User{Username: "one-legged-pete"})
+	users.SetBanned(context.Background(), 1, true)
+
+	if _, err := svc.GetUser(context.Background(), &userpb.GetUserRequest{UserId: 1}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+	if got := atomic.LoadUint32(&users.activeReads); got != 1 {
+		t.Fatalf("expected the first miss to hit the db once, got %d reads", got)
+	}
+
+	if _, err := svc.GetUser(context.Background(), &userpb.GetUserRequest{UserId: 1}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound on the second call, got %v", err)
+	}
+	if got := atomic.LoadUint32(&users.activeReads); got != 1 {
+		t.Fatalf("expected the second miss to be served from the tombstone without hitting the db, got %d reads", got)
+	}
+}
+
+func TestGetUser_ClearingTheTombstoneLetsTheNextCallReachTheDB(t *testing.T) {
+	svc, users := newTestService(t)
+	users.CreateUser(context.Background(), &models.User{Username: "one-legged-pete"})
+	users.SetBanned(context.Background(), 1, true)
+
+	if _, err := svc.GetUser(context.Background(), &userpb.GetUserRequest{UserId: 1}); status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got %v", err)
+	}
+
+	// UpdateUsername/CreateUser/unban all clear this tombstone in
+	// production; simulate that here rather than going through one of
+	// those write paths, so this test stays focused on GetUser's own
+	// behavior.
+	users.SetBanned(context.Background(), 1, false)
+	if err := svc.cache.ClearUserNotFound(context.Background(), 1); err != nil {
+		t.Fatalf("ClearUserNotFound: %v", err)
+	}
+
+	if _, err := svc.GetUser(context.Background(), &userpb.GetUserRequest{UserId: 1}); err != nil {
+		t.Fatalf("expected the id to be visible again after the tombstone was cleared, got %v", err)
+	}
+	if got := atomic.LoadUint32(&users.activeReads); got != 2 {
+		t.Fatalf("expected the cleared tombstone to let the second call reach the db, got %d reads", got)
+	}
+}
+
+func TestGetUser_BypassCacheConsultsDBEvenWithStaleCacheEntry(t *testing.T) {
+	svc, repo := newTestService(t)
+	ctx := context.Background()
+
+	if err := svc.cache.SetUser(ctx, &models.User{ID: 5, Username: "stale-name"}); err != nil {
+		t.Fatalf("seed stale cache: %v", err)
+	}
+
+	resp, err := svc.GetUser(ctx, &userpb.GetUserRequest{UserId: 5, BypassCache: true})
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if got := atomic.LoadUint32(&repo.activeReads); got != 1 {
+		t.Fatalf("expected bypass_cache to force exactly one DB read, got %d", got)
+	}
+	if resp.Username == "stale-name" {
+		t.Fatalf("expected the stale cache entry to be ignored, got %+v", resp)
+	}
+
+	cached, err := svc.cache.GetUser(ctx, 5)
+	if err != nil {
+		t.Fatalf("GetUser (cache): %v", err)
+	}
+	if cached.Username == "stale-name" {
+		t.Fatalf("expected the cache to be refreshed with the DB value, got %+v", cached)
+	}
+}
+
+func TestGetUser_ReportsOnlineForARecentlyActiveUser(t *testing.T) {
+	svc, repo := newTestService(t)
+	repo.lastActive = map[uint]time.Time{5: time.Now().Add(-time.Minute)}
+
+	resp, err := svc.GetUser(context.Background(), &userpb.GetUserRequest{UserId: 5, BypassCache: true})
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if !resp.IsOnline {
+		t.Fatalf("expected a user active a minute ago to be reported online, got %+v", resp)
+	}
+}
+
+func TestGetUser_ReportsOfflineForAStaleUser(t *testing.T) {
+	svc, repo := newTestService(t)
+	repo.lastActive = map[uint]time.Time{5: time.Now().Add(-time.Hour)}
+
+	resp, err := svc.GetUser(context.Background(), &userpb.GetUserRequest{UserId: 5, BypassCache: true})
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if resp.IsOnline {
+		t.Fatalf("expected a user last active an hour ago to be reported offline, got %+v", resp)
+	}
+}
+
+func TestGetUser_ServesStaleCopyAndRefreshesInBackgroundPastSoftTTL(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := &countingUserRepository{ratings: map[uint]float64{7: 4.5}}
+	prefs := &fakePreferenceRepository{byUser: map[uint][]string{}}
+	svc := NewUserService(repo, prefs, repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0), zap.NewNop(), 0, GeoLimits{}, nil, nil, LocationDebounceConfig{}, FeatureFlagConfig{}, 0, false)
+	ctx := context.Background()
+
+	// Seed both the soft-TTL entry and the longer-lived stale copy, as a
+	// normal GetUser would, without touching the DB read counter.
+	seeded := &models.User{ID: 7, Username: "old-quartermaster", Rating: 4.5}
+	if err := svc.cache.SetUser(ctx, seeded); err != nil {
+		t.Fatalf("seed cache: %v", err)
+	}
+	if err := svc.cache.SetStaleUser(ctx, seeded); err != nil {
+		t.Fatalf("seed stale cache: %v", err)
+	}
+
+	// Advance past the (default 15m) soft TTL, expiring the normal cache
+	// entry in Redis but not the stale fallback copy.
+	mr.FastForward(16 * time.Minute)
+
+	resp, err := svc.GetUser(ctx, &userpb.GetUserRequest{UserId: 7})
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if resp.Username != "old-quartermaster" {
+		t.Fatalf("expected the stale copy's data, got %+v", resp)
+	}
+	if !resp.Stale {
+		t.Fatalf("expected Stale to be true once the normal cache entry has passed its soft TTL")
+	}
+
+	deadline := time.After(time.Second)
+	for atomic.LoadUint32(&repo.activeReads) == 0 {
+		select {
+		case <-deadline:
+			t.Fatalf("expected a background refresh to read from the db")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestAddUserPreference_RejectsOnceLimitReached(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	repo := &countingUserRepository{}
+	prefs := &fakePreferenceRepository{byUser: map[uint][]string{}}
+	svc := NewUserService(repo, prefs, repository.NewResilientCacheRepository(repository.NewCacheRepository(client, ""), 0, 0, "", 0), zap.NewNop(), 2, GeoLimits{}, nil, nil, LocationDebounceConfig{}, FeatureFlagConfig{}, 0, false)
+
+	ctx := context.Background()
+	if _, err := svc.AddUserPreference(ctx, &userpb.AddUserPreferenceRequest{UserId: 1, Tag: "rum"}); err != nil {
+		t.Fatalf("first AddUserPreference: %v", err)
+	}
+	if _, err := svc.AddUserPreference(ctx, &userpb.AddUserPreferenceRequest{UserId: 1, Tag: "parley"}); err != nil {
+		t.Fatalf("second AddUserPreference: %v", err)
+	}
+
+	_, err = svc.AddUserPreference(ctx, &userpb.AddUserPreferenceRequest{UserId: 1, Tag: "plank"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition once at the limit, got %v", err)
+	}
+}
+
+func TestAddUserPreference_ReAddingTheSameTagIsIdempotent(t *testing.T) {
+	svc, _, prefs := newTestServiceWithPrefs(t)
+	prefs.byUser[1] = []string{}
+	ctx := context.Background()
+
+	if _, err := svc.AddUserPreference(ctx, &userpb.AddUserPreferenceRequest{UserId: 1, Tag: "rum"}); err != nil {
+		t.Fatalf("first AddUserPreference: %v", err)
+	}
+	if _, err := svc.AddUserPreference(ctx, &userpb.AddUserPreferenceRequest{UserId: 1, Tag: "rum"}); err != nil {
+		t.Fatalf("second AddUserPreference: %v", err)
+	}
+
+	if got := prefs.byUser[1]; len(got) != 1 {
+		t.Fatalf("expected exactly one stored preference after adding the same tag twice, got %v", got)
+	}
+}
+
+func TestAddUserPreference_IncrementsTagPopularity(t *testing.T) {
+	svc, _, prefs := newTestServiceWithPrefs(t)
+	prefs.byUser[1] = []string{}
+	prefs.byUser[2] = []string{}
+	ctx := context.Background()
+
+	if _, err := svc.AddUserPreference(ctx, &userpb.AddUserPreferenceRequest{UserId: 1, Tag: "rum"}); err != nil {
+		t.Fatalf("AddUserPreference(1, rum): %v", err)
+	}
+	if _, err := svc.AddUserPreference(ctx, &userpb.AddUserPreferenceRequest{UserId: 2, Tag: "rum"}); err != nil {
+		t.Fatalf("AddUserPreference(2, rum): %v", err)
+	}
+
+	resp, err := svc.GetPopularTags(ctx, &userpb.GetPopularTagsRequest{Limit: 10})
+	if err != nil {
+		t.Fatalf("GetPopularTags: %v", err)
+	}
+	if len(resp.Tags) != 1 || resp.Tags[0].Tag != "rum" || resp.Tags[0].Count != 2 {
+		t.Fatalf("expected rum with count 2, got %+v", resp.Tags)
+	}
+
+	if _, err := svc.AddUserPreference(ctx, &userpb.AddUserPreferenceRequest{UserId: 1, Tag: "rum"}); err != nil {
+		t.Fatalf("re-add AddUserPreference(1, rum): %v", err)
+	}
+	resp, err = svc.GetPopularTags(ctx, &userpb.GetPopularTagsRequest{Limit: 10})
+	if err != nil {
+		t.Fatalf("GetPopularTags after re-add: %v", err)
+	}
+	if len(resp.Tags) != 1 || resp.Tags[0].Count != 2 {
+		t.Fatalf("expected re-adding an existing tag not to move the popularity count, got %+v", resp.Tags)
+	}
+}
+
+func (r *countingUserRepository) DeleteUser(context.Context, uint) error { return nil }
+
+func (r *countingUserRepository) UpdateUserRating(context.Context, uint, float64, uint) error { return nil }
+
+func (r *countingUserRepository) RecomputeUserRating(context.Context, uint) error { return nil }
+
+func (r *countingUserRepository) GetRatingHistory(_ context.Context, id uint, limit int) ([]models.UserRatingEvent, error) {
+	if r.ratingHistoryErr != nil {
+		return nil, r.ratingHistoryErr
+	}
+	events := r.ratingHistory[id]
+	if limit > 0 && limit < len(events) {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (r *countingUserRepository) DeleteRatingHistory(_ context.Context, id uint) error {
+	delete(r.ratingHistory, id)
+	return nil
+}
+
+// UserExists reports r.existingIDs[id]. A nil existingIDs treats every
+// id as existing, matching GetUserByID/GetActiveUserByID's own
+// permissive default above.
+func (r *countingUserRepository) UserExists(_ context.Context, id uint) (bool, error) {
+	if r.existingIDs == nil {
+		return true, nil
+	}
+	return r.existingIDs[id], nil
+}
+
+func (r *countingUserRepository) UserExistsByTelegramID(context.Context, int64) (bool, error) {
+	return false, nil
+}
+
+func (r *countingUserRepository) GetUserFeatures(_ context.Context, id uint) (models.FeatureFlags, error) {
+	if r.features == nil {
+		return models.FeatureFlags{}, nil
+	}
+	return r.features[id], nil
+}
+
+func (r *countingUserRepository) SetUserFeature(_ context.Context, id uint, key string, value bool) error {
+	if r.features == nil {
+		r.features = map[uint]models.FeatureFlags{}
+	}
+	if r.features[id] == nil {
+		r.features[id] = models.FeatureFlags{}
+	}
+	r.features[id][key] = value
+	return nil
+}
+
+func (r *fakePreferenceRepository) DeleteAllForUser(_ context.Context, userID uint) error {
+	delete(r.byUser, userID)
+	return nil
+}
+
+func TestFindUsersByTag_OnlyReturnsUsersWithTheTag(t *testing.T) {
+	svc, _, prefs := newTestServiceWithPrefs(t)
+	prefs.byUser[1] = []string{"rum"}
+	prefs.byUser[2] = []string{"parley"}
+	prefs.byUser[3] = []string{"rum", "parley"}
+
+	resp, err := svc.FindUsersByTag(context.Background(), &userpb.FindUsersByTagRequest{Tag: "rum"})
+	if err != nil {
+		t.Fatalf("FindUsersByTag: %v", err)
+	}
+	if len(resp.Users) != 2 {
+		t.Fatalf("expected 2 users tagged rum, got %d", len(resp.Users))
+	}
+	for _, u := range resp.Users {
+		if u.Id != 1 && u.Id != 3 {
+			t.Fatalf("unexpected user %d in results", u.Id)
+		}
+	}
+}
+
+func TestFindUsersByTag_OrdersByRatingDescendingAndPaginates(t *testing.T) {
+	svc, _, prefs := newTestServiceWithPrefs(t)
+	prefs.byUser[1] = []string{"rum"}
+	prefs.byUser[2] = []string{"rum"}
+	prefs.byUser[3] = []string{"rum"}
+	prefs.ratings = map[uint]float64{1: 1.0, 2: 3.0, 3: 2.0}
+
+	resp, err := svc.FindUsersByTag(context.Background(), &userpb.FindUsersByTagRequest{Tag: "rum", Limit: 2})
+	if err != nil {
+		t.Fatalf("FindUsersByTag: %v", err)
+	}
+	if len(resp.Users) != 2 || resp.Users[0].Id != 2 || resp.Users[1].Id != 3 {
+		t.Fatalf("expected top 2 by rating [2, 3], got %+v", resp.Users)
+	}
+
+	resp, err = svc.FindUsersByTag(context.Background(), &userpb.FindUsersByTagRequest{Tag: "rum", Limit: 2, Offset: 2})
+	if err != nil {
+		t.Fatalf("FindUsersByTag with offset: %v", err)
+	}
+	if len(resp.Users) != 1 || resp.Users[0].Id != 1 {
+		t.Fatalf("expected the last remaining user [1] after offset, got %+v", resp.Users)
+	}
+}
@@ -0,0 +1,70 @@
+// Package validation attaches per-field detail to gRPC InvalidArgument
+// errors, in the spirit of google.rpc.BadRequest.FieldViolation, so
+// clients can map a rejected request back to the offending form field
+// instead of pattern-matching a flat message string.
+//
+// The real thing - google.golang.org/genproto/googleapis/rpc/errdetails
+// plus status.WithDetails - isn't usable here: errdetails isn't a
+// dependency of this module, and status.WithDetails requires each
+// detail to implement proto.Message, which the hand-written stand-ins
+// under proto/*pb don't. Violations are instead encoded as a JSON blob
+// appended to the status message, which FromError decodes back out.
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FieldViolation names one invalid field in a request and describes why
+// it was rejected, mirroring google.rpc.BadRequest.FieldViolation's
+// Field/Description shape.
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// violationMarker prefixes the JSON-encoded violations appended to a
+// status message, so FromError can find and strip it without disturbing
+// the human-readable prefix.
+const violationMarker = "field_violations="
+
+// Errorf builds a gRPC status error with the given code, whose message
+// is format/args (as status.Errorf would produce on its own) followed
+// by violations encoded for FromError to decode. If violations is
+// empty, Errorf behaves exactly like status.Errorf.
+func Errorf(code codes.Code, violations []FieldViolation, format string, args ...interface{}) error {
+	msg := fmt.Sprintf(format, args...)
+	if len(violations) == 0 {
+		return status.Error(code, msg)
+	}
+	encoded, err := json.Marshal(violations)
+	if err != nil {
+		return status.Error(code, msg)
+	}
+	return status.Error(code, fmt.Sprintf("%s (%s%s)", msg, violationMarker, encoded))
+}
+
+// FromError decodes the field violations Errorf attached to err, or
+// returns nil if err is not a status error or carries none.
+func FromError(err error) []FieldViolation {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	msg := st.Message()
+	idx := strings.LastIndex(msg, violationMarker)
+	if idx == -1 {
+		return nil
+	}
+	encoded := strings.TrimSuffix(msg[idx+len(violationMarker):], ")")
+	var violations []FieldViolation
+	if jsonErr := json.Unmarshal([]byte(encoded), &violations); jsonErr != nil {
+		return nil
+	}
+	return violations
+}
@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorf_RoundTripsFieldViolationsThroughAStatusError(t *testing.T) {
+	err := Errorf(codes.InvalidArgument,
+		[]FieldViolation{{Field: "username", Description: "must not be empty"}},
+		"username must not be empty")
+
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", status.Code(err))
+	}
+
+	violations := FromError(err)
+	if len(violations) != 1 {
+		t.Fatalf("expected 1 violation, got %d: %+v", len(violations), violations)
+	}
+	if violations[0].Field != "username" {
+		t.Fatalf("expected offending field %q, got %q", "username", violations[0].Field)
+	}
+}
+
+func TestErrorf_WithNoViolationsBehavesLikeStatusErrorf(t *testing.T) {
+	err := Errorf(codes.InvalidArgument, nil, "telegram_id must be positive, got %d", -1)
+
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument, got %v", status.Code(err))
+	}
+	if status.Convert(err).Message() != "telegram_id must be positive, got -1" {
+		t.Fatalf("unexpected message: %q", status.Convert(err).Message())
+	}
+	if violations := FromError(err); violations != nil {
+		t.Fatalf("expected no violations, got %+v", violations)
+	}
+}
+
+func TestFromError_ReturnsNilForAPlainError(t *testing.T) {
+	if violations := FromError(status.Error(codes.Internal, "boom")); violations != nil {
+		t.Fatalf("expected nil violations for an error with none attached, got %+v", violations)
+	}
+}
@@ -0,0 +1,173 @@
+// Package migrations applies numbered SQL files to Postgres and tracks
+// which ones have run in a schema_migrations table. It replaces GORM's
+// AutoMigrate, which can create tables and columns but can't express
+// index changes or data migrations safely.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+//go:embed *.sql
+var sqlFiles embed.FS
+
+// Migration is a single numbered schema change, parsed from a
+// "<version>_<name>.sql" file.
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+// createSchemaMigrationsTable records which migration versions have
+// already been applied, so Run only ever applies a given version once.
+const createSchemaMigrationsTable = `
+CREATE TABLE IF NOT EXISTS schema_migrations (
+	version INTEGER PRIMARY KEY,
+	applied_at TIMESTAMP NOT NULL
+)`
+
+// Load reads and parses every migration embedded alongside this
+// package, sorted ascending by version.
+func Load() ([]Migration, error) {
+	return loadFS(sqlFiles)
+}
+
+func loadFS(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	all := make([]Migration, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		version, name, err := parseFilename(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		contents, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, Migration{Version: version, Name: name, SQL: string(contents)})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Version < all[j].Version })
+	return all, nil
+}
+
+// parseFilename splits "0001_init_schema.sql" into version 1 and name
+// "init_schema".
+func parseFilename(filename string) (int, string, error) {
+	base := strings.TrimSuffix(filename, ".sql")
+	parts := strings.SplitN(base, "_", 2)
+	version, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("migration filename %q must start with a numeric version: %w", filename, err)
+	}
+	name := base
+	if len(parts) == 2 {
+		name = parts[1]
+	}
+	return version, name, nil
+}
+
+// pending returns the subset of all not yet recorded in applied,
+// preserving all's version order.
+func pending(all []Migration, applied map[int]bool) []Migration {
+	var out []Migration
+	for _, m := range all {
+		if !applied[m.Version] {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// Run applies every migration in all that isn't already recorded in
+// schema_migrations, in ascending version order, each in its own
+// transaction. It's safe to call on every startup: a fresh database
+// applies everything, and one that's already current is a no-op.
+func Run(ctx context.Context, db *sql.DB, all []Migration) error {
+	if _, err := db.ExecContext(ctx, createSchemaMigrationsTable); err != nil {
+		return fmt.Errorf("create schema_migrations table: %w", err)
+	}
+
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return fmt.Errorf("read applied migrations: %w", err)
+	}
+
+	for _, m := range pending(all, applied) {
+		if err := applyOne(ctx, db, m); err != nil {
+			return fmt.Errorf("apply migration %d_%s: %w", m.Version, m.Name, err)
+		}
+	}
+	return nil
+}
+
+// RunMigrations loads the migrations embedded in this package and
+// applies any pending ones against db's underlying connection. It's the
+// entry point startup calls in place of AutoMigrate.
+func RunMigrations(ctx context.Context, db *gorm.DB) error {
+	all, err := Load()
+	if err != nil {
+		return fmt.Errorf("load migrations: %w", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("get underlying sql.DB: %w", err)
+	}
+	return Run(ctx, sqlDB, all)
+}
+
+func appliedVersions(ctx context.Context, db *sql.DB) (map[int]bool, error) {
+	rows, err := db.QueryContext(ctx, "SELECT version FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]bool{}
+	for rows.Next() {
+		var version int
+		if err := rows.Scan(&version); err != nil {
+			return nil, err
+		}
+		applied[version] = true
+	}
+	return applied, rows.Err()
+}
+
+// applyOne runs a single migration's SQL and records its version, in
+// one transaction so a failure partway through never leaves the version
+// marked applied. The version is formatted directly into the INSERT
+// rather than bound as a parameter, since $-style placeholders aren't
+// portable across the SQL drivers this runner is used with (Postgres in
+// production, SQLite in tests) and m.Version is our own int, not
+// untrusted input.
+func applyOne(ctx context.Context, db *sql.DB, m Migration) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, m.SQL); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("INSERT INTO schema_migrations (version, applied_at) VALUES (%d, CURRENT_TIMESTAMP)", m.Version)); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
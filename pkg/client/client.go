@@ -0,0 +1,329 @@
+// Package client provides a thin gRPC client for downstream services
+// that need to call JollyRogerUserService, so they don't each
+// reimplement dialing, TLS, timeouts, and retries.
+package client
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+// defaultCallTimeout bounds how long a single RPC made through this
+// client may take, absent a deadline already set on the caller's ctx.
+const defaultCallTimeout = 5 * time.Second
+
+// defaultMaxRetries is how many times a call is retried after an
+// Unavailable error before giving up.
+const defaultMaxRetries = 2
+
+// defaultRetryBackoff is the delay between retry attempts.
+const defaultRetryBackoff = 100 * time.Millisecond
+
+type options struct {
+	creds        credentials.TransportCredentials
+	callTimeout  time.Duration
+	maxRetries   int
+	retryBackoff time.Duration
+}
+
+// Option configures a UserServiceClient.
+type Option func(*options)
+
+// WithTLS dials using creds instead of the insecure transport used by
+// default. Downstream services talking to JollyRogerUserService across
+// a network boundary should set this.
+func WithTLS(creds credentials.TransportCredentials) Option {
+	return func(o *options) { o.creds = creds }
+}
+
+// WithCallTimeout overrides the default per-call timeout.
+func WithCallTimeout(d time.Duration) Option {
+	return func(o *options) { o.callTimeout = d }
+}
+
+// WithMaxRetries overrides how many times an Unavailable call is
+// retried before giving up.
+func WithMaxRetries(n int) Option {
+	return func(o *options) { o.maxRetries = n }
+}
+
+// UserServiceClient is a typed wrapper around the generated
+// userpb.UserServiceClient, adding retries and result conversion into
+// internal/models types.
+type UserServiceClient struct {
+	conn *grpc.ClientConn
+	pb   userpb.UserServiceClient
+	opts options
+}
+
+// NewUserServiceClient dials addr and returns a ready-to-use client. By
+// default it dials without transport security and applies
+// defaultCallTimeout/defaultMaxRetries; use WithTLS/WithCallTimeout/
+// WithMaxRetries to override.
+func NewUserServiceClient(addr string, opts ...Option) (*UserServiceClient, error) {
+	o := options{
+		creds:        insecure.NewCredentials(),
+		callTimeout:  defaultCallTimeout,
+		maxRetries:   defaultMaxRetries,
+		retryBackoff: defaultRetryBackoff,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	conn, err := grpc.Dial(addr,
+		grpc.WithTransportCredentials(o.creds),
+		grpc.WithUnaryInterceptor(retryInterceptor(o.maxRetries, o.retryBackoff)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &UserServiceClient{conn: conn, pb: userpb.NewUserServiceClient(conn), opts: o}, nil
+}
+
+// Close releases the underlying connection.
+func (c *UserServiceClient) Close() error {
+	return c.conn.Close()
+}
+
+// GetUser fetches a single user by id.
+func (c *UserServiceClient) GetUser(ctx context.Context, id uint64) (*models.User, error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.pb.GetUser(ctx, &userpb.GetUserRequest{UserId: id})
+	if err != nil {
+		return nil, err
+	}
+	return fromUserResponse(resp)
+}
+
+// GetUsersByTelegramIDs resolves many Telegram user ids in a single
+// call, for callers (e.g. the bot backend on a group join) that need to
+// resolve a whole batch at once rather than one GetUser call per id. A
+// telegram_id with no matching account is simply absent from the
+// returned map.
+func (c *UserServiceClient) GetUsersByTelegramIDs(ctx context.Context, telegramIDs []int64) (map[int64]*models.User, error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.pb.GetUsersByTelegramIDs(ctx, &userpb.GetUsersByTelegramIDsRequest{TelegramIds: telegramIDs})
+	if err != nil {
+		return nil, err
+	}
+	byTelegramID := make(map[int64]*models.User, len(resp.Users))
+	for _, u := range resp.Users {
+		user, err := fromUserResponse(u)
+		if err != nil {
+			return nil, err
+		}
+		byTelegramID[user.TelegramID] = user
+	}
+	return byTelegramID, nil
+}
+
+// FindUsersByTag returns the users who have opted into tag, ordered by
+// rating descending, for a matching service doing a reverse lookup
+// ("who likes X"). limit and offset are passed straight through to the
+// server, which caps the limit.
+func (c *UserServiceClient) FindUsersByTag(ctx context.Context, tag string, limit, offset int) ([]*models.User, error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.pb.FindUsersByTag(ctx, &userpb.FindUsersByTagRequest{Tag: tag, Limit: int32(limit), Offset: int32(offset)})
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*models.User, 0, len(resp.Users))
+	for _, u := range resp.Users {
+		user, err := fromUserResponse(u)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// PopularTag is one tag's current popularity count, as returned by
+// GetPopularTags, most popular first.
+type PopularTag struct {
+	Tag   string
+	Count float64
+}
+
+// GetPopularTags returns up to limit tags currently opted into by the
+// most users, most popular first, for a caller building "trending" UI.
+// limit <= 0 falls back to the server's own default.
+func (c *UserServiceClient) GetPopularTags(ctx context.Context, limit int) ([]PopularTag, error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.pb.GetPopularTags(ctx, &userpb.GetPopularTagsRequest{Limit: int32(limit)})
+	if err != nil {
+		return nil, err
+	}
+	tags := make([]PopularTag, len(resp.Tags))
+	for i, t := range resp.Tags {
+		tags[i] = PopularTag{Tag: t.Tag, Count: t.Count}
+	}
+	return tags, nil
+}
+
+// RatingEvent is one rating contribution, as returned by
+// GetRatingHistory, newest first.
+type RatingEvent struct {
+	RaterID   uint64
+	Score     float64
+	CreatedAt string
+}
+
+// GetRatingHistory returns up to limit of userID's recent rating events,
+// newest first, for dispute resolution and "recent feedback" UIs. limit
+// <= 0 falls back to the server's own default.
+func (c *UserServiceClient) GetRatingHistory(ctx context.Context, userID uint, limit int) ([]RatingEvent, error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.pb.GetRatingHistory(ctx, &userpb.GetRatingHistoryRequest{UserId: uint64(userID), Limit: int32(limit)})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]RatingEvent, len(resp.Events))
+	for i, e := range resp.Events {
+		events[i] = RatingEvent{RaterID: e.RaterId, Score: e.Score, CreatedAt: e.CreatedAt}
+	}
+	return events, nil
+}
+
+// FindUsersInBounds returns users whose current location falls within
+// the map viewport rectangle [minLat, maxLat] x [minLon, maxLon], for a
+// caller that already has a bounding box (e.g. a map's visible area)
+// rather than a center point and radius. A minLon greater than maxLon is
+// treated as a box crossing the antimeridian. limit <= 0 falls back to
+// the server's own default.
+func (c *UserServiceClient) FindUsersInBounds(ctx context.Context, minLat, minLon, maxLat, maxLon float64, limit int) ([]*models.User, error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.pb.FindUsersInBounds(ctx, &userpb.FindUsersInBoundsRequest{
+		MinLat: minLat,
+		MinLon: minLon,
+		MaxLat: maxLat,
+		MaxLon: maxLon,
+		Limit:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*models.User, 0, len(resp.Users))
+	for _, u := range resp.Users {
+		user, err := fromUserResponse(u)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// UserExists reports whether a user exists, by id or by Telegram id,
+// without fetching the full user. Exactly one of userID/telegramID
+// should be nonzero; if both are, userID takes precedence.
+func (c *UserServiceClient) UserExists(ctx context.Context, userID uint, telegramID int64) (bool, error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.pb.UserExists(ctx, &userpb.UserExistsRequest{UserId: uint64(userID), TelegramId: telegramID})
+	if err != nil {
+		return false, err
+	}
+	return resp.Exists, nil
+}
+
+// GetUserFeatures returns a user's feature flags.
+func (c *UserServiceClient) GetUserFeatures(ctx context.Context, userID uint) (map[string]bool, error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.pb.GetUserFeatures(ctx, &userpb.GetUserFeaturesRequest{UserId: uint64(userID)})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Flags, nil
+}
+
+// SetUserFeature sets a single feature toggle for a user, returning the
+// full set of flags after the write.
+func (c *UserServiceClient) SetUserFeature(ctx context.Context, userID uint, key string, value bool) (map[string]bool, error) {
+	ctx, cancel := c.withCallTimeout(ctx)
+	defer cancel()
+
+	resp, err := c.pb.SetUserFeature(ctx, &userpb.SetUserFeatureRequest{UserId: uint64(userID), Key: key, Value: value})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Flags, nil
+}
+
+func (c *UserServiceClient) withCallTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, ok := ctx.Deadline(); ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.opts.callTimeout)
+}
+
+func fromUserResponse(resp *userpb.UserResponse) (*models.User, error) {
+	user := &models.User{
+		ID:         uint(resp.Id),
+		TelegramID: resp.TelegramId,
+		Username:   resp.Username,
+		FirstName:  resp.FirstName,
+		LastName:   resp.LastName,
+		IsBot:      resp.IsBot,
+	}
+	if resp.CreatedAt != "" {
+		createdAt, err := time.Parse(time.RFC3339, resp.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		user.CreatedAt = createdAt
+	}
+	if resp.UpdatedAt != "" {
+		updatedAt, err := time.Parse(time.RFC3339, resp.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		user.UpdatedAt = updatedAt
+	}
+	return user, nil
+}
+
+// retryInterceptor retries a unary call up to maxRetries times when it
+// fails with codes.Unavailable, waiting backoff between attempts.
+func retryInterceptor(maxRetries int, backoff time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+		var err error
+		for attempt := 0; attempt <= maxRetries; attempt++ {
+			err = invoker(ctx, method, req, reply, cc, callOpts...)
+			if err == nil || status.Code(err) != codes.Unavailable {
+				return err
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoff):
+			}
+		}
+		return err
+	}
+}
@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"gorm.io/gorm"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/service"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+const bufSize = 1024 * 1024
+
+// staticUserRepository serves GetUserByID from an in-memory slice,
+// standing in for Postgres in tests.
+type staticUserRepository struct {
+	users []models.User
+}
+
+func (r *staticUserRepository) CreateUser(context.Context, *models.User) error { return nil }
+
+func (r *staticUserRepository) CreateUserWithOnboarding(context.Context, *models.User, []string, *models.UserLocation) error {
+	return nil
+}
+
+func (r *staticUserRepository) GetUserByID(_ context.Context, id uint) (*models.User, error) {
+	for i := range r.users {
+		if r.users[i].ID == id {
+			return &r.users[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *staticUserRepository) GetActiveUserByID(ctx context.Context, id uint) (*models.User, error) {
+	return r.GetUserByID(ctx, id)
+}
+
+func (r *staticUserRepository) GetUsersByTelegramIDs(_ context.Context, telegramIDs []int64) (map[int64]*models.User, error) {
+	wanted := make(map[int64]bool, len(telegramIDs))
+	for _, id := range telegramIDs {
+		wanted[id] = true
+	}
+	byTelegramID := make(map[int64]*models.User, len(telegramIDs))
+	for i := range r.users {
+		if wanted[r.users[i].TelegramID] {
+			byTelegramID[r.users[i].TelegramID] = &r.users[i]
+		}
+	}
+	return byTelegramID, nil
+}
+
+func (r *staticUserRepository) SetBanned(context.Context, uint, bool) error      { return nil }
+func (r *staticUserRepository) ChangeTelegramID(context.Context, uint, int64) error { return nil }
+func (r *staticUserRepository) UpdateUsername(context.Context, uint, string) error  { return nil }
+func (r *staticUserRepository) CountUsers(context.Context) (int64, error)           { return int64(len(r.users)), nil }
+func (r *staticUserRepository) UpdateLastActive(context.Context, uint) error     { return nil }
+func (r *staticUserRepository) DeleteUser(context.Context, uint) error           { return nil }
+func (r *staticUserRepository) UpdateUserRating(context.Context, uint, float64, uint) error { return nil }
+func (r *staticUserRepository) RecomputeUserRating(context.Context, uint) error             { return nil }
+func (r *staticUserRepository) GetRatingHistory(context.Context, uint, int) ([]models.UserRatingEvent, error) {
+	return nil, nil
+}
+
+func (r *staticUserRepository) DeleteRatingHistory(context.Context, uint) error { return nil }
+
+func (r *staticUserRepository) UserExists(ctx context.Context, id uint) (bool, error) {
+	_, err := r.GetUserByID(ctx, id)
+	if err == gorm.ErrRecordNotFound {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *staticUserRepository) UserExistsByTelegramID(_ context.Context, telegramID int64) (bool, error) {
+	for i := range r.users {
+		if r.users[i].TelegramID == telegramID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *staticUserRepository) ListUsers(context.Context, uint, int, bool) ([]models.User, error) {
+	return r.users, nil
+}
+
+func (r *staticUserRepository) GetUserFeatures(context.Context, uint) (models.FeatureFlags, error) {
+	return models.FeatureFlags{}, nil
+}
+
+func (r *staticUserRepository) SetUserFeature(context.Context, uint, string, bool) error {
+	return nil
+}
+
+type noopPreferenceRepository struct{}
+
+func (noopPreferenceRepository) ListPreferences(context.Context, uint) ([]models.Preference, error) {
+	return nil, nil
+}
+func (noopPreferenceRepository) AddPreference(context.Context, uint, string) error { return nil }
+func (noopPreferenceRepository) AddPreferenceWithLimit(context.Context, uint, string, int) error {
+	return nil
+}
+func (noopPreferenceRepository) DeleteAllForUser(context.Context, uint) error { return nil }
+func (noopPreferenceRepository) FindUsersByTag(context.Context, string, int, int) ([]models.User, error) {
+	return nil, nil
+}
+func (noopPreferenceRepository) ListUserIDsForTag(context.Context, string) ([]uint, error) {
+	return nil, nil
+}
+func (noopPreferenceRepository) DeletePreferencesByTag(context.Context, string) (int64, error) {
+	return 0, nil
+}
+
+// taggedPreferenceRepository serves FindUsersByTag from an in-memory
+// map, standing in for Postgres in tests.
+type taggedPreferenceRepository struct {
+	byTag map[string][]models.User
+}
+
+func (r *taggedPreferenceRepository) ListPreferences(context.Context, uint) ([]models.Preference, error) {
+	return nil, nil
+}
+func (r *taggedPreferenceRepository) AddPreference(context.Context, uint, string) error { return nil }
+func (r *taggedPreferenceRepository) AddPreferenceWithLimit(context.Context, uint, string, int) error {
+	return nil
+}
+func (r *taggedPreferenceRepository) DeleteAllForUser(context.Context, uint) error { return nil }
+
+func (r *taggedPreferenceRepository) ListUserIDsForTag(context.Context, string) ([]uint, error) {
+	return nil, nil
+}
+
+func (r *taggedPreferenceRepository) DeletePreferencesByTag(context.Context, string) (int64, error) {
+	return 0, nil
+}
+
+func (r *taggedPreferenceRepository) FindUsersByTag(_ context.Context, tag string, limit, offset int) ([]models.User, error) {
+	matched := r.byTag[tag]
+	if offset >= len(matched) {
+		return nil, nil
+	}
+	matched = matched[offset:]
+	if limit > 0 && limit < len(matched) {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
+
+// newTestCacheRepository spins up an in-memory miniredis instance and
+// returns a CacheRepository backed by it, standing in for Redis in tests
+// that need a real client rather than a nil stub.
+func newTestCacheRepository(t *testing.T) *repository.CacheRepository {
+	t.Helper()
+
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	t.Cleanup(mr.Close)
+
+	return repository.NewCacheRepository(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "")
+}
+
+// dialTestServer starts a UserService backed by users over an in-memory
+// bufconn listener and returns a UserServiceClient dialed against it.
+func dialTestServer(t *testing.T, users []models.User) *UserServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(bufSize)
+	t.Cleanup(func() { lis.Close() })
+
+	grpcServer := grpc.NewServer()
+	svc := service.NewUserService(&staticUserRepository{users: users}, noopPreferenceRepository{}, repository.NewResilientCacheRepository(newTestCacheRepository(t), 0, 0, "", 0), zap.NewNop(), 0, service.GeoLimits{}, nil, nil, service.LocationDebounceConfig{}, service.FeatureFlagConfig{}, 0, false)
+	userpb.RegisterUserServiceServer(grpcServer, svc)
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &UserServiceClient{conn: conn, pb: userpb.NewUserServiceClient(conn), opts: options{callTimeout: defaultCallTimeout}}
+}
+
+func TestUserServiceClient_GetUser(t *testing.T) {
+	client := dialTestServer(t, []models.User{{ID: 1, Username: "blackbeard", TelegramID: 42}})
+
+	user, err := client.GetUser(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if user.Username != "blackbeard" || user.TelegramID != 42 {
+		t.Fatalf("unexpected user: %+v", user)
+	}
+}
+
+func TestUserServiceClient_FindUsersByTag(t *testing.T) {
+	lis := bufconn.Listen(bufSize)
+	t.Cleanup(func() { lis.Close() })
+
+	prefs := &taggedPreferenceRepository{byTag: map[string][]models.User{
+		"rum": {{ID: 1, Username: "blackbeard"}, {ID: 2, Username: "anne-bonny"}},
+	}}
+	grpcServer := grpc.NewServer()
+	svc := service.NewUserService(&staticUserRepository{}, prefs, repository.NewResilientCacheRepository(newTestCacheRepository(t), 0, 0, "", 0), zap.NewNop(), 0, service.GeoLimits{}, nil, nil, service.LocationDebounceConfig{}, service.FeatureFlagConfig{}, 0, false)
+	userpb.RegisterUserServiceServer(grpcServer, svc)
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	client := &UserServiceClient{conn: conn, pb: userpb.NewUserServiceClient(conn), opts: options{callTimeout: defaultCallTimeout}}
+
+	users, err := client.FindUsersByTag(context.Background(), "rum", 1, 0)
+	if err != nil {
+		t.Fatalf("FindUsersByTag: %v", err)
+	}
+	if len(users) != 1 || users[0].Username != "blackbeard" {
+		t.Fatalf("expected limit=1 to return blackbeard, got %+v", users)
+	}
+}
@@ -0,0 +1,80 @@
+// Package database holds small, dependency-light helpers for talking to
+// the datastores this service uses, kept separate from
+// internal/repository so they can be reused by other services without
+// pulling in JollyRogerUserService's domain types.
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// defaultReconnectBackoff is how long ReliableSubscriber waits before
+// resubscribing after its Redis pub/sub subscription ends, when
+// NewReliableSubscriber is given a backoff <= 0.
+const defaultReconnectBackoff = time.Second
+
+// ReliableSubscriber wraps a Redis pub/sub subscription so a dropped
+// connection is transparently resubscribed instead of silently ending
+// delivery. go-redis's own PubSub already reconnects its underlying
+// network connection, but a subscription still ends for good if that
+// connection is closed out from under it (e.g. a server restart or the
+// client shutting down mid-read); ReliableSubscriber re-issues Subscribe
+// in that case rather than requiring the caller to notice and restart.
+type ReliableSubscriber struct {
+	client   *redis.Client
+	channels []string
+	backoff  time.Duration
+	log      *zap.Logger
+}
+
+// NewReliableSubscriber returns a ReliableSubscriber that subscribes
+// client to channels, waiting backoff between resubscribe attempts.
+// backoff <= 0 falls back to defaultReconnectBackoff.
+func NewReliableSubscriber(client *redis.Client, log *zap.Logger, backoff time.Duration, channels ...string) *ReliableSubscriber {
+	if backoff <= 0 {
+		backoff = defaultReconnectBackoff
+	}
+	return &ReliableSubscriber{client: client, channels: channels, backoff: backoff, log: log}
+}
+
+// Run subscribes to the configured channels and calls handle for every
+// message received, until ctx is canceled. If the subscription ends for
+// any other reason, Run waits its backoff and resubscribes rather than
+// returning, so a caller can fire Run once in a goroutine for the life
+// of the process and rely on it to keep delivering messages.
+func (s *ReliableSubscriber) Run(ctx context.Context, handle func(msg *redis.Message)) {
+	for ctx.Err() == nil {
+		s.subscribeOnce(ctx, handle)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.backoff):
+		}
+	}
+}
+
+// subscribeOnce runs a single subscription attempt until it ends, either
+// because ctx was canceled or the underlying channel closed.
+func (s *ReliableSubscriber) subscribeOnce(ctx context.Context, handle func(msg *redis.Message)) {
+	pubsub := s.client.Subscribe(ctx, s.channels...)
+	defer pubsub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-pubsub.Channel():
+			if !ok {
+				if s.log != nil {
+					s.log.Warn("reliable subscriber: subscription ended, resubscribing", zap.Strings("channels", s.channels))
+				}
+				return
+			}
+			handle(msg)
+		}
+	}
+}
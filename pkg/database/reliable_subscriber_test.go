@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+func TestReliableSubscriber_ResubscribesAfterTheConnectionDrops(t *testing.T) {
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("start miniredis: %v", err)
+	}
+	defer mr.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	sub := NewReliableSubscriber(client, zap.NewNop(), 10*time.Millisecond, "watch")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	received := make(chan string, 2)
+	go sub.Run(ctx, func(msg *redis.Message) {
+		received <- msg.Payload
+	})
+
+	if !publishUntilDelivered(t, client, received, "before-drop") {
+		t.Fatalf("expected the initial subscription to deliver a published message")
+	}
+
+	// Simulate the pub/sub connection dropping mid-stream, e.g. a Redis
+	// restart or a network blip: Close() tears down the listener and
+	// every accepted connection, and Restart() brings miniredis back up
+	// on the same port.
+	mr.Close()
+	if err := mr.Restart(); err != nil {
+		t.Fatalf("restart miniredis: %v", err)
+	}
+
+	if !publishUntilDelivered(t, client, received, "after-resubscribe") {
+		t.Fatalf("expected resubscription to deliver a message published after the connection dropped")
+	}
+}
+
+// publishUntilDelivered republishes payload on "watch" until received
+// yields it back or deadline elapses, absorbing the small race between a
+// (re)subscription registering with Redis and this test's next publish.
+func publishUntilDelivered(t *testing.T, client *redis.Client, received chan string, payload string) bool {
+	t.Helper()
+	deadline := time.After(2 * time.Second)
+	for {
+		if err := client.Publish(context.Background(), "watch", payload).Err(); err != nil {
+			t.Fatalf("publish %q: %v", payload, err)
+		}
+		select {
+		case got := <-received:
+			return got == payload
+		case <-deadline:
+			return false
+		case <-time.After(50 * time.Millisecond):
+		}
+	}
+}
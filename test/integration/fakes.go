@@ -0,0 +1,412 @@
+package integration
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/models"
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/internal/repository"
+)
+
+// InMemoryUserRepository is a concurrency-safe, in-process stand-in for
+// PostgresUserRepository, for tests that don't need a real database.
+type InMemoryUserRepository struct {
+	mu           sync.Mutex
+	nextID       uint
+	users        map[uint]models.User
+	ratingEvents map[uint][]models.UserRatingEvent
+}
+
+// NewInMemoryUserRepository constructs an empty InMemoryUserRepository.
+func NewInMemoryUserRepository() *InMemoryUserRepository {
+	return &InMemoryUserRepository{users: map[uint]models.User{}}
+}
+
+func (r *InMemoryUserRepository) CreateUser(_ context.Context, user *models.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.nextID++
+	user.ID = r.nextID
+	r.users[user.ID] = *user
+	return nil
+}
+
+// CreateUserWithOnboarding creates user the same way CreateUser does.
+// This fake has no preferences/location storage of its own, so tags and
+// location are accepted (to satisfy the interface) but not persisted;
+// tests exercising onboarding's cross-repository behavior use the
+// service-layer fakes in internal/service instead.
+func (r *InMemoryUserRepository) CreateUserWithOnboarding(ctx context.Context, user *models.User, _ []string, _ *models.UserLocation) error {
+	return r.CreateUser(ctx, user)
+}
+
+func (r *InMemoryUserRepository) GetUserByID(_ context.Context, id uint) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &user, nil
+}
+
+func (r *InMemoryUserRepository) GetActiveUserByID(ctx context.Context, id uint) (*models.User, error) {
+	user, err := r.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Banned {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return user, nil
+}
+
+func (r *InMemoryUserRepository) SetBanned(_ context.Context, id uint, banned bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	user.Banned = banned
+	r.users[id] = user
+	return nil
+}
+
+func (r *InMemoryUserRepository) ChangeTelegramID(_ context.Context, id uint, newTelegramID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for existingID, u := range r.users {
+		if existingID != id && u.TelegramID == newTelegramID {
+			return repository.ErrTelegramIDTaken
+		}
+	}
+	user, ok := r.users[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	user.TelegramID = newTelegramID
+	r.users[id] = user
+	return nil
+}
+
+func (r *InMemoryUserRepository) UpdateUsername(_ context.Context, id uint, username string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	user.Username = username
+	r.users[id] = user
+	return nil
+}
+
+func (r *InMemoryUserRepository) CountUsers(_ context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return int64(len(r.users)), nil
+}
+
+func (r *InMemoryUserRepository) GetUsersByTelegramIDs(_ context.Context, telegramIDs []int64) (map[int64]*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	wanted := make(map[int64]bool, len(telegramIDs))
+	for _, id := range telegramIDs {
+		wanted[id] = true
+	}
+	byTelegramID := make(map[int64]*models.User, len(telegramIDs))
+	for _, user := range r.users {
+		user := user
+		if wanted[user.TelegramID] {
+			byTelegramID[user.TelegramID] = &user
+		}
+	}
+	return byTelegramID, nil
+}
+
+func (r *InMemoryUserRepository) UpdateLastActive(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	user.LastActiveAt = time.Now()
+	r.users[id] = user
+	return nil
+}
+
+func (r *InMemoryUserRepository) DeleteUser(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.users, id)
+	return nil
+}
+
+func (r *InMemoryUserRepository) UpdateUserRating(_ context.Context, id uint, score float64, raterID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	if r.ratingEvents == nil {
+		r.ratingEvents = map[uint][]models.UserRatingEvent{}
+	}
+	r.ratingEvents[id] = append(r.ratingEvents[id], models.UserRatingEvent{
+		ID: uint(len(r.ratingEvents[id])) + 1, UserID: id, RaterID: raterID, Score: score, CreatedAt: time.Now(),
+	})
+	user.RatingSum += score
+	user.RatingCount++
+	user.Rating = user.RatingSum / float64(user.RatingCount)
+	r.users[id] = user
+	return nil
+}
+
+func (r *InMemoryUserRepository) RecomputeUserRating(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	events := r.ratingEvents[id]
+	var sum float64
+	for _, e := range events {
+		sum += e.Score
+	}
+	user.RatingSum = sum
+	user.RatingCount = int64(len(events))
+	if len(events) > 0 {
+		user.Rating = sum / float64(len(events))
+	} else {
+		user.Rating = 0
+	}
+	r.users[id] = user
+	return nil
+}
+
+// GetRatingHistory returns up to limit of id's rating events, newest
+// first, mirroring the ORDER BY created_at DESC, id DESC the real
+// Postgres query uses.
+func (r *InMemoryUserRepository) GetRatingHistory(_ context.Context, id uint, limit int) ([]models.UserRatingEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := append([]models.UserRatingEvent(nil), r.ratingEvents[id]...)
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].CreatedAt.Equal(events[j].CreatedAt) {
+			return events[i].ID > events[j].ID
+		}
+		return events[i].CreatedAt.After(events[j].CreatedAt)
+	})
+	if limit > 0 && limit < len(events) {
+		events = events[:limit]
+	}
+	return events, nil
+}
+
+func (r *InMemoryUserRepository) DeleteRatingHistory(_ context.Context, id uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.ratingEvents, id)
+	return nil
+}
+
+func (r *InMemoryUserRepository) UserExists(_ context.Context, id uint) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.users[id]
+	return ok, nil
+}
+
+func (r *InMemoryUserRepository) UserExistsByTelegramID(_ context.Context, telegramID int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, u := range r.users {
+		if u.TelegramID == telegramID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *InMemoryUserRepository) GetUserFeatures(_ context.Context, id uint) (models.FeatureFlags, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if user.Features == nil {
+		return models.FeatureFlags{}, nil
+	}
+	return user.Features, nil
+}
+
+func (r *InMemoryUserRepository) SetUserFeature(_ context.Context, id uint, key string, value bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	if user.Features == nil {
+		user.Features = models.FeatureFlags{}
+	}
+	user.Features[key] = value
+	r.users[id] = user
+	return nil
+}
+
+func (r *InMemoryUserRepository) ListUsers(_ context.Context, afterID uint, limit int, excludeBots bool) ([]models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []models.User
+	for id, u := range r.users {
+		if id > afterID && !(excludeBots && u.IsBot) {
+			out = append(out, u)
+		}
+		if len(out) == limit {
+			break
+		}
+	}
+	return out, nil
+}
+
+// InMemoryPreferenceRepository is a concurrency-safe, in-process
+// stand-in for PostgresPreferenceRepository. It holds a reference to
+// the InMemoryUserRepository it shares a harness with, so FindUsersByTag
+// can join across the two fakes the same way the real query joins
+// preferences to users in Postgres.
+type InMemoryPreferenceRepository struct {
+	mu     sync.Mutex
+	byUser map[uint][]string
+	users  *InMemoryUserRepository
+}
+
+// NewInMemoryPreferenceRepository constructs an empty
+// InMemoryPreferenceRepository backed by users for FindUsersByTag joins.
+func NewInMemoryPreferenceRepository(users *InMemoryUserRepository) *InMemoryPreferenceRepository {
+	return &InMemoryPreferenceRepository{byUser: map[uint][]string{}, users: users}
+}
+
+func (r *InMemoryPreferenceRepository) ListPreferences(_ context.Context, userID uint) ([]models.Preference, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tags := r.byUser[userID]
+	prefs := make([]models.Preference, len(tags))
+	for i, tag := range tags {
+		prefs[i] = models.Preference{UserID: userID, Tag: tag}
+	}
+	return prefs, nil
+}
+
+func (r *InMemoryPreferenceRepository) hasPreferenceLocked(userID uint, tag string) bool {
+	for _, t := range r.byUser[userID] {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *InMemoryPreferenceRepository) AddPreference(_ context.Context, userID uint, tag string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hasPreferenceLocked(userID, tag) {
+		return nil
+	}
+	r.byUser[userID] = append(r.byUser[userID], tag)
+	return nil
+}
+
+func (r *InMemoryPreferenceRepository) AddPreferenceWithLimit(_ context.Context, userID uint, tag string, maxPreferences int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hasPreferenceLocked(userID, tag) {
+		return nil
+	}
+	if len(r.byUser[userID]) >= maxPreferences {
+		return repository.ErrPreferenceLimitExceeded
+	}
+	r.byUser[userID] = append(r.byUser[userID], tag)
+	return nil
+}
+
+func (r *InMemoryPreferenceRepository) DeleteAllForUser(_ context.Context, userID uint) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.byUser, userID)
+	return nil
+}
+
+func (r *InMemoryPreferenceRepository) ListUserIDsForTag(_ context.Context, tag string) ([]uint, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var ids []uint
+	for userID, tags := range r.byUser {
+		for _, t := range tags {
+			if t == tag {
+				ids = append(ids, userID)
+				break
+			}
+		}
+	}
+	return ids, nil
+}
+
+func (r *InMemoryPreferenceRepository) DeletePreferencesByTag(_ context.Context, tag string) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for userID, tags := range r.byUser {
+		kept := tags[:0]
+		for _, t := range tags {
+			if t == tag {
+				deleted++
+				continue
+			}
+			kept = append(kept, t)
+		}
+		r.byUser[userID] = kept
+	}
+	return deleted, nil
+}
+
+func (r *InMemoryPreferenceRepository) FindUsersByTag(ctx context.Context, tag string, limit, offset int) ([]models.User, error) {
+	r.mu.Lock()
+	var matchedIDs []uint
+	for userID, tags := range r.byUser {
+		for _, t := range tags {
+			if t == tag {
+				matchedIDs = append(matchedIDs, userID)
+				break
+			}
+		}
+	}
+	r.mu.Unlock()
+
+	matched := make([]models.User, 0, len(matchedIDs))
+	for _, userID := range matchedIDs {
+		user, err := r.users.GetUserByID(ctx, userID)
+		if err != nil {
+			continue
+		}
+		matched = append(matched, *user)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Rating > matched[j].Rating })
+
+	if offset >= len(matched) {
+		return nil, nil
+	}
+	matched = matched[offset:]
+	if limit > 0 && limit < len(matched) {
+		matched = matched[:limit]
+	}
+	return matched, nil
+}
@@ -0,0 +1,137 @@
+package integration
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ArtyomStepchuk03/JollyRogerUserService/proto/userpb"
+)
+
+func TestHarness_CreateUserThenGetUser(t *testing.T) {
+	users := NewInMemoryUserRepository()
+	h := NewHarness(t, users, NewInMemoryPreferenceRepository(users))
+	ctx := context.Background()
+
+	created, err := h.Users.CreateUser(ctx, &userpb.CreateUserRequest{TelegramId: 42, Username: "blackbeard"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	got, err := h.Users.GetUser(ctx, &userpb.GetUserRequest{UserId: created.Id})
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if got.Username != "blackbeard" {
+		t.Fatalf("expected username %q, got %q", "blackbeard", got.Username)
+	}
+}
+
+func TestHarness_GetUsersByTelegramIDsResolvesMultipleInOneQuery(t *testing.T) {
+	users := NewInMemoryUserRepository()
+	h := NewHarness(t, users, NewInMemoryPreferenceRepository(users))
+	ctx := context.Background()
+
+	blackbeard, err := h.Users.CreateUser(ctx, &userpb.CreateUserRequest{TelegramId: 42, Username: "blackbeard"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	anne, err := h.Users.CreateUser(ctx, &userpb.CreateUserRequest{TelegramId: 43, Username: "anne-bonny"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	resp, err := h.Users.GetUsersByTelegramIDs(ctx, &userpb.GetUsersByTelegramIDsRequest{
+		TelegramIds: []int64{42, 43, 99},
+	})
+	if err != nil {
+		t.Fatalf("GetUsersByTelegramIDs: %v", err)
+	}
+	if len(resp.Users) != 2 {
+		t.Fatalf("expected 2 resolved users, got %d", len(resp.Users))
+	}
+
+	byID := map[uint64]string{}
+	for _, u := range resp.Users {
+		byID[u.Id] = u.Username
+	}
+	if byID[blackbeard.Id] != "blackbeard" {
+		t.Fatalf("expected blackbeard to resolve, got %+v", byID)
+	}
+	if byID[anne.Id] != "anne-bonny" {
+		t.Fatalf("expected anne-bonny to resolve, got %+v", byID)
+	}
+}
+
+func TestHarness_FindUsersByTagReturnsOnlyTaggedUsersAndPaginates(t *testing.T) {
+	users := NewInMemoryUserRepository()
+	h := NewHarness(t, users, NewInMemoryPreferenceRepository(users))
+	ctx := context.Background()
+
+	blackbeard, err := h.Users.CreateUser(ctx, &userpb.CreateUserRequest{TelegramId: 42, Username: "blackbeard"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	anne, err := h.Users.CreateUser(ctx, &userpb.CreateUserRequest{TelegramId: 43, Username: "anne-bonny"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	if _, err := h.Users.CreateUser(ctx, &userpb.CreateUserRequest{TelegramId: 44, Username: "no-rum"}); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	if _, err := h.Users.AddUserPreference(ctx, &userpb.AddUserPreferenceRequest{UserId: blackbeard.Id, Tag: "rum"}); err != nil {
+		t.Fatalf("AddUserPreference: %v", err)
+	}
+	if _, err := h.Users.AddUserPreference(ctx, &userpb.AddUserPreferenceRequest{UserId: anne.Id, Tag: "rum"}); err != nil {
+		t.Fatalf("AddUserPreference: %v", err)
+	}
+
+	resp, err := h.Users.FindUsersByTag(ctx, &userpb.FindUsersByTagRequest{Tag: "rum"})
+	if err != nil {
+		t.Fatalf("FindUsersByTag: %v", err)
+	}
+	if len(resp.Users) != 2 {
+		t.Fatalf("expected 2 users tagged rum, got %d", len(resp.Users))
+	}
+
+	page, err := h.Users.FindUsersByTag(ctx, &userpb.FindUsersByTagRequest{Tag: "rum", Limit: 1})
+	if err != nil {
+		t.Fatalf("FindUsersByTag with limit: %v", err)
+	}
+	if len(page.Users) != 1 {
+		t.Fatalf("expected limit=1 to return 1 user, got %d", len(page.Users))
+	}
+}
+
+func TestHarness_GetPopularTagsRanksByPreferenceCount(t *testing.T) {
+	users := NewInMemoryUserRepository()
+	h := NewHarness(t, users, NewInMemoryPreferenceRepository(users))
+	ctx := context.Background()
+
+	blackbeard, err := h.Users.CreateUser(ctx, &userpb.CreateUserRequest{TelegramId: 42, Username: "blackbeard"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+	anne, err := h.Users.CreateUser(ctx, &userpb.CreateUserRequest{TelegramId: 43, Username: "anne-bonny"})
+	if err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	if _, err := h.Users.AddUserPreference(ctx, &userpb.AddUserPreferenceRequest{UserId: blackbeard.Id, Tag: "rum"}); err != nil {
+		t.Fatalf("AddUserPreference: %v", err)
+	}
+	if _, err := h.Users.AddUserPreference(ctx, &userpb.AddUserPreferenceRequest{UserId: anne.Id, Tag: "rum"}); err != nil {
+		t.Fatalf("AddUserPreference: %v", err)
+	}
+	if _, err := h.Users.AddUserPreference(ctx, &userpb.AddUserPreferenceRequest{UserId: anne.Id, Tag: "parrots"}); err != nil {
+		t.Fatalf("AddUserPreference: %v", err)
+	}
+
+	resp, err := h.Users.GetPopularTags(ctx, &userpb.GetPopularTagsRequest{Limit: 10})
+	if err != nil {
+		t.Fatalf("GetPopularTags: %v", err)
+	}
+	if len(resp.Tags) != 2 || resp.Tags[0].Tag != "rum" || resp.Tags[0].Count != 2 {
+		t.Fatalf("expected rum first with count 2, got %+v", resp.Tags)
+	}
+}